@@ -48,6 +48,9 @@ func NewApp() *App {
 
 	// Initialize settings service
 	a.settingsService = services.NewSettingsService()
+	if a.scanService != nil {
+		a.scanService.SetSettings(a.settingsService)
+	}
 	log.Println("✅ SettingsService initialized")
 
 	// Initialize update service
@@ -88,6 +91,19 @@ func (a *App) Scan(opts types.ScanOptions) error {
 	return a.scanService.Scan(opts)
 }
 
+func (a *App) ScanStream(opts types.ScanOptions) error {
+	if a.scanService == nil {
+		return nil
+	}
+	return a.scanService.ScanStream(opts)
+}
+
+func (a *App) CancelScan() {
+	if a.scanService != nil {
+		a.scanService.Cancel()
+	}
+}
+
 func (a *App) GetScanResults() []types.ScanResult {
 	if a.scanService == nil {
 		return []types.ScanResult{}
@@ -95,6 +111,13 @@ func (a *App) GetScanResults() []types.ScanResult {
 	return a.scanService.GetResults()
 }
 
+func (a *App) GetScanSummary() types.ScanSummary {
+	if a.scanService == nil {
+		return types.ScanSummary{ByType: map[types.CleanTargetType]int64{}}
+	}
+	return a.scanService.GetSummary()
+}
+
 func (a *App) IsScanning() bool {
 	if a.scanService == nil {
 		return false
@@ -121,7 +144,12 @@ func (a *App) Clean(items []types.ScanResult) ([]cleaner.CleanResult, error) {
 	if a.cleanService == nil {
 		return []cleaner.CleanResult{}, nil
 	}
-	return a.cleanService.Clean(items)
+	results, err := a.cleanService.Clean(items)
+	// Cached tree nodes of cleaned folders (and their parents) are stale
+	if a.treeService != nil {
+		a.treeService.InvalidateCleaned(results)
+	}
+	return results, err
 }
 
 func (a *App) IsCleaning() bool {
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/thanhdevapp/dev-cleaner/internal/scanner"
+	"github.com/thanhdevapp/dev-cleaner/internal/tui"
+	"github.com/thanhdevapp/dev-cleaner/internal/ui"
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+var (
+	analyzeDepth   int
+	analyzeTop     int
+	analyzeConfirm bool
+)
+
+// analyzeCmd lists the largest folders of any directory, for space the
+// ecosystem scanners don't know about
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze DIR",
+	Short: "Find the biggest folders in a directory, whatever they hold",
+	Long: `Walk DIR and list its largest folders by size, like a sorted du.
+
+Unlike scan, analyze does not look for known development artifacts: every
+folder counts, so it also finds downloads, VM images and other space the
+ecosystem scanners miss. Results open in the same TUI as scan, where they
+can be explored in tree mode. Any folder can be listed, so the TUI only
+previews deletions unless --confirm is given (review each one first).
+
+With --depth greater than 1, nested folders are listed too, so a folder's
+size includes the listed folders inside it.
+
+Examples:
+  dev-cleaner analyze ~                    # Largest folders in your home
+  dev-cleaner analyze ~/Library --depth 2  # Two levels deep
+  dev-cleaner analyze / --top 50 --no-tui  # Print the 50 largest
+  dev-cleaner analyze ~/Downloads --confirm  # Allow deleting from the TUI
+
+Flags:
+  --depth N         List folders down to N levels below DIR (default 1)
+  --top N           Only the N largest folders (default 20, 0 = all)
+  --confirm         Actually delete what is cleaned in the TUI (default: dry-run)
+  --no-tui, -T      Print the list instead of opening the TUI`,
+	Args: cobra.ExactArgs(1),
+	Run:  runAnalyze,
+}
+
+func init() {
+	rootCmd.AddCommand(analyzeCmd)
+
+	analyzeCmd.Flags().IntVar(&analyzeDepth, "depth", 1, "List folders down to this many levels below DIR")
+	analyzeCmd.Flags().IntVar(&analyzeTop, "top", 20, "Show only the N largest folders (0 = all)")
+	analyzeCmd.Flags().BoolVar(&analyzeConfirm, "confirm", false, "Actually delete what is cleaned in the TUI (default: dry-run)")
+	analyzeCmd.Flags().BoolP("no-tui", "T", false, "Print the list instead of opening the TUI")
+}
+
+func runAnalyze(cmd *cobra.Command, args []string) {
+	if analyzeDepth < 1 {
+		fmt.Fprintln(os.Stderr, "Error: --depth must be 1 or greater")
+		os.Exit(1)
+	}
+	if analyzeTop < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --top must be 0 or greater")
+		os.Exit(1)
+	}
+
+	roots, err := resolveRoots(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	dir := roots[0]
+
+	s, err := scanner.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	noTUI, _ := cmd.Flags().GetBool("no-tui")
+	if noTUI {
+		ui.PrintHeader(os.Stdout, "Analyzing "+dir+"...")
+	}
+
+	analyze := func() ([]types.ScanResult, error) {
+		results, err := s.Analyze(dir, analyzeDepth)
+		if analyzeTop > 0 && len(results) > analyzeTop {
+			results = results[:analyzeTop]
+		}
+		return results, err
+	}
+	results, err := analyze()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error analyzing %s: %v\n", dir, err)
+		os.Exit(1)
+	}
+
+	if !noTUI {
+		// Rescans re-run analyze rather than an ecosystem scan
+		tuiOpts := tuiOptions(types.DefaultScanOptions())
+		tuiOpts.Rescan = analyze
+		if err := tui.RunWithOptions(results, !analyzeConfirm, Version, tuiOpts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	ui.PrintResults(os.Stdout, results)
+	// Nested folders would be counted twice in a total
+	if analyzeDepth == 1 {
+		ui.PrintSummary(os.Stdout, results, 0)
+	}
+}
@@ -4,32 +4,64 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/thanhdevapp/dev-cleaner/internal/cleaner"
 	"github.com/thanhdevapp/dev-cleaner/internal/scanner"
+	"github.com/thanhdevapp/dev-cleaner/internal/services"
 	"github.com/thanhdevapp/dev-cleaner/internal/tui"
 	"github.com/thanhdevapp/dev-cleaner/internal/ui"
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
 
 var (
-	dryRun          bool
-	confirmFlag     bool
-	cleanIOS        bool
-	cleanAndroid    bool
-	cleanNode       bool
+	dryRun           bool
+	confirmFlag      bool
+	cleanIOS         bool
+	cleanAndroid     bool
+	cleanNode        bool
 	cleanReactNative bool
-	cleanFlutter    bool
-	cleanPython     bool
-	cleanRust       bool
-	cleanGo         bool
-	cleanHomebrew   bool
-	cleanDocker     bool
-	cleanJava       bool
-	useTUI          bool
+	cleanFlutter     bool
+	cleanPython      bool
+	cleanRust        bool
+	cleanGo          bool
+	cleanHomebrew    bool
+	cleanDocker      bool
+	cleanJava        bool
+	cleanDeno        bool
+	cleanDotNet      bool
+	cleanPHP         bool
+	cleanElixir      bool
+	cleanHaskell     bool
+	cleanJetBrains   bool
+	useTUI           bool
+	cleanDeep        bool
+	cleanGlobalsOnly bool
+	cleanParallel    int
+	cleanFollow      bool
+	cleanMaxDepth    int
+	cleanRootDepth   int
+	cleanHidden      bool
+	cleanPaths       []string
+	cleanExcludeGlob []string
+	cleanUseDu       bool
+	cleanNoDaemon    bool
+	cleanOlderThan   string
+	cleanAll         bool
+	cleanAuto        bool
+	assumeYes        bool
+	cleanNoCache     bool
+	cleanProtect     int
+	cleanKeepRecent  int
+	cleanResume      bool
+	cleanExclude     []string
+	cleanTimeout     time.Duration
+	cleanPreviewLog  bool
+	cleanPrune       bool
 )
 
 // cleanCmd represents the clean command
@@ -40,6 +72,8 @@ var cleanCmd = &cobra.Command{
 
 By default, runs in TUI mode with interactive selection and dry-run
 enabled (preview only). Use --confirm to actually delete files.
+Without category flags, "scanCategories" from the settings file is used
+when present (see 'dev-cleaner scan --help'); --all overrides it.
 
 The TUI provides:
   • Real-time deletion progress with package-manager style output
@@ -59,6 +93,11 @@ Examples:
   dev-cleaner clean --no-tui          # Simple text mode
   dev-cleaner clean --ios --confirm   # Clean iOS artifacts only
   dev-cleaner clean --node            # Preview Node.js cleanup (dry-run)
+  dev-cleaner clean -T --confirm --yes  # Fully non-interactive delete
+  dev-cleaner clean --globals-only    # Shared caches only, no project dirs
+  dev-cleaner clean --auto            # Only ecosystems installed on this machine
+  dev-cleaner clean --ios --keep-recent 2  # Select all but the 2 newest versions
+  dev-cleaner clean --resume --confirm  # Finish an interrupted cleanup
 
 Flags:
   --confirm         Actually delete files (disables dry-run)
@@ -73,8 +112,43 @@ Flags:
   --homebrew        Clean Homebrew caches
   --docker          Clean Docker images, containers, volumes
   --java            Clean Maven/Gradle caches
+  --deno            Clean Deno caches
+  --dotnet          Clean NuGet caches, .NET bin/obj, Unity Library
+  --php             Clean Composer cache and vendor directories
+  --elixir          Clean Hex/Mix caches and Mix _build/deps
+  --haskell         Clean Stack/Cabal caches, .stack-work and dist-newstyle
+  --jetbrains       Clean JetBrains IDE caches, indexes and logs
+  --deep            List global cache subfolders (e.g. ~/.npm/_cacache) separately
+  --include-hidden  Also search hidden folders directly under project roots
+  --path DIR        Also search and allow cleaning below DIR, e.g. /Volumes/Work (repeatable)
+  --all             Clean all categories, ignoring scanCategories in settings
+  --auto            Clean only ecosystems whose toolchain is installed
+  --exclude-type T  Skip category T, e.g. docker (repeatable); alone it means all but T
+  --exclude-glob P  Never offer paths at or below matches of P, e.g. '~/work/important-*/target' (repeatable)
+  --globals-only    Only clean global caches, skip project directories
+  --parallel-scan-limit N  Run at most N category scans at once (1 = serial)
+  --scan-root-depth N  Search N levels below each project root (default 3; monorepos may need 5-6)
+  --max-depth N     Levels tree mode descends below an item (default 5)
+  --follow-symlinks Descend into symlinked folders while searching for projects
+  --older-than AGE  Offer only Xcode Archives date folders older than AGE, e.g. 180d
+  --protect-active DAYS  Require extra confirmation for projects edited in the last DAYS
+  --danger-size SIZE, --danger-count N  Type DELETE to confirm more than this (default 50GB, 500 items)
+  --page-size N     Rows per page of the TUI list (default 50), PgUp/PgDn switch pages
+  --keep-recent N   Pre-select all but the N newest DerivedData, DeviceSupport and system image versions
+  --resume          Continue an interrupted cleanup instead of scanning (items still left, all selected)
+  --no-cache        Walk every folder instead of reusing sizes of unchanged ones
+  --use-du          Size folders with du -sk (faster on huge APFS trees, no file counts)
+  --timeout D       Stop scanning after D (default 5m, 0 = never) and offer partial results
   --no-tui, -T      Disable TUI, use simple text mode
   --tui             Use interactive TUI mode (default: true)
+  --yes, -y         Select all and skip the typed 'yes' prompt (requires --no-tui)
+  --preview-log     Show the exact log entries before confirming, for auditing
+  --interactive-exclude  Before selecting, drop items by number and optionally save them to excludePaths in settings (requires --no-tui)
+
+Headless (scripted) use:
+  --no-tui --confirm --yes   Delete all scanned items (except ones still in use) without any prompt
+  --no-tui --yes             No-op: still a dry-run, nothing is deleted
+  --yes without --no-tui     Error: the TUI cannot be auto-confirmed
 
 TUI Keyboard Shortcuts:
   c            Quick clean current item (ignores selections)
@@ -108,11 +182,86 @@ func init() {
 	cleanCmd.Flags().BoolVar(&cleanHomebrew, "homebrew", false, "Clean Homebrew caches")
 	cleanCmd.Flags().BoolVar(&cleanDocker, "docker", false, "Clean Docker images, containers, volumes")
 	cleanCmd.Flags().BoolVar(&cleanJava, "java", false, "Clean Maven/Gradle caches")
+	cleanCmd.Flags().BoolVar(&cleanDeno, "deno", false, "Clean Deno caches")
+	cleanCmd.Flags().BoolVar(&cleanDotNet, "dotnet", false, "Clean NuGet caches, .NET bin/obj and Unity Library")
+	cleanCmd.Flags().BoolVar(&cleanPHP, "php", false, "Clean Composer cache and vendor directories")
+	cleanCmd.Flags().BoolVar(&cleanElixir, "elixir", false, "Clean Hex/Mix/rebar3 caches and project _build/deps")
+	cleanCmd.Flags().BoolVar(&cleanHaskell, "haskell", false, "Clean Stack/Cabal caches and project .stack-work/dist-newstyle")
+	cleanCmd.Flags().BoolVar(&cleanJetBrains, "jetbrains", false, "Clean JetBrains IDE caches, indexes and logs")
+	cleanCmd.Flags().BoolVar(&cleanDeep, "deep", false, "Expand global caches into per-subfolder items")
+	cleanCmd.Flags().BoolVar(&cleanFollow, "follow-symlinks", false, "Follow symlinked directories while searching project roots (loops are detected)")
+	cleanCmd.Flags().IntVar(&cleanRootDepth, "scan-root-depth", types.DefaultProjectSearchDepth, "Directory levels searched below each project root for node_modules, target, etc.")
+	cleanCmd.Flags().IntVar(&cleanMaxDepth, "max-depth", types.DefaultTreeDepth, "Directory levels tree mode can descend below an item")
+	cleanCmd.Flags().IntVar(&cleanParallel, "parallel-scan-limit", 0, "Max category scans running at once (0 = all, 1 = serial for slow disks)")
+	cleanCmd.Flags().BoolVar(&cleanGlobalsOnly, "globals-only", false, "Only clean global caches (npm, gradle, pip, cargo...), skip project directories")
+	cleanCmd.Flags().BoolVar(&cleanAll, "all", false, "Clean all categories, ignoring scanCategories in settings")
+	cleanCmd.Flags().StringArrayVar(&cleanExclude, "exclude-type", nil, "Skip this category, e.g. docker or node (repeatable); without category flags all others are cleaned")
+	cleanCmd.Flags().StringArrayVar(&cleanExcludeGlob, "exclude-glob", nil, "Skip results at or below paths matching this pattern; ** spans folders, ~ is expanded (repeatable)")
+	cleanCmd.Flags().BoolVar(&cleanAuto, "auto", false, "Clean only ecosystems whose toolchain is installed (cargo, go, node... or their caches)")
+	cleanCmd.Flags().BoolVar(&cleanHidden, "include-hidden", false, "Also search hidden folders directly under project roots (e.g. ~/Projects/.archive)")
+	cleanCmd.Flags().StringArrayVar(&cleanPaths, "path", nil, "Also search this directory for projects (repeatable); cleaning below it is allowed")
+	cleanCmd.Flags().StringVar(&cleanOlderThan, "older-than", "", "List only Xcode Archives date folders older than this, e.g. 180d, 4w (other items are unaffected)")
+	cleanCmd.Flags().IntVar(&cleanProtect, "protect-active", 0, "Flag build output of projects with source edits in the last N days as in use (0 = off)")
+	cleanCmd.Flags().StringVar(&dangerSize, "danger-size", defaultDangerSize, "Deleting more than this in the TUI requires typing DELETE (0 = never)")
+	cleanCmd.Flags().IntVar(&dangerCount, "danger-count", defaultDangerCount, "Deleting more items than this in the TUI requires typing DELETE (0 = never)")
+	cleanCmd.Flags().BoolVar(&groupByProject, "group-by-project", false, "Group the TUI list by project, with a collapsible total per project (g toggles)")
+	cleanCmd.Flags().IntVar(&pageSize, "page-size", tui.DefaultPageSize, "Rows per page of the TUI list; PgUp/PgDn switch pages")
+	cleanCmd.Flags().IntVar(&cleanKeepRecent, "keep-recent", 0, "Pre-select all but the N most recently modified versions under DerivedData, DeviceSupport and system-images (0 = off)")
+	cleanCmd.Flags().BoolVar(&cleanResume, "resume", false, "Continue the last interrupted cleanup from ~/.dev-cleaner-resume.json instead of scanning")
+	cleanCmd.Flags().DurationVar(&cleanTimeout, "timeout", defaultScanTimeout, "Stop scanning after this long and offer partial results, e.g. 1m (0 = no limit)")
+	cleanCmd.Flags().BoolVar(&cleanUseDu, "use-du", false, "Size folders with du -sk instead of walking them in Go (no file counts; falls back if du fails)")
+	cleanCmd.Flags().BoolVar(&cleanNoDaemon, "assume-docker-stopped", false, "Don't query the Docker daemon; report the size of Docker Desktop's disk image instead")
+	cleanCmd.Flags().BoolVar(&cleanNoCache, "no-cache", false, "Ignore ~/.dev-cleaner-sizecache.json and walk every folder")
 	cleanCmd.Flags().BoolVar(&useTUI, "tui", true, "Use interactive TUI mode (default)")
 	cleanCmd.Flags().BoolP("no-tui", "T", false, "Disable TUI, use simple text mode")
+	cleanCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Select all and skip the 'yes' prompt (requires --no-tui, only deletes with --confirm)")
+	cleanCmd.Flags().BoolVar(&cleanPreviewLog, "preview-log", false, "Show the entries the cleanup will append to the log before confirming")
+	cleanCmd.Flags().BoolVar(&cleanPrune, "interactive-exclude", false, "Before selecting, drop items by number and optionally save them to excludePaths in the settings file (requires --no-tui)")
+}
+
+// validateCleanFlags checks flag combinations that would be unsafe or meaningless.
+//
+// The --yes interlock works as follows:
+//   - --yes --confirm --no-tui: selects all items, skips the typed "yes" prompt and deletes
+//   - --yes --no-tui (no --confirm): allowed, but forced to stay a dry-run,
+//     even if --dry-run=false was passed
+//   - --yes with the TUI: rejected, an interactive TUI can't be auto-confirmed
+func validateCleanFlags(noTUI bool) error {
+	if assumeYes && !noTUI {
+		return fmt.Errorf("--yes requires --no-tui: the interactive TUI cannot be auto-confirmed")
+	}
+	if cleanPrune && (!noTUI || assumeYes) {
+		return fmt.Errorf("--interactive-exclude requires --no-tui without --yes: it prompts for the items to exclude")
+	}
+	if assumeYes && !confirmFlag {
+		// Auto-confirming must never delete without an explicit --confirm
+		dryRun = true
+	}
+	return nil
 }
 
 func runClean(cmd *cobra.Command, args []string) {
+	if cleanParallel < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --parallel-scan-limit must be 0 or greater")
+		os.Exit(1)
+	}
+	if cleanRootDepth < 1 {
+		fmt.Fprintln(os.Stderr, "Error: --scan-root-depth must be 1 or greater (use --globals-only to skip project directories)")
+		os.Exit(1)
+	}
+	if cleanMaxDepth < 1 {
+		fmt.Fprintln(os.Stderr, "Error: --max-depth must be 1 or greater")
+		os.Exit(1)
+	}
+	if cleanTimeout < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --timeout must be 0 or greater")
+		os.Exit(1)
+	}
+	if cleanKeepRecent < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --keep-recent must be 0 or greater")
+		os.Exit(1)
+	}
+
 	// If --confirm is set, disable dry-run
 	if confirmFlag {
 		dryRun = false
@@ -124,20 +273,31 @@ func runClean(cmd *cobra.Command, args []string) {
 		useTUI = false
 	}
 
+	if err := validateCleanFlags(!useTUI); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if cleanResume {
+		runResume()
+		return
+	}
+
 	s, err := scanner.New()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing scanner: %v\n", err)
 		os.Exit(1)
 	}
+	if !cleanNoCache {
+		s.EnableSizeCache()
+	}
 
 	// Determine scan options
-	opts := types.ScanOptions{
-		MaxDepth: 3,
-	}
+	var opts types.ScanOptions
 
 	specificFlagSet := cleanIOS || cleanAndroid || cleanNode || cleanReactNative ||
 		cleanFlutter || cleanPython || cleanRust || cleanGo ||
-		cleanHomebrew || cleanDocker || cleanJava
+		cleanHomebrew || cleanDocker || cleanJava || cleanDeno || cleanDotNet || cleanPHP || cleanElixir || cleanHaskell || cleanJetBrains
 
 	if specificFlagSet {
 		opts.IncludeXcode = cleanIOS
@@ -151,49 +311,195 @@ func runClean(cmd *cobra.Command, args []string) {
 		opts.IncludeHomebrew = cleanHomebrew
 		opts.IncludeDocker = cleanDocker
 		opts.IncludeJava = cleanJava
-	} else {
+		opts.IncludeDeno = cleanDeno
+		opts.IncludeDotNet = cleanDotNet
+		opts.IncludePHP = cleanPHP
+		opts.IncludeElixir = cleanElixir
+		opts.IncludeHaskell = cleanHaskell
+		opts.IncludeJetBrains = cleanJetBrains
+	} else if cleanAuto {
+		opts = autoScanOptions(s)
+	} else if cleanAll || len(cleanExclude) > 0 {
+		// Like scan: excludes alone mean all other categories
 		opts = types.DefaultScanOptions()
+	} else {
+		opts = defaultScanOptions()
+	}
+	excludeTypes(&opts, cleanExclude)
+	opts.Deep = cleanDeep
+	opts.IncludeHiddenRoots = cleanHidden
+	if opts.ExtraRoots, err = resolveRoots(cleanPaths); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --path: %v\n", err)
+		os.Exit(1)
+	}
+	opts.GlobalsOnly = cleanGlobalsOnly
+	opts.Concurrency = cleanParallel
+	opts.ProjectSearchDepth = cleanRootDepth
+	opts.MaxDepth = cleanMaxDepth
+	opts.FollowSymlinks = cleanFollow
+	opts.ProtectActiveDays = cleanProtect
+	if err := scanner.ValidateGlobs(cleanExcludeGlob); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --exclude-glob: %v\n", err)
+		os.Exit(1)
+	}
+	opts.ExcludeGlobs = slices.Concat(cleanExcludeGlob, settingsExcludeGlobs())
+	opts.UseDu = cleanUseDu
+	opts.AssumeDockerStopped = cleanNoDaemon
+	if cleanOlderThan != "" {
+		if opts.ArchivesOlderThan, err = ui.ParseAge(cleanOlderThan); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --older-than: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// The TUI fills its list in as each category finishes scanning
+	if useTUI {
+		tuiOpts := tuiOptions(opts)
+		tuiOpts.KeepRecent = cleanKeepRecent
+		tuiOpts.PreviewLog = cleanPreviewLog
+		stream, report, timedOut := scanStream(s, opts, cleanTimeout)
+		tuiOpts.StreamReport = report
+		err := tui.RunStream(stream, dryRun, Version, tuiOpts)
+		saveSizeCache(s)
+		if timedOut() {
+			warnScanTimeout(cleanTimeout, report().Unfinished)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "TUI error: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	ui.PrintHeader("Scanning for development artifacts...")
+	ui.PrintHeader(os.Stdout, "Scanning for development artifacts...")
 
-	results, err := s.ScanAll(opts)
+	report, err := scanReport(s, opts, cleanTimeout)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error scanning: %v\n", err)
 		os.Exit(1)
 	}
+	results := report.Results
+	saveSizeCache(s)
+	ui.PrintScanErrors(os.Stdout, report.Errors, len(report.Timings))
 
 	if len(results) == 0 {
-		fmt.Println("\n  📭 No cleanable items found.")
+		ui.PrintNoResults(os.Stdout)
 		return
 	}
 
 	// Sort by size
 	sortBySize(results)
 
-	// Use TUI or simple mode
+	runSimpleMode(results, opts.ExtraRoots, report.DirsWalked)
+}
+
+// runResume continues the cleanup recorded in the resume file (--resume)
+// with the same TUI or text flow as a scan, all remaining items selected
+func runResume() {
+	path, err := cleaner.DefaultResumePath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error locating resume file: %v\n", err)
+		os.Exit(1)
+	}
+	resume, err := cleaner.LoadResume(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading resume file: %v\n", err)
+		os.Exit(1)
+	}
+	if len(resume.Remaining) == 0 {
+		fmt.Println("Nothing to resume: no interrupted cleanup was recorded.")
+		return
+	}
+
+	// Items deleted just before the interrupt are already gone
+	var items []types.ScanResult
+	for _, item := range resume.Remaining {
+		if _, err := os.Stat(item.Path); err == nil || types.IsPseudoPath(item.Path) {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		os.Remove(path)
+		fmt.Println("Nothing to resume: everything left by the interrupted cleanup is already gone.")
+		return
+	}
+	fmt.Printf("Resuming cleanup interrupted %s: %d items left (sizes as of then)\n",
+		resume.Saved.Format("Jan 2 15:04"), len(items))
+
 	if useTUI {
-		if err := tui.Run(results, dryRun, Version); err != nil {
+		opts := types.DefaultScanOptions()
+		opts.ExtraRoots = resume.AllowedRoots
+		tuiOpts := tuiOptions(opts)
+		tuiOpts.SelectAll = true
+		tuiOpts.PreviewLog = cleanPreviewLog
+		if err := tui.RunWithOptions(items, dryRun, Version, tuiOpts); err != nil {
 			fmt.Fprintf(os.Stderr, "TUI error: %v\n", err)
 			os.Exit(1)
 		}
-	} else {
-		runSimpleMode(results)
+		return
 	}
+
+	sortBySize(items)
+	runSimpleMode(items, resume.AllowedRoots, 0)
 }
 
-func runSimpleMode(results []types.ScanResult) {
-	// Print results with enhanced UI
-	ui.PrintResults(results)
-	ui.PrintSummary(results)
+// retentionSelection returns the 1-based item numbers --keep-recent selects,
+// as a comma-separated list for the simple mode prompt
+func retentionSelection(results []types.ScanResult, keep int) string {
+	if keep <= 0 {
+		return ""
+	}
+	older := scanner.ApplyRetention(results, keep)
+	var numbers []string
+	for i, r := range results {
+		if older[r.Path] {
+			numbers = append(numbers, strconv.Itoa(i+1))
+		}
+	}
+	return strings.Join(numbers, ",")
+}
 
-	// Interactive selection
-	fmt.Println("\n📋 Enter item numbers to clean (comma-separated), 'all' for everything, or 'q' to quit:")
-	fmt.Print("   > ")
+func runSimpleMode(results []types.ScanResult, allowedRoots []string, dirsWalked int64) {
+	// Print results with enhanced UI
+	ui.PrintResults(os.Stdout, results)
+	ui.PrintSummary(os.Stdout, results, dirsWalked)
 
 	reader := bufio.NewReader(os.Stdin)
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+
+	if cleanPrune {
+		if results = promptExcludes(reader, results); len(results) == 0 {
+			fmt.Println("Nothing left to clean.")
+			return
+		}
+	}
+
+	// Interactive selection (--yes answers "all" for fully headless runs, or
+	// the --keep-recent selection when given)
+	retained := retentionSelection(results, cleanKeepRecent)
+	var input string
+	if assumeYes && cleanKeepRecent > 0 {
+		if retained == "" {
+			fmt.Printf("\n📋 No older versions to clean (--keep-recent %d)\n", cleanKeepRecent)
+			return
+		}
+		fmt.Printf("\n📋 Selecting older versions %s (--keep-recent %d, --yes)\n", retained, cleanKeepRecent)
+		input = retained
+	} else if assumeYes {
+		fmt.Printf("\n📋 Selecting all %d items (--yes)\n", len(results))
+		input = "all"
+	} else {
+		fmt.Println("\n📋 Enter item numbers to clean (comma-separated), 'all' for everything, or 'q' to quit:")
+		if retained != "" {
+			fmt.Printf("   Press Enter for the older versions: %s (--keep-recent %d)\n", retained, cleanKeepRecent)
+		}
+		fmt.Print("   > ")
+
+		input, _ = reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" {
+			input = retained
+		}
+	}
 
 	if input == "q" || input == "quit" || input == "" {
 		fmt.Println("Cancelled.")
@@ -204,6 +510,17 @@ func runSimpleMode(results []types.ScanResult) {
 
 	if input == "all" || input == "a" {
 		selectedResults = results
+		// Headless runs never delete items that look actively used
+		if assumeYes {
+			selectedResults = nil
+			for _, r := range results {
+				if r.Risky {
+					fmt.Printf("   Skipping in-use item: %s\n", r.Path)
+					continue
+				}
+				selectedResults = append(selectedResults, r)
+			}
+		}
 	} else {
 		// Parse comma-separated numbers
 		parts := strings.Split(input, ",")
@@ -229,11 +546,19 @@ func runSimpleMode(results []types.ScanResult) {
 		totalSize += r.Size
 	}
 
+	// The exact entries the log will get, for review before confirming
+	if cleanPreviewLog {
+		printLogPreview(selectedResults, dryRun)
+	}
+
 	// Show warning
 	if dryRun {
-		ui.PrintDryRunWarning()
+		ui.PrintDryRunWarning(os.Stdout)
+	} else if assumeYes {
+		ui.PrintDeleteWarning(os.Stdout, len(selectedResults), totalSize)
+		fmt.Println("Confirmed via --yes.")
 	} else {
-		ui.PrintDeleteWarning(len(selectedResults), totalSize)
+		ui.PrintDeleteWarning(os.Stdout, len(selectedResults), totalSize)
 		fmt.Print("Type 'yes' to confirm: ")
 
 		confirmInput, _ := reader.ReadString('\n')
@@ -243,16 +568,117 @@ func runSimpleMode(results []types.ScanResult) {
 			fmt.Println("Cancelled.")
 			return
 		}
+
+		// Risky items (recently used venvs, a linked pnpm store, recently edited projects) need a second confirmation
+		var riskyCount int
+		for _, r := range selectedResults {
+			if r.Risky {
+				riskyCount++
+			}
+		}
+		if riskyCount > 0 {
+			fmt.Printf("⚠️  %d selected items may still be in use. Type 'yes' again to delete them: ", riskyCount)
+			confirmInput, _ = reader.ReadString('\n')
+			if strings.TrimSpace(confirmInput) != "yes" {
+				fmt.Println("Cancelled.")
+				return
+			}
+		}
+	}
+
+	cleanAndReport(selectedResults, allowedRoots, dryRun)
+}
+
+// promptExcludes lets the user drop items by number before selecting
+// (clean --interactive-exclude), reprinting the renumbered list after each
+// round, then offers to save the dropped paths to the settings file's
+// ExcludePaths so later scans skip them. It returns the remaining results.
+func promptExcludes(reader *bufio.Reader, results []types.ScanResult) []types.ScanResult {
+	var excluded []string
+	for len(results) > 0 {
+		fmt.Println("\n🚫 Enter item numbers to exclude (comma-separated), or press Enter to continue:")
+		fmt.Print("   > ")
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" {
+			break
+		}
+
+		drop := make(map[int]bool)
+		for _, part := range strings.Split(input, ",") {
+			part = strings.TrimSpace(part)
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 1 || idx > len(results) {
+				fmt.Printf("Invalid selection: %s\n", part)
+				continue
+			}
+			drop[idx-1] = true
+		}
+		if len(drop) == 0 {
+			continue
+		}
+
+		var kept []types.ScanResult
+		for i, r := range results {
+			if drop[i] {
+				excluded = append(excluded, r.Path)
+				continue
+			}
+			kept = append(kept, r)
+		}
+		results = kept
+		fmt.Printf("Excluded %d items.\n", len(drop))
+		if len(results) > 0 {
+			ui.PrintResults(os.Stdout, results)
+		}
 	}
 
-	// Perform cleaning
-	c, err := cleaner.New(dryRun)
+	if len(excluded) == 0 {
+		return results
+	}
+	fmt.Printf("\nAlways exclude these %d paths from future scans (saved to excludePaths in settings)? [y/N]: ", len(excluded))
+	answer, _ := reader.ReadString('\n')
+	if answer = strings.ToLower(strings.TrimSpace(answer)); answer == "y" || answer == "yes" {
+		if err := services.NewSettingsService().AddExcludePaths(excluded...); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save exclude paths: %v\n", err)
+		} else {
+			fmt.Printf("Saved %d exclude paths.\n", len(excluded))
+		}
+	}
+	return results
+}
+
+// printLogPreview prints the entries a cleanup of results will append to
+// the log (clean --preview-log)
+func printLogPreview(results []types.ScanResult, dryRun bool) {
+	logPath := logFile
+	if logPath == "" {
+		logPath, _ = cleaner.DefaultLogPath()
+	}
+	fmt.Printf("\nLog entries to be appended to %s:\n", logPath)
+	for _, line := range cleaner.PreviewLog(results, dryRun) {
+		fmt.Println("  " + line)
+	}
+	fmt.Println()
+}
+
+// cleanAndReport deletes results (or previews with dryRun), printing each
+// outcome, the freed total and, for real runs, the measured free space
+func cleanAndReport(selectedResults []types.ScanResult, allowedRoots []string, dryRun bool) {
+	c, err := cleaner.NewWithOptions(types.CleanOptions{DryRun: dryRun, LogPath: logFile, AllowedRoots: allowedRoots})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing cleaner: %v\n", err)
 		os.Exit(1)
 	}
 	defer c.Close()
 
+	// Measure free space around a real run to verify the summed sizes
+	paths := make([]string, len(selectedResults))
+	for i, r := range selectedResults {
+		paths[i] = r.Path
+	}
+	spaceBefore, spaceErr := cleaner.FreeSpace(paths)
+
 	fmt.Println()
 	cleanResults, err := c.Clean(selectedResults)
 	if err != nil {
@@ -261,26 +687,40 @@ func runSimpleMode(results []types.ScanResult) {
 	}
 
 	// Print results
-	var successCount int
-	var freedSpace int64
 	for _, result := range cleanResults {
 		if result.Success {
-			successCount++
-			freedSpace += result.Size
 			if result.WasDryRun {
-				fmt.Printf("  %s[DRY-RUN]%s Would delete: %s\n", ui.Yellow, ui.Reset, result.Path)
+				fmt.Printf("  %s Would delete: %s\n", ui.Colorize(ui.Yellow, "[DRY-RUN]"), result.Path)
+			} else if took := result.TookLabel(); took != "" {
+				fmt.Printf("  %s Deleted: %s (%s)\n", ui.Colorize(ui.Green, "✓"), result.Path, took)
 			} else {
-				fmt.Printf("  %s✓%s Deleted: %s\n", ui.Green, ui.Reset, result.Path)
+				fmt.Printf("  %s Deleted: %s\n", ui.Colorize(ui.Green, "✓"), result.Path)
 			}
 		} else {
-			fmt.Printf("  %s✗%s Failed: %s (%v)\n", ui.Red, ui.Reset, result.Path, result.Error)
+			fmt.Printf("  %s Failed: %s (%v)\n", ui.Colorize(ui.Red, "✗"), result.Path, result.Error)
+			if result.FreedSize > 0 {
+				fmt.Printf("    partially removed: %s of %s freed\n", ui.FormatSize(result.FreedSize), ui.FormatSize(result.Size))
+			}
 		}
 	}
 
-	fmt.Printf("\n%sCompleted!%s %d items processed", ui.Bold, ui.Reset, successCount)
+	successCount, freedSpace, notFreed := cleaner.SummarizeResults(cleanResults)
+	fmt.Printf("\n%s %d items processed", ui.Colorize(ui.Bold, "Completed!"), successCount)
 	if dryRun {
 		fmt.Printf(" (would free %s)\n", ui.FormatSize(freedSpace))
+	} else if notFreed > 0 {
+		fmt.Printf(" (%s freed, %s could not be removed)\n", ui.FormatSize(freedSpace), ui.FormatSize(notFreed))
 	} else {
 		fmt.Printf(" (%s freed)\n", ui.FormatSize(freedSpace))
 	}
+
+	if !dryRun && spaceErr == nil {
+		if spaceAfter, err := cleaner.FreeSpace(paths); err == nil {
+			check := cleaner.VerifySpace(spaceBefore, spaceAfter, cleanResults)
+			fmt.Printf("  %s\n", check)
+			if check.Diverges() {
+				fmt.Println(ui.Colorize(ui.Yellow, "  ⚠ Free space differs from the estimate (hard links, sparse files or other disk activity)"))
+			}
+		}
+	}
 }
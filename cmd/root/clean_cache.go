@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/thanhdevapp/dev-cleaner/internal/scanner"
+	"github.com/thanhdevapp/dev-cleaner/internal/ui"
+)
+
+var (
+	cacheConfirm bool
+	cacheDeep    bool
+)
+
+// cleanCacheCmd deletes a fixed list of known-safe global caches
+var cleanCacheCmd = &cobra.Command{
+	Use:   "clean-cache [flags]",
+	Short: "Clean known-safe global caches in one shot",
+	Long: `Delete only the global package and build caches that are always safe to
+remove, without scanning project directories or asking which items to pick.
+Everything here is re-downloaded or rebuilt on demand.
+
+Caches cleaned:
+  • npm, Yarn and Bun caches
+  • pnpm store (skipped while projects link into it)
+  • pip cache
+  • Cargo registry
+  • Go build cache
+  • Homebrew downloads
+  • Gradle wrapper distributions
+
+Runs as a dry-run unless --confirm is given; there is no other prompt.
+
+Examples:
+  dev-cleaner clean-cache             # Preview what would be freed
+  dev-cleaner clean-cache --confirm   # Weekly cleanup, e.g. from cron`,
+	Run: runCleanCache,
+}
+
+func init() {
+	rootCmd.AddCommand(cleanCacheCmd)
+
+	cleanCacheCmd.Flags().BoolVar(&cacheConfirm, "confirm", false, "Actually delete the caches (default is a dry-run)")
+	cleanCacheCmd.Flags().BoolVar(&cacheDeep, "deep", false, "List cache subfolders (e.g. ~/.npm/_cacache) separately")
+}
+
+func runCleanCache(cmd *cobra.Command, args []string) {
+	s, err := scanner.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing scanner: %v\n", err)
+		os.Exit(1)
+	}
+	s.SetDeep(cacheDeep)
+
+	ui.PrintHeader(os.Stdout, "Scanning safe global caches...")
+
+	results := s.ScanSafeCaches()
+	if len(results) == 0 {
+		ui.PrintNoResults(os.Stdout)
+		return
+	}
+	sortBySize(results)
+
+	if !cacheConfirm {
+		ui.PrintDryRunWarning(os.Stdout)
+	}
+	cleanAndReport(results, nil, !cacheConfirm)
+}
@@ -6,11 +6,22 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/thanhdevapp/dev-cleaner/internal/ui"
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
 
 var (
 	// Version is set at build time
 	Version = "1.0.3"
+
+	// quiet strips decorative output (headers, emoji, colors) in text mode
+	quiet bool
+
+	// siUnits shows sizes in 1000-based units, matching Finder (--si)
+	siUnits bool
+
+	// logFile overrides the cleaner log location (default ~/.dev-cleaner.log)
+	logFile string
 )
 
 // rootCmd represents the base command
@@ -43,10 +54,16 @@ Scan Examples:
   dev-cleaner scan --no-tui           # Text output without TUI
 
 Clean Examples:
+  dev-cleaner clean-cache --confirm   # Delete only known-safe global caches
   dev-cleaner clean                   # Interactive TUI (dry-run)
   dev-cleaner clean --confirm         # Interactive TUI (actually delete)
   dev-cleaner clean --ios --confirm   # Clean iOS artifacts only
   dev-cleaner clean --no-tui          # Simple text mode cleanup
+  dev-cleaner scan --no-tui --quiet   # Plain text output for piping
+  dev-cleaner scan --si               # Sizes in decimal units, as Finder shows them
+  dev-cleaner clean --log-file /tmp/dc.log  # Log deletions to a custom file
+  dev-cleaner stats                   # Total space freed so far
+  dev-cleaner version --check         # Check for a newer release
 
 TUI Keyboard Shortcuts:
   ↑/↓, k/j     Navigate up/down
@@ -54,9 +71,15 @@ TUI Keyboard Shortcuts:
   a            Select all items
   n            Deselect all items
   c            Quick clean current item (single-item mode)
+  o            Open current item in Finder
+  y            Copy current item's path to the clipboard
   Enter        Clean all selected items (batch mode)
   →/l          Drill down into folder (tree mode)
   ←/h          Go back to parent (in tree mode)
+  s            Sort tree by size or name (tree mode)
+  v            Visual mode (mark a range, Space toggles it)
+  t            Toggle treemap size chart
+  R            Rescan only the current item's category
   ?            Show detailed help screen
   q            Quit
 
@@ -73,5 +96,16 @@ func Execute() {
 }
 
 func init() {
-	// Global flags can be added here if needed
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress decorative output (headers, emoji, colors)")
+	rootCmd.PersistentFlags().BoolVar(&siUnits, "si", false, "Show and parse sizes (e.g. --fail-over 20GB) in decimal units (1 KB = 1000 bytes) like Finder, instead of 1024-based ones")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Log file path (default ~/.dev-cleaner.log, rotated at 5MB)")
+
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		ui.SetQuiet(quiet)
+		types.SetSI(siUnits)
+		startUpdateCheck(cmd)
+	}
+	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		printUpdateNotice()
+	}
 }
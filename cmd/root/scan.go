@@ -1,11 +1,23 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/thanhdevapp/dev-cleaner/internal/cleaner"
 	"github.com/thanhdevapp/dev-cleaner/internal/scanner"
+	"github.com/thanhdevapp/dev-cleaner/internal/services"
 	"github.com/thanhdevapp/dev-cleaner/internal/tui"
 	"github.com/thanhdevapp/dev-cleaner/internal/ui"
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
@@ -23,8 +35,53 @@ var (
 	scanHomebrew    bool
 	scanDocker      bool
 	scanJava        bool
+	scanDeno        bool
+	scanDotNet      bool
+	scanPHP         bool
+	scanElixir      bool
+	scanHaskell     bool
+	scanJetBrains   bool
 	scanAll         bool
+	scanAuto        bool
 	scanTUI         bool
+	scanDeep        bool
+	scanGlobalsOnly bool
+	scanParallel    int
+	scanFollow      bool
+	scanMaxDepth    int
+	scanRootDepth   int
+	scanHidden      bool
+	scanPaths       []string
+	scanExcludeGlob []string
+	scanUseDu       bool
+	scanFast        bool
+	scanNoDaemon    bool
+	scanTreeJSON    string
+	scanOlderThan   string
+	scanTiming      bool
+	scanFormat      string
+	scanFailOver    string
+	scanOutputFile  string
+	scanRecommend   bool
+	scanNoCache     bool
+	scanProtect     int
+	scanInteractive bool
+	scanChoose      bool
+	scanTop         int
+	scanExclude     []string
+	scanTimeout     time.Duration
+	scanDiff        bool
+	scanFindDupes   bool
+
+	// Shared by scan and clean: TUI deletions above these need DELETE typed
+	dangerSize  string
+	dangerCount int
+
+	// Shared by scan and clean: start the TUI grouped by project
+	groupByProject bool
+
+	// Shared by scan and clean: rows per page of the TUI list
+	pageSize int
 )
 
 // scanCmd represents the scan command
@@ -37,8 +94,12 @@ By default, scans all supported categories and opens interactive TUI
 for browsing, selection, and cleanup. The TUI provides tree navigation,
 keyboard shortcuts, and real-time deletion progress.
 
+Without category flags, the "scanCategories" list saved in the settings
+file (~/.dev-cleaner-gui.json) is used when present. Category flags or an
+explicit --all override it.
+
 Categories Scanned:
-  • Xcode (DerivedData, Archives, CoreSimulator, CocoaPods)
+  • Xcode (DerivedData, Archives, CoreSimulator, unavailable simulators, CocoaPods)
   • Android (Gradle caches, SDK system images)
   • Node.js (node_modules, npm/yarn/pnpm/bun caches)
   • React Native (metro cache, gradle, build artifacts)
@@ -49,10 +110,17 @@ Categories Scanned:
   • Homebrew (download caches)
   • Docker (unused images, containers, volumes, build cache)
   • Java/Kotlin (Maven .m2, Gradle caches, build directories)
+  • Deno (module cache, honors $DENO_DIR)
+  • .NET/Unity (NuGet packages, bin/obj, Unity Library)
+  • PHP (Composer cache, vendor directories)
+  • Elixir/Erlang (Hex/Mix/rebar3 caches, _build and deps)
+  • Haskell (Stack/Cabal caches, .stack-work and dist-newstyle)
+  • JetBrains IDEs (caches, indexes and logs per product)
 
 Examples:
   dev-cleaner scan                    # Scan all, launch TUI (default)
   dev-cleaner scan --ios              # Scan iOS/Xcode only
+  dev-cleaner scan --ios --older-than 180d  # Only Xcode archives from over 180 days ago
   dev-cleaner scan --android          # Scan Android only
   dev-cleaner scan --node             # Scan Node.js only
   dev-cleaner scan --rn               # Scan React Native only
@@ -63,7 +131,29 @@ Examples:
   dev-cleaner scan --homebrew         # Scan Homebrew only
   dev-cleaner scan --docker           # Scan Docker only
   dev-cleaner scan --java             # Scan Java/Maven/Gradle only
+  dev-cleaner scan --deno             # Scan Deno caches only
+  dev-cleaner scan --dotnet           # Scan .NET/NuGet and Unity only
+  dev-cleaner scan --php              # Scan PHP/Composer only
+  dev-cleaner scan --elixir           # Scan Elixir/Erlang only
+  dev-cleaner scan --haskell          # Scan Haskell/Stack/Cabal only
+  dev-cleaner scan --jetbrains        # Scan JetBrains IDE caches only
   dev-cleaner scan --no-tui           # Text output without TUI
+  dev-cleaner scan --interactive      # Pick ecosystems from a numbered list (text mode)
+  dev-cleaner scan --choose           # Toggle ecosystems in the TUI, then scan
+  dev-cleaner scan --node --deep      # Split npm/yarn/pnpm caches into subfolders
+  dev-cleaner scan --globals-only     # Fast: global caches only, no project dirs
+  dev-cleaner scan --auto             # Only ecosystems installed on this machine
+  dev-cleaner scan --exclude-type docker  # Everything except Docker
+  dev-cleaner scan --exclude-glob '~/work/important-*'  # Never list anything below these
+  dev-cleaner scan --no-tui --timing  # Show which category scan is slow
+  dev-cleaner scan --format=csv > usage.csv  # Export for spreadsheets
+  dev-cleaner scan --tree-json ~/Library/Caches > caches.json  # Nested folder sizes
+  dev-cleaner scan -q --fail-over 20GB  # Cron check: exit 2 above 20 GB
+  dev-cleaner scan --output-file scan.txt  # Save the text report (implies --no-tui)
+  dev-cleaner scan --recommend        # Group results by how safe they are to delete
+  dev-cleaner scan --top 10           # Only the 10 largest items, the rest as one total
+  dev-cleaner scan --timeout 1m       # Give up on slow or stalled disks after a minute
+  dev-cleaner scan --diff             # What is new, grown or gone since the last text scan
 
 Flags:
   --ios             Scan iOS/Xcode artifacts only
@@ -77,12 +167,51 @@ Flags:
   --homebrew        Scan Homebrew caches
   --docker          Scan Docker images, containers, volumes
   --java            Scan Maven/Gradle caches and build dirs
+  --deno            Scan Deno caches
+  --dotnet          Scan NuGet caches, .NET bin/obj, Unity Library
+  --php             Scan Composer cache and vendor directories
+  --elixir          Scan Hex/Mix caches and Mix _build/deps
+  --haskell         Scan Stack/Cabal caches, .stack-work and dist-newstyle
+  --jetbrains       Scan JetBrains IDE caches, indexes and logs
+  --deep            List global cache subfolders (e.g. ~/.npm/_cacache) separately
+  --include-hidden  Also search hidden folders directly under project roots
+  --path DIR        Also search DIR for projects, e.g. /Volumes/Work (repeatable)
+  --globals-only    Only scan global caches, skip project directories
+  --parallel-scan-limit N  Run at most N category scans at once (1 = serial)
+  --scan-root-depth N  Search N levels below each project root (default 3; monorepos may need 5-6)
+  --max-depth N     Levels tree mode descends below an item (default 5)
+  --follow-symlinks Descend into symlinked folders while searching for projects
+  --timing          Print how long each category took (text output only)
   --no-tui, -T      Disable TUI, show simple text output
-  --all             Scan all categories (default: true)
+  --interactive     Without category flags, pick ecosystems from a numbered list (implies --no-tui)
+  --choose          Open the TUI on a category checklist and scan what you pick
+  --format          Output format: table (default), json, csv (implies --no-tui)
+  --tree-json DIR   Print DIR's folder tree (sizes, children) as nested JSON, --max-depth levels deep
+  --fail-over SIZE  Exit with code 2 if reclaimable space exceeds SIZE (e.g. 20GB)
+  --output-file F   Write the report (any --format) to F instead of stdout
+  --recommend       Group the text report into safe / inactive-project / review tiers
+  --top N           List only the N largest items plus a total for the rest (implies --no-tui)
+  --diff            Show new, grown and gone items since the last text-mode scan (implies --no-tui)
+  --find-dupes      Also list projects whose package.json declare identical dependencies (implies --no-tui)
+  --older-than AGE  List only Xcode Archives date folders older than AGE, e.g. 180d
+  --protect-active DAYS  Mark build output of projects edited in the last DAYS as in use
+  --danger-size SIZE, --danger-count N  In the TUI, type DELETE to delete more (default 50GB, 500 items)
+  --page-size N     Rows per page of the TUI list (default 50), PgUp/PgDn switch pages
+  --no-cache        Walk every folder instead of reusing sizes of unchanged ones
+  --use-du          Size folders with du -sk (faster on huge APFS trees, no file counts)
+  --fast            Estimate sizes from a shallow walk for a quick rough total (marked ~)
+  --timeout D       Stop scanning after D (default 5m, 0 = never) and show partial results
+  --all             Scan all categories, ignoring scanCategories in settings
+  --auto            Scan only ecosystems whose toolchain is installed
+  --exclude-type T  Skip category T, e.g. docker (repeatable); alone it means all but T
+  --exclude-glob P  Skip results at or below paths matching P, e.g. '~/work/keep/**' (repeatable);
+                    a match inside a result, like node_modules/.cache, does not skip it
 
 TUI Features:
   • Navigate with arrow keys or vim bindings (k/j/h/l)
+  • Long lists are split into pages (--page-size, default 50): PgUp/PgDn switch pages, Home/End jump to first/last
   • Select items with Space, 'a' for all, 'n' for none
+  • Detail pane below the list shows the highlighted item's path, file count and age
   • Quick clean single item with 'c'
   • Batch clean selected items with Enter
   • Drill down into folders with → or 'l'
@@ -105,27 +234,118 @@ func init() {
 	scanCmd.Flags().BoolVar(&scanHomebrew, "homebrew", false, "Scan Homebrew caches")
 	scanCmd.Flags().BoolVar(&scanDocker, "docker", false, "Scan Docker images, containers, volumes")
 	scanCmd.Flags().BoolVar(&scanJava, "java", false, "Scan Maven/Gradle caches and build dirs")
-	scanCmd.Flags().BoolVar(&scanAll, "all", true, "Scan all categories (default)")
+	scanCmd.Flags().BoolVar(&scanDeno, "deno", false, "Scan Deno caches")
+	scanCmd.Flags().BoolVar(&scanDotNet, "dotnet", false, "Scan NuGet caches, .NET bin/obj and Unity Library")
+	scanCmd.Flags().BoolVar(&scanPHP, "php", false, "Scan Composer cache and vendor directories")
+	scanCmd.Flags().BoolVar(&scanElixir, "elixir", false, "Scan Hex/Mix/rebar3 caches and project _build/deps")
+	scanCmd.Flags().BoolVar(&scanHaskell, "haskell", false, "Scan Stack/Cabal caches and project .stack-work/dist-newstyle")
+	scanCmd.Flags().BoolVar(&scanJetBrains, "jetbrains", false, "Scan JetBrains IDE caches, indexes and logs")
+	scanCmd.Flags().BoolVar(&scanDeep, "deep", false, "Expand global caches into per-subfolder items")
+	scanCmd.Flags().BoolVar(&scanFollow, "follow-symlinks", false, "Follow symlinked directories while searching project roots (loops are detected)")
+	scanCmd.Flags().IntVar(&scanRootDepth, "scan-root-depth", types.DefaultProjectSearchDepth, "Directory levels searched below each project root for node_modules, target, etc.")
+	scanCmd.Flags().IntVar(&scanMaxDepth, "max-depth", types.DefaultTreeDepth, "Directory levels tree mode can descend below an item")
+	scanCmd.Flags().IntVar(&scanParallel, "parallel-scan-limit", 0, "Max category scans running at once (0 = all, 1 = serial for slow disks)")
+	scanCmd.Flags().BoolVar(&scanGlobalsOnly, "globals-only", false, "Only scan global caches (npm, gradle, pip, cargo...), skip project directories")
+	scanCmd.Flags().BoolVar(&scanHidden, "include-hidden", false, "Also search hidden folders directly under project roots (e.g. ~/Projects/.archive)")
+	scanCmd.Flags().StringArrayVar(&scanPaths, "path", nil, "Also search this directory for projects (repeatable); cleaning below it is allowed")
+	scanCmd.Flags().BoolVar(&scanTiming, "timing", false, "Print per-category scan durations (with --no-tui)")
+	scanCmd.Flags().BoolVar(&scanAll, "all", true, "Scan all categories (default; explicit --all ignores saved settings)")
+	scanCmd.Flags().StringArrayVar(&scanExclude, "exclude-type", nil, "Skip this category, e.g. docker or node (repeatable); without category flags all others are scanned")
+	scanCmd.Flags().StringArrayVar(&scanExcludeGlob, "exclude-glob", nil, "Skip results at or below paths matching this pattern; ** spans folders, ~ is expanded (repeatable)")
+	scanCmd.Flags().BoolVar(&scanAuto, "auto", false, "Scan only ecosystems whose toolchain is installed (cargo, go, node... or their caches)")
 	scanCmd.Flags().BoolVar(&scanTUI, "tui", true, "Launch interactive TUI (default)")
 	scanCmd.Flags().BoolP("no-tui", "T", false, "Disable TUI, show text output")
+	scanCmd.Flags().BoolVar(&scanInteractive, "interactive", false, "Choose ecosystems to scan from a numbered prompt when no category flag is given (implies --no-tui)")
+	scanCmd.Flags().BoolVar(&scanChoose, "choose", false, "Open the TUI on a category checklist (preselected from flags or settings) and scan the chosen ones")
+	scanCmd.Flags().StringVar(&scanFailOver, "fail-over", "", "Exit with code 2 if reclaimable space exceeds this size, e.g. 20GB (implies --no-tui)")
+	scanCmd.Flags().StringVar(&scanOutputFile, "output-file", "", "Write the report to this file instead of stdout (implies --no-tui)")
+	scanCmd.Flags().StringVar(&scanOlderThan, "older-than", "", "List only Xcode Archives date folders older than this, e.g. 180d, 4w (other items are unaffected)")
+	scanCmd.Flags().StringVar(&dangerSize, "danger-size", defaultDangerSize, "Deleting more than this in the TUI requires typing DELETE (0 = never)")
+	scanCmd.Flags().IntVar(&dangerCount, "danger-count", defaultDangerCount, "Deleting more items than this in the TUI requires typing DELETE (0 = never)")
+	scanCmd.Flags().BoolVar(&groupByProject, "group-by-project", false, "Group the TUI list by project, with a collapsible total per project (g toggles)")
+	scanCmd.Flags().IntVar(&pageSize, "page-size", tui.DefaultPageSize, "Rows per page of the TUI list; PgUp/PgDn switch pages")
+	scanCmd.Flags().IntVar(&scanProtect, "protect-active", 0, "Flag build output of projects with source edits in the last N days as in use (0 = off)")
+	scanCmd.Flags().BoolVar(&scanNoCache, "no-cache", false, "Ignore ~/.dev-cleaner-sizecache.json and walk every folder")
+	scanCmd.Flags().BoolVar(&scanUseDu, "use-du", false, "Size folders with du -sk instead of walking them in Go (no file counts; falls back if du fails)")
+	scanCmd.Flags().BoolVar(&scanFast, "fast", false, "Estimate sizes by walking only a few levels of each folder (approximate, shown with ~)")
+	scanCmd.Flags().BoolVar(&scanNoDaemon, "assume-docker-stopped", false, "Don't query the Docker daemon; report the size of Docker Desktop's disk image instead")
+	scanCmd.Flags().DurationVar(&scanTimeout, "timeout", defaultScanTimeout, "Stop scanning after this long and show partial results, e.g. 1m (0 = no limit)")
+	scanCmd.Flags().IntVar(&scanTop, "top", 0, "List only the N largest results, summing up the rest in one line (implies --no-tui)")
+	scanCmd.Flags().BoolVar(&scanDiff, "diff", false, "Show items that are new, grown or gone since the last text-mode scan (implies --no-tui)")
+	scanCmd.Flags().BoolVar(&scanFindDupes, "find-dupes", false, "Also list projects whose package.json declare the same direct dependencies, i.e. near-identical node_modules (implies --no-tui)")
+	scanCmd.Flags().BoolVar(&scanRecommend, "recommend", false, "Group results by safety tier: safe, inactive project, review first (implies --no-tui)")
+	scanCmd.Flags().StringVar(&scanTreeJSON, "tree-json", "", "Print this directory's folder tree, --max-depth levels deep, as nested JSON instead of scanning categories")
+	scanCmd.Flags().StringVar(&scanFormat, "format", ui.FormatTable, "Output format: table, json, csv (json/csv imply --no-tui)")
 }
 
 func runScan(cmd *cobra.Command, args []string) {
+	if err := ui.ValidateFormat(scanFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if scanParallel < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --parallel-scan-limit must be 0 or greater")
+		os.Exit(1)
+	}
+	if scanRootDepth < 1 {
+		fmt.Fprintln(os.Stderr, "Error: --scan-root-depth must be 1 or greater (use --globals-only to skip project directories)")
+		os.Exit(1)
+	}
+	if scanMaxDepth < 1 {
+		fmt.Fprintln(os.Stderr, "Error: --max-depth must be 1 or greater")
+		os.Exit(1)
+	}
+	if scanTimeout < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --timeout must be 0 or greater")
+		os.Exit(1)
+	}
+	if scanTop < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --top must be 0 or greater")
+		os.Exit(1)
+	}
+	var failOver int64
+	if scanFailOver != "" {
+		var err error
+		if failOver, err = types.ParseSize(scanFailOver); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --fail-over: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	// Machine-readable formats never launch the TUI or print decorations
+	machineOutput := scanFormat != ui.FormatTable
+	if scanDiff && machineOutput {
+		fmt.Fprintln(os.Stderr, "Error: --diff only works with --format=table")
+		os.Exit(1)
+	}
+	if scanFindDupes && machineOutput {
+		fmt.Fprintln(os.Stderr, "Error: --find-dupes only works with --format=table")
+		os.Exit(1)
+	}
+
 	s, err := scanner.New()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing scanner: %v\n", err)
 		os.Exit(1)
 	}
+	if !scanNoCache {
+		s.EnableSizeCache()
+	}
 
-	// Determine scan options
-	opts := types.ScanOptions{
-		MaxDepth: 3,
+	// --tree-json exports one folder's hierarchy instead of a category scan
+	if scanTreeJSON != "" {
+		writeTreeJSON(s, scanTreeJSON)
+		return
 	}
 
-	// If any specific flag is set, use only those
+	// Determine scan options
+	var opts types.ScanOptions
+
+	// Category flags mean only those categories. Otherwise the base is the
+	// --interactive prompt, --auto, all (explicit --all or any
+	// --exclude-type) or the settings file. --exclude-type applies last.
 	specificFlagSet := scanIOS || scanAndroid || scanNode || scanReactNative ||
 		scanFlutter || scanPython || scanRust || scanGo ||
-		scanHomebrew || scanDocker || scanJava
+		scanHomebrew || scanDocker || scanJava || scanDeno || scanDotNet || scanPHP || scanElixir || scanHaskell || scanJetBrains
 
 	if specificFlagSet {
 		opts.IncludeXcode = scanIOS
@@ -139,46 +359,502 @@ func runScan(cmd *cobra.Command, args []string) {
 		opts.IncludeHomebrew = scanHomebrew
 		opts.IncludeDocker = scanDocker
 		opts.IncludeJava = scanJava
-	} else {
-		// Default: scan all
+		opts.IncludeDeno = scanDeno
+		opts.IncludeDotNet = scanDotNet
+		opts.IncludePHP = scanPHP
+		opts.IncludeElixir = scanElixir
+		opts.IncludeHaskell = scanHaskell
+		opts.IncludeJetBrains = scanJetBrains
+	} else if scanInteractive {
+		opts = promptScanOptions()
+	} else if scanAuto {
+		opts = autoScanOptions(s)
+	} else if (scanAll && cmd.Flags().Changed("all")) || len(scanExclude) > 0 {
+		// Explicit --all ignores categories saved in settings
 		opts = types.DefaultScanOptions()
+	} else {
+		// Default: categories from settings, or all
+		opts = defaultScanOptions()
+	}
+	excludeTypes(&opts, scanExclude)
+	opts.Deep = scanDeep
+	opts.IncludeHiddenRoots = scanHidden
+	if opts.ExtraRoots, err = resolveRoots(scanPaths); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --path: %v\n", err)
+		os.Exit(1)
+	}
+	opts.GlobalsOnly = scanGlobalsOnly
+	opts.Concurrency = scanParallel
+	opts.ProjectSearchDepth = scanRootDepth
+	opts.MaxDepth = scanMaxDepth
+	opts.FollowSymlinks = scanFollow
+	opts.ProtectActiveDays = scanProtect
+	if err := scanner.ValidateGlobs(scanExcludeGlob); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --exclude-glob: %v\n", err)
+		os.Exit(1)
+	}
+	opts.ExcludeGlobs = slices.Concat(scanExcludeGlob, settingsExcludeGlobs())
+	opts.UseDu = scanUseDu
+	opts.EstimateOnly = scanFast
+	opts.AssumeDockerStopped = scanNoDaemon
+	if scanOlderThan != "" {
+		if opts.ArchivesOlderThan, err = ui.ParseAge(scanOlderThan); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --older-than: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	ui.PrintHeader("Scanning for development artifacts...")
+	// Check for --no-tui flag
+	noTUI, _ := cmd.Flags().GetBool("no-tui")
+	if noTUI || machineOutput || scanFailOver != "" || scanOutputFile != "" || scanRecommend || scanInteractive || scanTop > 0 || scanDiff || scanFindDupes {
+		scanTUI = false
+	}
 
-	results, err := s.ScanAll(opts)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error scanning: %v\n", err)
-		os.Exit(1)
+	// --choose lets the TUI pick the categories and run the scan itself
+	if scanTUI && scanChoose {
+		tuiOpts := tuiOptions(opts)
+		timedOut := func() bool { return false }
+		report := func() types.ScanReport { return types.ScanReport{} }
+		tuiOpts.StartScan = func(chosen types.ScanOptions) (<-chan types.ScanResult, func() types.ScanReport) {
+			var stream <-chan types.ScanResult
+			stream, report, timedOut = scanStream(s, chosen, scanTimeout)
+			return stream, report
+		}
+		err := tui.RunCategories(false, Version, tuiOpts)
+		saveSizeCache(s)
+		if timedOut() {
+			warnScanTimeout(scanTimeout, report().Unfinished)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	if len(results) == 0 {
-		fmt.Println("\n  📭 No cleanable items found.")
+	// Launch TUI by default, filling the list in as each category finishes
+	if scanTUI {
+		tuiOpts := tuiOptions(opts)
+		stream, report, timedOut := scanStream(s, opts, scanTimeout)
+		tuiOpts.StreamReport = report
+		err := tui.RunStream(stream, false, Version, tuiOpts)
+		saveSizeCache(s)
+		if timedOut() {
+			warnScanTimeout(scanTimeout, report().Unfinished)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
+			os.Exit(1)
+		}
 		return
 	}
 
+	// Report goes to stdout, or to --output-file; errors always go to stderr
+	var out io.Writer = os.Stdout
+	if scanOutputFile != "" {
+		file, err := os.Create(scanOutputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --output-file: %v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if !machineOutput {
+		ui.PrintHeader(out, "Scanning for development artifacts...")
+	}
+
+	report, err := scanReport(s, opts, scanTimeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error scanning: %v\n", err)
+		os.Exit(1)
+	}
+	results := report.Results
+	saveSizeCache(s)
+
 	// Sort by size (largest first)
 	sortBySize(results)
 
-	// Check for --no-tui flag
-	noTUI, _ := cmd.Flags().GetBool("no-tui")
-	if noTUI {
-		scanTUI = false
+	// Snapshot complete scans for the next --diff; a timed-out scan would
+	// show everything it missed as gone
+	var last scanner.LastScan
+	saved := len(report.Unfinished) == 0
+	if saved {
+		last = updateLastScan(opts, results)
 	}
 
-	// Launch TUI by default
-	if scanTUI {
-		if err := tui.Run(results, false, Version); err != nil {
-			fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
+	// Machine-readable output keeps stdout clean, so timing and notes go
+	// to stderr
+	if machineOutput {
+		if scanTiming {
+			ui.PrintTimings(os.Stderr, report.Timings)
+		}
+		ui.PrintNotes(os.Stderr, report.Notes)
+		ui.PrintScanErrors(os.Stderr, report.Errors, len(report.Timings))
+	}
+
+	switch scanFormat {
+	case ui.FormatJSON:
+		if err := ui.WriteJSON(out, results); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing JSON: %v\n", err)
+			os.Exit(1)
+		}
+		exitIfOver(results, failOver)
+		return
+	case ui.FormatCSV:
+		if err := ui.WriteCSV(out, results); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing CSV: %v\n", err)
 			os.Exit(1)
 		}
+		exitIfOver(results, failOver)
+		return
+	}
+
+	if len(results) == 0 && !scanDiff {
+		ui.PrintNoResults(out)
+		if scanTiming {
+			ui.PrintTimings(out, report.Timings)
+		}
+		ui.PrintNotes(out, report.Notes)
+		ui.PrintScanErrors(out, report.Errors, len(report.Timings))
 		return
 	}
 
 	// Print results with enhanced UI
-	ui.PrintResults(results)
-	ui.PrintSummary(results)
-	ui.PrintFooter()
+	if scanDiff {
+		printDiff(out, opts, results, last, saved)
+	} else if scanRecommend {
+		ui.PrintRecommendations(out, results)
+	} else {
+		ui.PrintTopResults(out, results, scanTop)
+	}
+	// Read-only insight: only package.json files are read
+	if scanFindDupes && opts.IncludeNode {
+		ui.PrintDuplicates(out, scanner.FindDuplicateNodeModules(results))
+	} else if scanFindDupes {
+		ui.PrintNotes(out, []string{"--find-dupes compares node_modules, but Node.js was not scanned"})
+	}
+	ui.PrintSummary(out, results, report.DirsWalked)
+	if scanTiming {
+		ui.PrintTimings(out, report.Timings)
+	}
+	ui.PrintNotes(out, report.Notes)
+	ui.PrintScanErrors(out, report.Errors, len(report.Timings))
+	ui.PrintFooter(out)
+	exitIfOver(results, failOver)
+}
+
+// exitIfOver exits with code 2 when the total reclaimable size exceeds
+// limit (--fail-over), so monitoring scripts can alert without parsing
+// output. A zero limit disables the check.
+func exitIfOver(results []types.ScanResult, limit int64) {
+	if limit <= 0 {
+		return
+	}
+	if total := cleaner.TotalSize(results); total > limit {
+		fmt.Fprintf(os.Stderr, "Reclaimable space %s exceeds --fail-over %s\n", ui.FormatSize(total), ui.FormatSize(limit))
+		os.Exit(2)
+	}
+}
+
+// updateLastScan replaces the scanned categories in the last scan snapshot
+// (~/.dev-cleaner-lastscan.json) with results and returns the previous
+// snapshot. Like the size cache it is optional, so errors are warnings.
+func updateLastScan(opts types.ScanOptions, results []types.ScanResult) scanner.LastScan {
+	path, err := scanner.DefaultLastScanPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not locate last scan file: %v\n", err)
+		return scanner.LastScan{}
+	}
+	last, err := scanner.LoadLastScan(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if err := scanner.SaveLastScan(path, last.Update(results, opts)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save last scan: %v\n", err)
+	}
+	return last
+}
+
+// printDiff prints what changed since the last snapshot (--diff), limited
+// to the categories this scan covered. saved is false for a timed-out scan,
+// which is neither compared nor kept as the next snapshot.
+func printDiff(w io.Writer, opts types.ScanOptions, results []types.ScanResult, last scanner.LastScan, saved bool) {
+	if !saved {
+		ui.PrintNotes(w, []string{"Scan timed out, so it isn't compared or saved for the next --diff"})
+		return
+	}
+	if last.Saved.IsZero() {
+		ui.PrintNotes(w, []string{"No previous scan to compare with; this one is saved for the next --diff"})
+		return
+	}
+	ui.PrintDiff(w, scanner.DiffResults(last.Within(opts), results), last.Saved)
+}
+
+// defaultScanTimeout bounds a scan (--timeout) so a stalled network mount
+// can't hang the tool forever
+const defaultScanTimeout = 5 * time.Minute
+
+// scanContext returns the context of a scan limited to timeout (0 = none)
+func scanContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// scanReport scans like ScanAllReport within timeout. Hitting the deadline
+// is not an error: it warns which categories did not finish and returns
+// the partial report.
+func scanReport(s *scanner.Scanner, opts types.ScanOptions, timeout time.Duration) (types.ScanReport, error) {
+	ctx, cancel := scanContext(timeout)
+	defer cancel()
+
+	// Text mode shows nothing else until the scan is done; the spinner goes
+	// to stderr so stdout (e.g. JSON) stays clean
+	stopSpinner := ui.StartSpinner(os.Stderr, "Scanning...")
+	report, err := s.ScanAllReportContext(ctx, opts)
+	stopSpinner()
+	if errors.Is(err, context.DeadlineExceeded) {
+		warnScanTimeout(timeout, report.Unfinished)
+		return report, nil
+	}
+	return report, err
+}
+
+// Default TUI danger thresholds (--danger-size, --danger-count)
+const (
+	defaultDangerSize  = "50GB"
+	defaultDangerCount = 500
+)
+
+// scanStream streams a scan for the TUI within timeout. Once the stream is
+// closed, report gives the scan's report (see
+// scanner.ScanAllStreamReport) and timedOut whether the deadline cut it
+// short.
+func scanStream(s *scanner.Scanner, opts types.ScanOptions, timeout time.Duration) (stream <-chan types.ScanResult, report func() types.ScanReport, timedOut func() bool) {
+	ctx, cancel := scanContext(timeout)
+	results, report := s.ScanAllStreamReport(ctx, opts)
+
+	// Forward the results so the deadline stops applying once the scan is
+	// over, however long the TUI then stays open
+	out := make(chan types.ScanResult, cap(results))
+	var cut atomic.Bool
+	go func() {
+		defer cancel()
+		defer close(out)
+		for result := range results {
+			out <- result
+		}
+		cut.Store(ctx.Err() != nil)
+	}()
+	return out, report, cut.Load
+}
+
+// warnScanTimeout tells the user a scan hit --timeout and which categories
+// (when known) are missing from the results
+func warnScanTimeout(timeout time.Duration, unfinished []string) {
+	if len(unfinished) == 0 {
+		fmt.Fprintf(os.Stderr, "Warning: scan timed out after %s; results may be incomplete\n", timeout)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: scan timed out after %s; these categories did not finish: %s\n", timeout, strings.Join(unfinished, ", "))
+}
+
+// writeTreeJSON scans dir scanMaxDepth levels deep and writes the tree as
+// JSON to stdout or --output-file
+func writeTreeJSON(s *scanner.Scanner, dir string) {
+	roots, err := resolveRoots([]string{dir})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --tree-json: %v\n", err)
+		os.Exit(1)
+	}
+	node, err := s.ScanTree(roots[0], scanMaxDepth)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --tree-json: %v\n", err)
+		os.Exit(1)
+	}
+
+	var out io.Writer = os.Stdout
+	if scanOutputFile != "" {
+		file, err := os.Create(scanOutputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --output-file: %v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := ui.WriteTreeJSON(out, node); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing JSON: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// saveSizeCache persists sizes computed by this run. The cache is only an
+// optimization, so failing to write it is just a warning.
+func saveSizeCache(s *scanner.Scanner) {
+	if err := s.SaveSizeCache(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save size cache: %v\n", err)
+	}
+}
+
+// defaultScanOptions returns options for the ScanCategories saved in the
+// settings file, or all categories when none were saved
+func defaultScanOptions() types.ScanOptions {
+	settings := services.NewSettingsService()
+	categories := settings.Get().ScanCategories
+	if !settings.Loaded() || len(categories) == 0 {
+		return types.DefaultScanOptions()
+	}
+
+	opts, unknown := types.ScanOptionsForCategories(categories)
+	if len(unknown) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring unknown scan categories in settings: %s\n", strings.Join(unknown, ", "))
+	}
+	if len(unknown) == len(categories) {
+		return types.DefaultScanOptions()
+	}
+	if !ui.IsQuiet() {
+		known := make([]string, 0, len(categories))
+		for _, category := range categories {
+			if !slices.Contains(unknown, category) {
+				known = append(known, category)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Using scan categories from settings: %s (pass category flags or --all to override)\n", strings.Join(known, ", "))
+	}
+	return opts
+}
+
+// settingsExcludeGlobs returns the ExcludePaths saved in the settings file
+// (clean --interactive-exclude) as patterns matching just those paths
+func settingsExcludeGlobs() []string {
+	return services.NewSettingsService().ExcludeGlobs()
+}
+
+// autoScanOptions returns options for the categories whose toolchain is
+// installed (--auto), or all categories when none is detected
+func autoScanOptions(s *scanner.Scanner) types.ScanOptions {
+	detected := s.DetectCategories()
+	if len(detected) == 0 {
+		fmt.Fprintln(os.Stderr, "Warning: --auto detected no installed toolchains, scanning all categories")
+		return types.DefaultScanOptions()
+	}
+
+	opts, _ := types.ScanOptionsForCategories(detected)
+	if !ui.IsQuiet() {
+		fmt.Fprintf(os.Stderr, "Auto-detected toolchains: %s\n", strings.Join(detected, ", "))
+	}
+	return opts
+}
+
+// promptScanOptions asks which ecosystems to scan (--interactive), reading
+// comma-separated numbers from stdin. The prompt goes to stderr so a JSON or
+// CSV report on stdout stays clean. Enter or 'all' scans everything.
+func promptScanOptions() types.ScanOptions {
+	fmt.Fprintln(os.Stderr, "📋 Ecosystems to scan:")
+	for i, category := range types.Categories {
+		fmt.Fprintf(os.Stderr, "   [%2d] %s\n", i+1, category.Label)
+	}
+	fmt.Fprintln(os.Stderr, "\n   Enter numbers (comma-separated), 'all' or Enter for everything, or 'q' to quit:")
+	fmt.Fprint(os.Stderr, "   > ")
+
+	reader := bufio.NewReader(os.Stdin)
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+
+	switch input {
+	case "q", "quit":
+		fmt.Fprintln(os.Stderr, "Cancelled.")
+		os.Exit(0)
+	case "", "all", "a":
+		return types.DefaultScanOptions()
+	}
+
+	var categories []string
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		idx, err := strconv.Atoi(part)
+		if err != nil || idx < 1 || idx > len(types.Categories) {
+			fmt.Fprintf(os.Stderr, "Invalid selection: %s\n", part)
+			continue
+		}
+		categories = append(categories, types.Categories[idx-1].Name)
+	}
+	if len(categories) == 0 {
+		fmt.Fprintln(os.Stderr, "No valid ecosystems selected.")
+		os.Exit(1)
+	}
+
+	opts, _ := types.ScanOptionsForCategories(categories)
+	return opts
+}
+
+// excludeTypes switches off the --exclude-type categories in opts, exiting
+// on names it does not know
+func excludeTypes(opts *types.ScanOptions, names []string) {
+	if unknown := opts.ExcludeCategories(names); len(unknown) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: --exclude-type: unknown category %s\n", strings.Join(unknown, ", "))
+		os.Exit(1)
+	}
+}
+
+// resolveRoots turns --path values into absolute, existing directories
+func resolveRoots(paths []string) ([]string, error) {
+	roots := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if strings.HasPrefix(path, "~") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, err
+			}
+			path = filepath.Join(home, path[1:])
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(abs)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("%s is not a directory", abs)
+		}
+		roots = append(roots, abs)
+	}
+	return roots, nil
+}
+
+// tuiOptions builds TUI options from the shared settings file; opts is
+// reused when the TUI rescans
+func tuiOptions(opts types.ScanOptions) tui.Options {
+	settings := services.NewSettingsService().Get()
+	size, err := types.ParseSize(dangerSize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --danger-size: %v\n", err)
+		os.Exit(1)
+	}
+	if dangerCount < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --danger-count must be 0 or greater")
+		os.Exit(1)
+	}
+	if pageSize < 1 {
+		fmt.Fprintln(os.Stderr, "Error: --page-size must be 1 or greater")
+		os.Exit(1)
+	}
+	return tui.Options{
+		DefaultView: settings.DefaultView,
+		LogPath:     logFile,
+		ScanOptions: &opts,
+		DangerSize:  size,
+		DangerCount: dangerCount,
+
+		GroupByProject: groupByProject,
+		PageSize:       pageSize,
+	}
 }
 
 // sortBySize sorts results by size in descending order
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/thanhdevapp/dev-cleaner/internal/cleaner"
+	"github.com/thanhdevapp/dev-cleaner/internal/ui"
+)
+
+// statsCmd prints the cumulative space freed by past cleanups
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show how much space dev-cleaner has freed so far",
+	Long: `Show the running total of space freed, items deleted and cleanup sessions
+since dev-cleaner was first used on this machine.
+
+Every real (non-dry-run) clean from the CLI, TUI or GUI is added to
+~/.dev-cleaner-stats.json. Dry-runs are not counted.`,
+	Run: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, args []string) {
+	path, err := cleaner.DefaultStatsPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error locating stats: %v\n", err)
+		os.Exit(1)
+	}
+
+	stats, err := cleaner.LoadStats(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading stats: %v\n", err)
+		os.Exit(1)
+	}
+
+	if stats.Sessions == 0 {
+		fmt.Println("No cleanups recorded yet. Run 'dev-cleaner clean --confirm' to free some space.")
+		return
+	}
+
+	sessions := "sessions"
+	if stats.Sessions == 1 {
+		sessions = "session"
+	}
+	fmt.Printf("Since %s, dev-cleaner has freed %s across %d %s.\n",
+		stats.FirstUse.Format("Jan 2, 2006"), ui.FormatSize(stats.BytesFreed), stats.Sessions, sessions)
+	fmt.Printf("%s items deleted, last cleanup on %s.\n",
+		ui.FormatCount(int64(stats.Deletions)), stats.LastClean.Format("Jan 2, 2006"))
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thanhdevapp/dev-cleaner/internal/services"
+)
+
+// GitHub repository whose releases are checked for updates
+const (
+	updateRepoOwner = "thanhdevapp"
+	updateRepoName  = "mac-dev-cleaner-cli"
+)
+
+var versionCheck bool
+
+// versionCmd prints build info and optionally checks for a newer release
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show version and build info, optionally checking for updates",
+	Long: `Show the dev-cleaner version, Go version and platform.
+
+With --check, the latest GitHub release is compared against this version
+and, when it is newer, its URL and release notes are printed.
+
+Other commands check for updates in the background too when
+"checkAutoUpdate" is true in the settings file (~/.dev-cleaner-gui.json,
+shared with the GUI), printing a one-line notice when they finish.
+
+Examples:
+  dev-cleaner version           # Version and build info
+  dev-cleaner version --check   # Also check GitHub for a newer release`,
+	Run: runVersion,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+
+	versionCmd.Flags().BoolVar(&versionCheck, "check", false, "Check GitHub for a newer release")
+}
+
+func runVersion(cmd *cobra.Command, args []string) {
+	fmt.Printf("dev-cleaner %s\n", Version)
+	fmt.Printf("Go %s, %s/%s\n", strings.TrimPrefix(runtime.Version(), "go"), runtime.GOOS, runtime.GOARCH)
+
+	if !versionCheck {
+		return
+	}
+
+	info, err := services.NewUpdateService(Version, updateRepoOwner, updateRepoName).CheckForUpdates()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking for updates: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	if !info.Available {
+		fmt.Printf("You are up to date (latest release: %s).\n", info.LatestVersion)
+		return
+	}
+	fmt.Printf("Update available: %s → %s\n", info.CurrentVersion, info.LatestVersion)
+	if info.ReleaseURL != "" {
+		fmt.Println(info.ReleaseURL)
+	}
+	if notes := strings.TrimSpace(info.ReleaseNotes); notes != "" {
+		fmt.Printf("\nRelease notes:\n%s\n", notes)
+	}
+}
+
+// updateNotice receives the result of the background update check started
+// by startUpdateCheck; nil when no check is running
+var updateNotice chan *services.UpdateInfo
+
+// startUpdateCheck checks for a newer release in the background when
+// "checkAutoUpdate" is enabled in a saved settings file. Without a settings
+// file the CLI stays offline, like it ignores default scanCategories.
+func startUpdateCheck(cmd *cobra.Command) {
+	if cmd == versionCmd || quiet {
+		return
+	}
+	settings := services.NewSettingsService()
+	if !settings.Loaded() || !settings.Get().CheckAutoUpdate {
+		return
+	}
+
+	updateNotice = make(chan *services.UpdateInfo, 1)
+	go func() {
+		info, err := services.NewUpdateService(Version, updateRepoOwner, updateRepoName).CheckForUpdates()
+		if err != nil {
+			info = nil // Offline or rate limited: no notice
+		}
+		updateNotice <- info
+	}()
+}
+
+// printUpdateNotice prints a one-line notice if the background check has
+// already found a newer release; it never waits for the check to finish
+func printUpdateNotice() {
+	if updateNotice == nil {
+		return
+	}
+	select {
+	case info := <-updateNotice:
+		if info != nil && info.Available {
+			fmt.Fprintf(os.Stderr, "\nA new version of dev-cleaner is available: %s → %s\n%s\n",
+				info.CurrentVersion, info.LatestVersion, info.ReleaseURL)
+		}
+	default:
+	}
+}
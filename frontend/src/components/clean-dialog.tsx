@@ -39,7 +39,7 @@ export function CleanDialog({
     const totalSize = selectedItems.reduce((sum, item) => sum + item.size, 0)
     const successCount = results.filter(r => r.Success).length
     const failCount = results.filter(r => !r.Success).length
-    const freedSpace = results.filter(r => r.Success).reduce((sum, r) => sum + r.Size, 0)
+    const freedSpace = results.reduce((sum, r) => sum + r.FreedSize, 0)
 
     // Check settings and reset state when dialog opens
     useEffect(() => {
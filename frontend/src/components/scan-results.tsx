@@ -8,6 +8,13 @@ import { FileTreeList } from './file-tree-list'
 import { TreemapChart } from './treemap-chart'
 import { LayoutGrid, List, Columns } from 'lucide-react'
 import { ToggleGroup, ToggleGroupItem } from '@/components/ui/toggle-group'
+import { useToast } from '@/components/ui/use-toast'
+
+// ScanError mirrors types.ScanError: a category that failed to scan
+interface ScanError {
+  category: string
+  message: string
+}
 
 export function ScanResults() {
   // Use Zustand store for all state
@@ -21,6 +28,7 @@ export function ScanResults() {
     isScanning,
     typeFilter
   } = useUIStore()
+  const { toast } = useToast()
 
   // Filter results based on typeFilter
   const filteredResults = typeFilter.length > 0
@@ -48,6 +56,16 @@ export function ScanResults() {
       }
     })
 
+    // Failed categories, so they aren't mistaken for having nothing to clean
+    EventsOn('scan:errors', (errors: ScanError[]) => {
+      if (!Array.isArray(errors) || errors.length === 0) return
+      toast({
+        variant: 'destructive',
+        title: errors.length === 1 ? '1 category failed to scan' : `${errors.length} categories failed to scan`,
+        description: errors.map((e) => `${e.category}: ${e.message}`).join('\n'),
+      })
+    })
+
     // Optional: Poll for updates while scanning (less aggressive - 2 second interval)
     let pollInterval: ReturnType<typeof setInterval> | null = null
     if (isScanning) {
@@ -63,6 +81,7 @@ export function ScanResults() {
     return () => {
       console.log('🧹 Cleanup: removing event listeners and stopping polling')
       EventsOff('scan:complete')
+      EventsOff('scan:errors')
       if (pollInterval) {
         clearInterval(pollInterval)
       }
@@ -194,6 +194,22 @@ export function SettingsDialog({ open, onOpenChange }: SettingsDialogProps) {
                             <h4 className="text-sm font-medium mb-4">Scan Settings</h4>
 
                             <div className="space-y-2">
+                                <Label htmlFor="projectSearchDepth">Project Search Depth</Label>
+                                <Input
+                                    id="projectSearchDepth"
+                                    type="number"
+                                    min={1}
+                                    max={10}
+                                    value={settings.projectSearchDepth}
+                                    onChange={(e) => updateSetting('projectSearchDepth', parseInt(e.target.value) || 3)}
+                                    className="w-24"
+                                />
+                                <p className="text-xs text-muted-foreground">
+                                    How deep to search project folders for artifacts (1-10)
+                                </p>
+                            </div>
+
+                            <div className="space-y-2 mt-4">
                                 <Label htmlFor="maxDepth">Max Depth</Label>
                                 <Input
                                     id="maxDepth"
@@ -201,11 +217,11 @@ export function SettingsDialog({ open, onOpenChange }: SettingsDialogProps) {
                                     min={1}
                                     max={10}
                                     value={settings.maxDepth}
-                                    onChange={(e) => updateSetting('maxDepth', parseInt(e.target.value) || 3)}
+                                    onChange={(e) => updateSetting('maxDepth', parseInt(e.target.value) || 5)}
                                     className="w-24"
                                 />
                                 <p className="text-xs text-muted-foreground">
-                                    How deep to search for artifacts (1-10)
+                                    How deep the tree view can expand an item (1-10)
                                 </p>
                             </div>
                         </div>
@@ -17,7 +17,7 @@ import {
 
 // Category definitions
 const CATEGORIES = [
-    { id: 'all', name: 'All Items', icon: FolderOpen, color: 'text-gray-400', bgColor: 'bg-gray-500/10', types: ['xcode', 'android', 'node', 'react-native', 'flutter', 'python', 'rust', 'go', 'homebrew', 'docker', 'java'] },
+    { id: 'all', name: 'All Items', icon: FolderOpen, color: 'text-gray-400', bgColor: 'bg-gray-500/10', types: ['xcode', 'android', 'node', 'react-native', 'flutter', 'python', 'rust', 'go', 'homebrew', 'docker', 'java', 'deno', 'dotnet', 'unity', 'php', 'elixir', 'haskell', 'jetbrains'] },
     { id: 'xcode', name: 'Xcode', icon: Apple, color: 'text-blue-400', bgColor: 'bg-blue-500/10', types: ['xcode'] },
     { id: 'android', name: 'Android', icon: Smartphone, color: 'text-green-400', bgColor: 'bg-green-500/10', types: ['android'] },
     { id: 'node', name: 'Node.js', icon: Box, color: 'text-yellow-400', bgColor: 'bg-yellow-500/10', types: ['node'] },
@@ -29,6 +29,12 @@ const CATEGORIES = [
     { id: 'homebrew', name: 'Homebrew', icon: Package, color: 'text-amber-500', bgColor: 'bg-amber-500/10', types: ['homebrew'] },
     { id: 'docker', name: 'Docker', icon: Container, color: 'text-sky-500', bgColor: 'bg-sky-500/10', types: ['docker'] },
     { id: 'java', name: 'Java', icon: Coffee, color: 'text-red-600', bgColor: 'bg-red-600/10', types: ['java'] },
+    { id: 'deno', name: 'Deno', icon: Code2, color: 'text-emerald-400', bgColor: 'bg-emerald-500/10', types: ['deno'] },
+    { id: 'dotnet', name: '.NET / Unity', icon: Code2, color: 'text-purple-400', bgColor: 'bg-purple-500/10', types: ['dotnet', 'unity'] },
+    { id: 'php', name: 'PHP', icon: Code2, color: 'text-indigo-400', bgColor: 'bg-indigo-500/10', types: ['php'] },
+    { id: 'elixir', name: 'Elixir', icon: Code2, color: 'text-violet-400', bgColor: 'bg-violet-500/10', types: ['elixir'] },
+    { id: 'haskell', name: 'Haskell', icon: Code2, color: 'text-fuchsia-400', bgColor: 'bg-fuchsia-500/10', types: ['haskell'] },
+    { id: 'jetbrains', name: 'JetBrains', icon: Code2, color: 'text-pink-400', bgColor: 'bg-pink-500/10', types: ['jetbrains'] },
 ] as const
 
 // CSS styles as objects to avoid Tailwind issues
@@ -109,6 +109,33 @@ describe('Toolbar', () => {
         )
       })
     })
+
+    it('uses projectSearchDepth from settings when scanning', async () => {
+      const mockGetSettings = vi.mocked(GetSettings)
+      mockGetSettings.mockResolvedValue({
+        maxDepth: 5,
+        projectSearchDepth: 6,
+        autoScan: false,
+        defaultView: 'list',
+      } as any)
+
+      const mockScan = vi.mocked(Scan)
+      mockScan.mockResolvedValue(undefined)
+
+      const user = userEvent.setup()
+      render(<Toolbar />)
+
+      await user.click(screen.getByText('Scan'))
+
+      await waitFor(() => {
+        expect(mockScan).toHaveBeenCalledWith(
+          expect.objectContaining({
+            ProjectSearchDepth: 6,
+            MaxDepth: 5,
+          })
+        )
+      })
+    })
   })
 
   describe('View Mode', () => {
@@ -6,6 +6,7 @@ import { types, services } from '../../wailsjs/go/models'
  */
 export function createDefaultScanOptions(settings?: services.Settings): types.ScanOptions {
   const maxDepth = settings?.maxDepth || 5
+  const projectSearchDepth = settings?.projectSearchDepth || 3
 
   return new types.ScanOptions({
     // Development tools
@@ -20,6 +21,12 @@ export function createDefaultScanOptions(settings?: services.Settings): types.Sc
     IncludePython: true,
     IncludeRust: true,
     IncludeGo: true,
+    IncludeDeno: true,
+    IncludeDotNet: true,
+    IncludePHP: true,
+    IncludeElixir: true,
+    IncludeHaskell: true,
+    IncludeJetBrains: true,
 
     // System tools
     IncludeHomebrew: true,
@@ -30,6 +37,7 @@ export function createDefaultScanOptions(settings?: services.Settings): types.Sc
 
     // Scan configuration
     ProjectRoot: '/Users',
-    MaxDepth: maxDepth
+    ProjectSearchDepth: projectSearchDepth, // Levels below each project root searched for node_modules, target...
+    MaxDepth: maxDepth // Tree navigation depth
   })
 }
@@ -3,10 +3,13 @@ import { vi } from 'vitest'
 
 // Mock Wails runtime - Go function bindings
 vi.mock('../../wailsjs/go/main/App', () => ({
+  CancelScan: vi.fn().mockResolvedValue(undefined),
   Scan: vi.fn().mockResolvedValue(undefined),
   GetScanResults: vi.fn().mockResolvedValue([]),
+  GetScanSummary: vi.fn().mockResolvedValue({ totalSize: 0, count: 0, byType: {} }),
   GetSettings: vi.fn().mockResolvedValue({
     maxDepth: 5,
+    projectSearchDepth: 3,
     autoScan: false,
     defaultView: 'list',
   }),
@@ -4,6 +4,8 @@ import {services} from '../models';
 import {types} from '../models';
 import {cleaner} from '../models';
 
+export function CancelScan():Promise<void>;
+
 export function CheckForUpdates():Promise<services.UpdateInfo>;
 
 export function Clean(arg1:Array<types.ScanResult>):Promise<Array<cleaner.CleanResult>>;
@@ -14,6 +16,8 @@ export function ClearUpdateCache():Promise<void>;
 
 export function GetScanResults():Promise<Array<types.ScanResult>>;
 
+export function GetScanSummary():Promise<types.ScanSummary>;
+
 export function GetSettings():Promise<services.Settings>;
 
 export function GetTreeNode(arg1:string,arg2:number):Promise<types.TreeNode>;
@@ -24,4 +28,6 @@ export function IsScanning():Promise<boolean>;
 
 export function Scan(arg1:types.ScanOptions):Promise<void>;
 
+export function ScanStream(arg1:types.ScanOptions):Promise<void>;
+
 export function UpdateSettings(arg1:services.Settings):Promise<void>;
@@ -2,6 +2,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function CancelScan() {
+  return window['go']['main']['App']['CancelScan']();
+}
+
 export function CheckForUpdates() {
   return window['go']['main']['App']['CheckForUpdates']();
 }
@@ -22,6 +26,10 @@ export function GetScanResults() {
   return window['go']['main']['App']['GetScanResults']();
 }
 
+export function GetScanSummary() {
+  return window['go']['main']['App']['GetScanSummary']();
+}
+
 export function GetSettings() {
   return window['go']['main']['App']['GetSettings']();
 }
@@ -42,6 +50,10 @@ export function Scan(arg1) {
   return window['go']['main']['App']['Scan'](arg1);
 }
 
+export function ScanStream(arg1) {
+  return window['go']['main']['App']['ScanStream'](arg1);
+}
+
 export function UpdateSettings(arg1) {
   return window['go']['main']['App']['UpdateSettings'](arg1);
 }
@@ -3,9 +3,11 @@ export namespace cleaner {
 	export class CleanResult {
 	    Path: string;
 	    Size: number;
+	    FreedSize: number;
 	    Success: boolean;
 	    Error: any;
 	    WasDryRun: boolean;
+	    Duration: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new CleanResult(source);
@@ -15,9 +17,11 @@ export namespace cleaner {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.Path = source["Path"];
 	        this.Size = source["Size"];
+	        this.FreedSize = source["FreedSize"];
 	        this.Success = source["Success"];
 	        this.Error = source["Error"];
 	        this.WasDryRun = source["WasDryRun"];
+	        this.Duration = source["Duration"];
 	    }
 	}
 
@@ -32,7 +36,9 @@ export namespace services {
 	    confirmDelete: boolean;
 	    scanCategories: string[];
 	    maxDepth: number;
+	    projectSearchDepth: number;
 	    checkAutoUpdate: boolean;
+	    excludePaths: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Settings(source);
@@ -46,7 +52,9 @@ export namespace services {
 	        this.confirmDelete = source["confirmDelete"];
 	        this.scanCategories = source["scanCategories"];
 	        this.maxDepth = source["maxDepth"];
+	        this.projectSearchDepth = source["projectSearchDepth"];
 	        this.checkAutoUpdate = source["checkAutoUpdate"];
+	        this.excludePaths = source["excludePaths"];
 	    }
 	}
 	export class UpdateInfo {
@@ -108,8 +116,27 @@ export namespace types {
 	    IncludeHomebrew: boolean;
 	    IncludeDocker: boolean;
 	    IncludeJava: boolean;
+	    IncludeDeno: boolean;
+	    IncludeDotNet: boolean;
+	    IncludePHP: boolean;
+	    IncludeElixir: boolean;
+	    IncludeHaskell: boolean;
+	    IncludeJetBrains: boolean;
+	    ProjectSearchDepth: number;
 	    MaxDepth: number;
 	    ProjectRoot: string;
+	    Deep: boolean;
+	    IncludeHiddenRoots: boolean;
+	    GlobalsOnly: boolean;
+	    Concurrency: number;
+	    ExtraRoots: string[];
+	    ProtectActiveDays: number;
+	    FollowSymlinks: boolean;
+	    ExcludeGlobs: string[];
+	    UseDu: boolean;
+	    EstimateOnly: boolean;
+	    AssumeDockerStopped: boolean;
+	    ArchivesOlderThan: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new ScanOptions(source);
@@ -129,8 +156,27 @@ export namespace types {
 	        this.IncludeHomebrew = source["IncludeHomebrew"];
 	        this.IncludeDocker = source["IncludeDocker"];
 	        this.IncludeJava = source["IncludeJava"];
+	        this.IncludeDeno = source["IncludeDeno"];
+	        this.IncludeDotNet = source["IncludeDotNet"];
+	        this.IncludePHP = source["IncludePHP"];
+	        this.IncludeElixir = source["IncludeElixir"];
+	        this.IncludeHaskell = source["IncludeHaskell"];
+	        this.IncludeJetBrains = source["IncludeJetBrains"];
+	        this.ProjectSearchDepth = source["ProjectSearchDepth"];
 	        this.MaxDepth = source["MaxDepth"];
 	        this.ProjectRoot = source["ProjectRoot"];
+	        this.Deep = source["Deep"];
+	        this.IncludeHiddenRoots = source["IncludeHiddenRoots"];
+	        this.GlobalsOnly = source["GlobalsOnly"];
+	        this.Concurrency = source["Concurrency"];
+	        this.ExtraRoots = source["ExtraRoots"];
+	        this.ProtectActiveDays = source["ProtectActiveDays"];
+	        this.FollowSymlinks = source["FollowSymlinks"];
+	        this.ExcludeGlobs = source["ExcludeGlobs"];
+	        this.UseDu = source["UseDu"];
+	        this.EstimateOnly = source["EstimateOnly"];
+	        this.AssumeDockerStopped = source["AssumeDockerStopped"];
+	        this.ArchivesOlderThan = source["ArchivesOlderThan"];
 	    }
 	}
 	export class ScanResult {
@@ -139,6 +185,8 @@ export namespace types {
 	    size: number;
 	    fileCount: number;
 	    name: string;
+	    reclaimablePercent?: number;
+	    estimated?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new ScanResult(source);
@@ -151,18 +199,36 @@ export namespace types {
 	        this.size = source["size"];
 	        this.fileCount = source["fileCount"];
 	        this.name = source["name"];
+	        this.reclaimablePercent = source["reclaimablePercent"];
+	        this.estimated = source["estimated"];
+	    }
+	}
+	export class ScanSummary {
+	    totalSize: number;
+	    count: number;
+	    byType: Record<string, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScanSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.totalSize = source["totalSize"];
+	        this.count = source["count"];
+	        this.byType = source["byType"];
 	    }
 	}
 	export class TreeNode {
-	    Path: string;
-	    Name: string;
-	    Size: number;
-	    IsDir: boolean;
-	    Type: string;
-	    Children: TreeNode[];
-	    Scanned: boolean;
-	    Depth: number;
-	    FileCount: number;
+	    path: string;
+	    name: string;
+	    size: number;
+	    isDir: boolean;
+	    type?: string;
+	    children?: TreeNode[];
+	    scanned: boolean;
+	    depth: number;
+	    fileCount: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new TreeNode(source);
@@ -170,15 +236,15 @@ export namespace types {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Path = source["Path"];
-	        this.Name = source["Name"];
-	        this.Size = source["Size"];
-	        this.IsDir = source["IsDir"];
-	        this.Type = source["Type"];
-	        this.Children = this.convertValues(source["Children"], TreeNode);
-	        this.Scanned = source["Scanned"];
-	        this.Depth = source["Depth"];
-	        this.FileCount = source["FileCount"];
+	        this.path = source["path"];
+	        this.name = source["name"];
+	        this.size = source["size"];
+	        this.isDir = source["isDir"];
+	        this.type = source["type"];
+	        this.children = this.convertValues(source["children"], TreeNode);
+	        this.scanned = source["scanned"];
+	        this.depth = source["depth"];
+	        this.fileCount = source["fileCount"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
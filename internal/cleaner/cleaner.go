@@ -2,12 +2,15 @@
 package cleaner
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
@@ -15,19 +18,38 @@ import (
 
 // Cleaner handles safe deletion of directories
 type Cleaner struct {
-	dryRun  bool
-	logger  *log.Logger
-	logFile *os.File
+	dryRun       bool
+	logger       *log.Logger
+	logFile      *os.File
+	statsPath    string
+	resumePath   string
+	allowedRoots []string
 }
 
-// New creates a new Cleaner instance
+// MaxLogSize is the size at which the log is rotated to <log>.1
+const MaxLogSize = 5 * 1024 * 1024
+
+// New creates a new Cleaner instance logging to DefaultLogPath
 func New(dryRun bool) (*Cleaner, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
+	return NewWithOptions(types.CleanOptions{DryRun: dryRun})
+}
+
+// NewWithOptions creates a new Cleaner instance. An empty LogPath logs to
+// DefaultLogPath, an empty StatsPath records stats to DefaultStatsPath and
+// an empty ResumePath keeps the resume list at DefaultResumePath.
+func NewWithOptions(opts types.CleanOptions) (*Cleaner, error) {
+	logPath := opts.LogPath
+	if logPath == "" {
+		var err error
+		if logPath, err = DefaultLogPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rotateLog(logPath, MaxLogSize); err != nil {
 		return nil, err
 	}
 
-	logPath := filepath.Join(home, ".dev-cleaner.log")
 	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
 	if err != nil {
 		return nil, err
@@ -35,13 +57,44 @@ func New(dryRun bool) (*Cleaner, error) {
 
 	logger := log.New(logFile, "", log.LstdFlags)
 
+	statsPath := opts.StatsPath
+	if statsPath == "" {
+		statsPath, _ = DefaultStatsPath() // Stats are skipped without a home
+	}
+	resumePath := opts.ResumePath
+	if resumePath == "" {
+		resumePath, _ = DefaultResumePath()
+	}
+
 	return &Cleaner{
-		dryRun:  dryRun,
-		logger:  logger,
-		logFile: logFile,
+		dryRun:       opts.DryRun,
+		logger:       logger,
+		logFile:      logFile,
+		statsPath:    statsPath,
+		resumePath:   resumePath,
+		allowedRoots: opts.AllowedRoots,
 	}, nil
 }
 
+// DefaultLogPath returns the default log location, ~/.dev-cleaner.log
+func DefaultLogPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".dev-cleaner.log"), nil
+}
+
+// rotateLog renames path to path.1 (replacing an older rotation) once it
+// grows beyond maxSize
+func rotateLog(path string, maxSize int64) error {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() <= maxSize {
+		return nil // Missing or small enough, nothing to rotate
+	}
+	return os.Rename(path, path+".1")
+}
+
 // Close closes the log file
 func (c *Cleaner) Close() error {
 	if c.logFile != nil {
@@ -55,6 +108,16 @@ func (c *Cleaner) SetDryRun(dryRun bool) {
 	c.dryRun = dryRun
 }
 
+// ValidatePath checks if path is safe to delete, also accepting paths under
+// the cleaner's AllowedRoots, and refuses folders that look like projects
+// (see CheckSourceMarkers)
+func (c *Cleaner) ValidatePath(path string) error {
+	if err := ValidatePathWithRoots(path, c.allowedRoots); err != nil {
+		return err
+	}
+	return CheckSourceMarkers(path)
+}
+
 // Logger returns the cleaner's logger instance
 func (c *Cleaner) Logger() *log.Logger {
 	return c.logger
@@ -62,27 +125,80 @@ func (c *Cleaner) Logger() *log.Logger {
 
 // CleanResult represents the result of a clean operation
 type CleanResult struct {
-	Path        string
-	Size        int64
-	Success     bool
-	Error       error
-	WasDryRun   bool
+	Path      string
+	Size      int64 // Original size of the item
+	FreedSize int64 // Bytes actually freed (may be > 0 for failed items)
+	Success   bool
+	Error     error
+	WasDryRun bool
+	Duration  time.Duration // Time spent removing the item, 0 when nothing was removed
+}
+
+// SlowDelete is how long removing one item takes before the results point
+// it out, e.g. a folder with millions of small files
+const SlowDelete = 5 * time.Second
+
+// TookLabel returns e.g. "took 42s" for an item whose removal took at
+// least SlowDelete, or "" otherwise
+func (r CleanResult) TookLabel() string {
+	if r.Duration < SlowDelete {
+		return ""
+	}
+	return "took " + r.Duration.Round(time.Second).String()
+}
+
+// FreedAfterFailure re-measures path after a failed removal and returns how
+// much of originalSize was actually freed
+func FreedAfterFailure(path string, originalSize int64) int64 {
+	var remaining int64
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+		if !info.IsDir() {
+			remaining += info.Size()
+		}
+		return nil
+	})
+
+	freed := originalSize - remaining
+	if freed < 0 {
+		return 0
+	}
+	return freed
 }
 
-// Clean deletes the specified paths after validation
+// SummarizeResults totals clean results into a success count, bytes freed
+// and bytes that could not be removed
+func SummarizeResults(results []CleanResult) (successCount int, freed, notFreed int64) {
+	for _, r := range results {
+		if r.Success {
+			successCount++
+		}
+		freed += r.FreedSize
+		notFreed += r.Size - r.FreedSize
+	}
+	return successCount, freed, notFreed
+}
+
+// Clean deletes the specified paths after validation. Real runs are added
+// to the cumulative stats, and keep the targets not yet processed in the
+// resume file until they complete.
 func (c *Cleaner) Clean(results []types.ScanResult) ([]CleanResult, error) {
 	var cleanResults []CleanResult
 
-	for _, result := range results {
-		// Handle Docker paths specially
-		if strings.HasPrefix(result.Path, "docker:") {
-			cleanResult := c.cleanDocker(result)
-			cleanResults = append(cleanResults, cleanResult)
+	for i, result := range results {
+		// An interrupt during this item leaves it and the rest to resume
+		c.SaveResume(results[i:])
+
+		// Docker and simctl resources are removed by their own tools
+		if types.IsPseudoPath(result.Path) {
+			cleanResults = append(cleanResults, c.CleanPseudoPath(result))
 			continue
 		}
 
 		// Validate path safety
-		if err := ValidatePath(result.Path); err != nil {
+		if err := c.ValidatePath(result.Path); err != nil {
 			cleanResults = append(cleanResults, CleanResult{
 				Path:    result.Path,
 				Size:    result.Size,
@@ -93,72 +209,188 @@ func (c *Cleaner) Clean(results []types.ScanResult) ([]CleanResult, error) {
 		}
 
 		if c.dryRun {
-			c.logger.Printf("[DRY-RUN] Would delete: %s (%.2f MB)\n", result.Path, float64(result.Size)/(1024*1024))
+			c.logger.Println(formatLogEntry(result, true))
 			cleanResults = append(cleanResults, CleanResult{
 				Path:      result.Path,
 				Size:      result.Size,
+				FreedSize: result.Size,
 				Success:   true,
 				WasDryRun: true,
 			})
 		} else {
-			c.logger.Printf("[DELETE] Removing: %s (%.2f MB)\n", result.Path, float64(result.Size)/(1024*1024))
+			c.logger.Println(formatLogEntry(result, false))
 
-			if err := os.RemoveAll(result.Path); err != nil {
-				c.logger.Printf("[ERROR] Failed to delete %s: %v\n", result.Path, err)
+			start := time.Now()
+			err := c.RemoveAll(result.Path, nil)
+			elapsed := time.Since(start)
+			if err != nil {
+				freed := FreedAfterFailure(result.Path, result.Size)
+				c.logger.Printf("[ERROR] Failed to delete %s: %v (%.2f MB freed)\n", result.Path, err, float64(freed)/(1024*1024))
 				cleanResults = append(cleanResults, CleanResult{
-					Path:    result.Path,
-					Size:    result.Size,
-					Success: false,
-					Error:   err,
+					Path:      result.Path,
+					Size:      result.Size,
+					FreedSize: freed,
+					Success:   false,
+					Error:     err,
+					Duration:  elapsed,
 				})
 			} else {
 				c.logger.Printf("[SUCCESS] Deleted: %s at %s\n", result.Path, time.Now().Format(time.RFC3339))
 				cleanResults = append(cleanResults, CleanResult{
-					Path:    result.Path,
-					Size:    result.Size,
-					Success: true,
+					Path:      result.Path,
+					Size:      result.Size,
+					FreedSize: result.Size,
+					Success:   true,
+					Duration:  elapsed,
 				})
 			}
 		}
 	}
 
+	c.ClearResume()
+	c.RecordStats(cleanResults)
 	return cleanResults, nil
 }
 
+// RemoveAll deletes path, and on a permission error makes the directories
+// under it writable and tries once more. Only path itself and its
+// descendants are ever chmod'ed. With removed set, files are deleted one
+// by one first and each is added to it, so a long deletion can report its
+// throughput. It does not validate path; Clean and the TUI call
+// ValidatePath first.
+func (c *Cleaner) RemoveAll(path string, removed *atomic.Int64) error {
+	err := removeTree(path, removed)
+	if err == nil || !errors.Is(err, fs.ErrPermission) {
+		return err
+	}
+
+	c.logger.Printf("[RETRY] Permission denied, making %s writable\n", path)
+	makeWritable(path)
+
+	if err = removeTree(path, removed); err != nil && errors.Is(err, fs.ErrPermission) {
+		return fmt.Errorf("permission denied, try sudo: %w", err)
+	}
+	return err
+}
+
+// removeTree is os.RemoveAll, counting files into removed when it is set.
+func removeTree(path string, removed *atomic.Int64) error {
+	if removed != nil {
+		filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil // Left to os.RemoveAll
+			}
+			if os.Remove(p) == nil {
+				removed.Add(1)
+			}
+			return nil
+		})
+	}
+	return os.RemoveAll(path)
+}
+
+// makeWritable adds owner rwx to every directory under root (including
+// root) so their entries can be listed and removed. Symlinks are not
+// followed, so nothing outside root is touched.
+func makeWritable(root string) {
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil // Skip unreadable entries and files
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.Mode().Perm()&0700 != 0700 {
+			os.Chmod(path, info.Mode().Perm()|0700)
+		}
+		return nil
+	})
+}
+
+// CleanPseudoPath cleans a tool-managed result (see types.IsPseudoPath)
+// by running its tool, or logs what would run in dry-run mode. Unlike
+// Clean it does not update the stats.
+func (c *Cleaner) CleanPseudoPath(result types.ScanResult) CleanResult {
+	if strings.HasPrefix(result.Path, types.SimctlPathPrefix) {
+		return c.cleanSimctl(result)
+	}
+	return c.cleanDocker(result)
+}
+
+// cleanSimctl deletes simulator devices via `xcrun simctl delete`, which
+// also updates the CoreSimulator device set
+func (c *Cleaner) cleanSimctl(result types.ScanResult) CleanResult {
+	if c.dryRun {
+		c.logger.Println(formatLogEntry(result, true))
+		return CleanResult{
+			Path:      result.Path,
+			Size:      result.Size,
+			FreedSize: result.Size,
+			Success:   true,
+			WasDryRun: true,
+		}
+	}
+
+	args, err := pseudoCommand(result)
+	if err != nil {
+		return CleanResult{
+			Path:    result.Path,
+			Size:    result.Size,
+			Success: false,
+			Error:   err,
+		}
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	c.logger.Println(formatLogEntry(result, false))
+
+	if err := cmd.Run(); err != nil {
+		c.logger.Printf("[ERROR] simctl cleanup failed: %v\n", err)
+		return CleanResult{
+			Path:    result.Path,
+			Size:    result.Size,
+			Success: false,
+			Error:   err,
+		}
+	}
+
+	c.logger.Printf("[SUCCESS] Unavailable simulators deleted at %s\n", time.Now().Format(time.RFC3339))
+	return CleanResult{
+		Path:      result.Path,
+		Size:      result.Size,
+		FreedSize: result.Size,
+		Success:   true,
+	}
+}
+
 // cleanDocker handles Docker resource cleanup via CLI
 func (c *Cleaner) cleanDocker(result types.ScanResult) CleanResult {
-	resourceType := strings.TrimPrefix(result.Path, "docker:")
+	resourceType := strings.TrimPrefix(result.Path, types.DockerPathPrefix)
 
 	if c.dryRun {
-		c.logger.Printf("[DRY-RUN] Would clean Docker %s (%.2f MB)\n", resourceType, float64(result.Size)/(1024*1024))
+		c.logger.Println(formatLogEntry(result, true))
 		return CleanResult{
 			Path:      result.Path,
 			Size:      result.Size,
+			FreedSize: result.Size,
 			Success:   true,
 			WasDryRun: true,
 		}
 	}
 
-	var cmd *exec.Cmd
-	switch resourceType {
-	case "images":
-		cmd = exec.Command("docker", "image", "prune", "-a", "-f")
-	case "containers":
-		cmd = exec.Command("docker", "container", "prune", "-f")
-	case "local-volumes":
-		cmd = exec.Command("docker", "volume", "prune", "-f")
-	case "build-cache":
-		cmd = exec.Command("docker", "builder", "prune", "-a", "-f")
-	default:
+	args, err := pseudoCommand(result)
+	if err != nil {
 		return CleanResult{
 			Path:    result.Path,
 			Size:    result.Size,
 			Success: false,
-			Error:   fmt.Errorf("unknown docker resource type: %s", resourceType),
+			Error:   err,
 		}
 	}
 
-	c.logger.Printf("[DELETE] Running: %s\n", strings.Join(cmd.Args, " "))
+	cmd := exec.Command(args[0], args[1:]...)
+	c.logger.Println(formatLogEntry(result, false))
 
 	if err := cmd.Run(); err != nil {
 		c.logger.Printf("[ERROR] Docker cleanup failed: %v\n", err)
@@ -172,12 +404,79 @@ func (c *Cleaner) cleanDocker(result types.ScanResult) CleanResult {
 
 	c.logger.Printf("[SUCCESS] Docker %s cleaned at %s\n", resourceType, time.Now().Format(time.RFC3339))
 	return CleanResult{
-		Path:    result.Path,
-		Size:    result.Size,
-		Success: true,
+		Path:      result.Path,
+		Size:      result.Size,
+		FreedSize: result.Size,
+		Success:   true,
+	}
+}
+
+// pseudoCommand returns the command line that cleans a tool-managed result
+func pseudoCommand(result types.ScanResult) ([]string, error) {
+	if target, ok := strings.CutPrefix(result.Path, types.SimctlPathPrefix); ok {
+		if target != "unavailable" {
+			return nil, fmt.Errorf("unknown simctl target: %s", target)
+		}
+		return []string{"xcrun", "simctl", "delete", "unavailable"}, nil
+	}
+
+	switch resourceType := strings.TrimPrefix(result.Path, types.DockerPathPrefix); resourceType {
+	case "images":
+		return []string{"docker", "image", "prune", "-a", "-f"}, nil
+	case "containers":
+		return []string{"docker", "container", "prune", "-f"}, nil
+	case "local-volumes":
+		return []string{"docker", "volume", "prune", "-f"}, nil
+	case "build-cache":
+		return []string{"docker", "builder", "prune", "-a", "-f"}, nil
+	default:
+		return nil, fmt.Errorf("unknown docker resource type: %s", resourceType)
 	}
 }
 
+// formatLogEntry returns the log line written before result is removed,
+// or instead of removing it in a dry-run. Tool-managed results log the
+// command that cleans them; one with an unknown target logs nothing ("").
+func formatLogEntry(result types.ScanResult, dryRun bool) string {
+	size := float64(result.Size) / (1024 * 1024)
+
+	if !types.IsPseudoPath(result.Path) {
+		if dryRun {
+			return fmt.Sprintf("[DRY-RUN] Would delete: %s (%.2f MB)", result.Path, size)
+		}
+		return fmt.Sprintf("[DELETE] Removing: %s (%.2f MB)", result.Path, size)
+	}
+
+	if dryRun {
+		if target, ok := strings.CutPrefix(result.Path, types.SimctlPathPrefix); ok {
+			return fmt.Sprintf("[DRY-RUN] Would delete %s simulators (%.2f MB)", target, size)
+		}
+		return fmt.Sprintf("[DRY-RUN] Would clean Docker %s (%.2f MB)", strings.TrimPrefix(result.Path, types.DockerPathPrefix), size)
+	}
+	args, err := pseudoCommand(result)
+	if err != nil {
+		return ""
+	}
+	return "[DELETE] Running: " + strings.Join(args, " ")
+}
+
+// LogTimePlaceholder stands in for the date and time the log prefixes
+// each entry with (log.LstdFlags) in PreviewLog
+const LogTimePlaceholder = "YYYY/MM/DD hh:mm:ss"
+
+// PreviewLog returns the entries a clean of results would append to the
+// log before each removal (clean --preview-log), so they can be reviewed
+// before confirming
+func PreviewLog(results []types.ScanResult, dryRun bool) []string {
+	var lines []string
+	for _, result := range results {
+		if entry := formatLogEntry(result, dryRun); entry != "" {
+			lines = append(lines, LogTimePlaceholder+" "+entry)
+		}
+	}
+	return lines
+}
+
 // TotalSize calculates total size from results
 func TotalSize(results []types.ScanResult) int64 {
 	var total int64
@@ -187,16 +486,8 @@ func TotalSize(results []types.ScanResult) int64 {
 	return total
 }
 
-// FormatSize formats bytes to human-readable format
+// FormatSize formats bytes to human-readable format, in the units chosen
+// with --si
 func FormatSize(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+	return types.FormatSize(bytes)
 }
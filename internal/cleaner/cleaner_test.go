@@ -0,0 +1,287 @@
+package cleaner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestFreedAfterFailure(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "left"), make([]byte, 300), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 1000 bytes originally, 300 still on disk
+	if got := FreedAfterFailure(dir, 1000); got != 700 {
+		t.Errorf("FreedAfterFailure() = %d, want 700", got)
+	}
+
+	// Nothing left on disk means everything was freed
+	if got := FreedAfterFailure(filepath.Join(dir, "gone"), 1000); got != 1000 {
+		t.Errorf("FreedAfterFailure() for missing path = %d, want 1000", got)
+	}
+
+	// Never report negative savings
+	if got := FreedAfterFailure(dir, 100); got != 0 {
+		t.Errorf("FreedAfterFailure() = %d, want 0", got)
+	}
+}
+
+func TestMakeWritable(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "target")
+	locked := filepath.Join(root, "locked")
+	outside := filepath.Join(base, "outside")
+	for _, dir := range []string{locked, outside} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.Symlink(outside, filepath.Join(root, "link"))
+	os.Chmod(locked, 0500)
+	os.Chmod(outside, 0500)
+	defer os.Chmod(outside, 0755)
+
+	makeWritable(root)
+
+	if info, _ := os.Stat(locked); info.Mode().Perm() != 0700 {
+		t.Errorf("locked dir mode = %o, want 700", info.Mode().Perm())
+	}
+	// The symlink target lies outside root and must not be touched
+	if info, _ := os.Stat(outside); info.Mode().Perm() != 0500 {
+		t.Errorf("outside dir mode = %o, want 500", info.Mode().Perm())
+	}
+}
+
+func TestRemoveAllCounts(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "node_modules")
+	for _, name := range []string{"a/index.js", "a/package.json", "b/c/index.js"} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := NewWithOptions(types.CleanOptions{LogPath: filepath.Join(t.TempDir(), "clean.log")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var removed atomic.Int64
+	if err := c.RemoveAll(root, &removed); err != nil {
+		t.Fatalf("RemoveAll() error = %v", err)
+	}
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		t.Errorf("%s still exists", root)
+	}
+	if got := removed.Load(); got != 3 {
+		t.Errorf("removed = %d, want 3", got)
+	}
+}
+
+func TestRemoveAllRetriesLockedDir(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "target")
+	locked := filepath.Join(root, "locked")
+	if err := os.MkdirAll(locked, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(locked, "file"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chmod(locked, 0500)
+	defer os.Chmod(locked, 0755)
+
+	c, err := NewWithOptions(types.CleanOptions{LogPath: filepath.Join(t.TempDir(), "clean.log")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// The counting path used by the TUI must go through the same retry
+	var removed atomic.Int64
+	if err := c.RemoveAll(root, &removed); err != nil {
+		t.Fatalf("RemoveAll() error = %v", err)
+	}
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		t.Errorf("%s still exists", root)
+	}
+	if got := removed.Load(); got != 1 {
+		t.Errorf("removed = %d, want 1", got)
+	}
+}
+
+func TestSummarizeResults(t *testing.T) {
+	results := []CleanResult{
+		{Path: "/a", Size: 100, FreedSize: 100, Success: true},
+		{Path: "/b", Size: 200, FreedSize: 150, Success: false},
+		{Path: "/c", Size: 50, FreedSize: 0, Success: false},
+	}
+
+	count, freed, notFreed := SummarizeResults(results)
+	if count != 1 || freed != 250 || notFreed != 100 {
+		t.Errorf("SummarizeResults() = %d, %d, %d; want 1, 250, 100", count, freed, notFreed)
+	}
+}
+
+func TestTookLabel(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{0, ""},
+		{SlowDelete - time.Millisecond, ""},
+		{SlowDelete, "took 5s"},
+		{83*time.Second + 400*time.Millisecond, "took 1m23s"},
+	}
+
+	for _, tt := range tests {
+		if got := (CleanResult{Duration: tt.duration}).TookLabel(); got != tt.want {
+			t.Errorf("TookLabel(%v) = %q, want %q", tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestNewWithOptionsLogPath(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "custom.log")
+
+	c, err := NewWithOptions(types.CleanOptions{DryRun: true, LogPath: logPath})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	c.Logger().Println("hello")
+	c.Close()
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("log file not written: %v", err)
+	}
+	if len(data) == 0 {
+		t.Error("log file is empty")
+	}
+}
+
+func TestRotateLog(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "dev-cleaner.log")
+	if err := os.WriteFile(logPath, make([]byte, 200), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Below the limit: untouched
+	if err := rotateLog(logPath, 500); err != nil {
+		t.Fatalf("rotateLog() error = %v", err)
+	}
+	if _, err := os.Stat(logPath + ".1"); !os.IsNotExist(err) {
+		t.Error("rotateLog() rotated a log below the limit")
+	}
+
+	// Above the limit: moved to .1
+	if err := rotateLog(logPath, 100); err != nil {
+		t.Fatalf("rotateLog() error = %v", err)
+	}
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Error("rotateLog() left the oversized log in place")
+	}
+	if info, err := os.Stat(logPath + ".1"); err != nil || info.Size() != 200 {
+		t.Errorf("rotated log missing or wrong size: %v", err)
+	}
+}
+
+func TestFreeSpace(t *testing.T) {
+	dir := t.TempDir()
+
+	// The target itself need not exist; its parent's volume is measured
+	free, err := FreeSpace([]string{filepath.Join(dir, "gone", "node_modules"), filepath.Join(dir, "other")})
+	if err != nil {
+		t.Fatalf("FreeSpace() error = %v", err)
+	}
+	if free <= 0 {
+		t.Errorf("FreeSpace() = %d, want > 0", free)
+	}
+
+	if _, err := FreeSpace([]string{"docker:images"}); err == nil {
+		t.Error("FreeSpace() with only docker paths should fail")
+	}
+}
+
+func TestVerifySpace(t *testing.T) {
+	const mb = 1024 * 1024
+	results := []CleanResult{
+		{Path: "/a", Size: 100 * mb, FreedSize: 100 * mb, Success: true},
+		{Path: "docker:images", Size: 500 * mb, FreedSize: 500 * mb, Success: true},
+	}
+
+	check := VerifySpace(1000*mb, 1098*mb, results)
+	if check.Estimated != 100*mb {
+		t.Errorf("Estimated = %d, want docker excluded", check.Estimated)
+	}
+	if check.Diverges() {
+		t.Errorf("Diverges() = true for %+v, want false", check)
+	}
+	if got := check.String(); got != "verified freed: 98.0 MB (estimated: 100.0 MB)" {
+		t.Errorf("String() = %q", got)
+	}
+
+	if !VerifySpace(1000*mb, 1040*mb, results).Diverges() {
+		t.Error("Diverges() = false for 40 MB verified vs 100 MB estimated")
+	}
+}
+
+func TestPreviewLog(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	target := filepath.Join(home, "project", "node_modules")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	results := []types.ScanResult{
+		{Path: target, Size: 3 * 1024 * 1024},
+		{Path: types.DockerPathPrefix + "images", Size: 1024 * 1024},
+		{Path: types.DockerPathPrefix + "bogus"},
+	}
+
+	got := PreviewLog(results, false)
+	want := []string{
+		LogTimePlaceholder + " [DELETE] Removing: " + target + " (3.00 MB)",
+		LogTimePlaceholder + " [DELETE] Running: docker image prune -a -f",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("PreviewLog() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("PreviewLog()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// A dry-run writes exactly the previewed entries
+	logPath := filepath.Join(t.TempDir(), "clean.log")
+	c, err := NewWithOptions(types.CleanOptions{DryRun: true, LogPath: logPath})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	if _, err := c.Clean(results[:2]); err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+	c.Close()
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range PreviewLog(results[:2], true) {
+		entry := strings.TrimPrefix(line, LogTimePlaceholder+" ")
+		if !strings.Contains(string(data), entry+"\n") {
+			t.Errorf("log %q does not contain previewed entry %q", data, entry)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package cleaner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// errNoVolumes is returned by FreeSpace when none of the paths could be
+// mapped to a volume
+var errNoVolumes = errors.New("no measurable volumes")
+
+// FreeSpace returns the bytes available on the volumes holding paths,
+// counting each volume once. Each path is resolved through its nearest
+// existing parent, so the same volumes are measured before and after the
+// paths are deleted. Docker and simctl pseudo-paths are skipped.
+func FreeSpace(paths []string) (int64, error) {
+	seen := make(map[uint64]bool)
+	var total int64
+
+	for _, path := range paths {
+		if types.IsPseudoPath(path) {
+			continue
+		}
+
+		dir := existingParent(filepath.Dir(path))
+		if dir == "" {
+			continue
+		}
+
+		dev, free, err := volumeFree(dir)
+		if err != nil || seen[dev] {
+			continue
+		}
+		seen[dev] = true
+		total += free
+	}
+
+	if len(seen) == 0 {
+		return 0, errNoVolumes
+	}
+	return total, nil
+}
+
+// HomeFreeSpace returns the bytes available on the volume holding the home
+// directory, which is where nearly every scanned item lives
+func HomeFreeSpace() (int64, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return 0, err
+	}
+	_, free, err := volumeFree(home)
+	return free, err
+}
+
+// existingParent walks up from dir until it finds a directory that exists
+func existingParent(dir string) string {
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// SpaceCheck compares measured free space with the sizes the cleaner
+// reported, to catch sizing that was off (hard links, sparse files)
+type SpaceCheck struct {
+	Verified  int64 // Growth in free space on the target volumes
+	Estimated int64 // FreedSize summed over non-Docker results
+}
+
+// VerifySpace builds a SpaceCheck from free space measured before and after
+// a delete run. Pseudo-path results are excluded from the estimate: Docker
+// space lives inside the Docker VM disk image, and FreeSpace does not
+// measure the simctl device volume.
+func VerifySpace(before, after int64, results []CleanResult) SpaceCheck {
+	check := SpaceCheck{Verified: after - before}
+	for _, r := range results {
+		if !types.IsPseudoPath(r.Path) {
+			check.Estimated += r.FreedSize
+		}
+	}
+	return check
+}
+
+// Diverges reports whether verified and estimated differ by more than 10%
+// (and at least 10 MB, to ignore background disk activity)
+func (c SpaceCheck) Diverges() bool {
+	diff := c.Verified - c.Estimated
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff > 10*1024*1024 && diff*10 > c.Estimated
+}
+
+// String formats the check as "verified freed: X (estimated: Y)"
+func (c SpaceCheck) String() string {
+	verified := c.Verified
+	if verified < 0 {
+		verified = 0
+	}
+	return fmt.Sprintf("verified freed: %s (estimated: %s)", FormatSize(verified), FormatSize(c.Estimated))
+}
@@ -0,0 +1,10 @@
+//go:build !darwin && !linux
+
+package cleaner
+
+import "errors"
+
+// volumeFree is not implemented on this platform
+func volumeFree(dir string) (uint64, int64, error) {
+	return 0, 0, errors.New("free space not supported on this platform")
+}
@@ -0,0 +1,28 @@
+//go:build darwin || linux
+
+package cleaner
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// volumeFree returns the device id of the volume holding dir and the bytes
+// available on it to unprivileged users
+func volumeFree(dir string) (uint64, int64, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, fmt.Errorf("no device info for %s", dir)
+	}
+
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(dir, &fs); err != nil {
+		return 0, 0, err
+	}
+	return uint64(stat.Dev), int64(fs.Bavail) * int64(fs.Bsize), nil
+}
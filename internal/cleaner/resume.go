@@ -0,0 +1,97 @@
+package cleaner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// ResumeFileName is the interrupted-clean file, kept in the home directory
+const ResumeFileName = ".dev-cleaner-resume.json"
+
+// Resume lists the targets of a real clean that were not processed yet, so
+// an interrupted session can be continued with clean --resume
+type Resume struct {
+	Saved        time.Time          `json:"saved"`
+	AllowedRoots []string           `json:"allowedRoots,omitempty"` // --path roots of the session
+	Remaining    []types.ScanResult `json:"remaining"`
+}
+
+// DefaultResumePath returns the default resume location,
+// ~/.dev-cleaner-resume.json
+func DefaultResumePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ResumeFileName), nil
+}
+
+// LoadResume reads the resume file at path. A missing file is an empty
+// Resume (nothing to continue).
+func LoadResume(path string) (Resume, error) {
+	var resume Resume
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return resume, nil
+	}
+	if err != nil {
+		return resume, err
+	}
+
+	if err := json.Unmarshal(data, &resume); err != nil {
+		return resume, fmt.Errorf("invalid resume file %s: %w", path, err)
+	}
+	return resume, nil
+}
+
+// saveResume replaces the resume file atomically, so an interrupt while
+// writing it never leaves a truncated list
+func saveResume(path string, resume Resume) error {
+	data, err := json.MarshalIndent(resume, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ResumeFileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// SaveResume records remaining as the targets still to clean, logging
+// (rather than returning) failures like RecordStats. Dry-runs never write
+// a resume file.
+func (c *Cleaner) SaveResume(remaining []types.ScanResult) {
+	if c.dryRun || c.resumePath == "" {
+		return
+	}
+	resume := Resume{Saved: time.Now(), AllowedRoots: c.allowedRoots, Remaining: remaining}
+	if err := saveResume(c.resumePath, resume); err != nil {
+		c.logger.Printf("[ERROR] Failed to update resume file %s: %v\n", c.resumePath, err)
+	}
+}
+
+// ClearResume removes the resume file once a clean has completed
+func (c *Cleaner) ClearResume() {
+	if c.dryRun || c.resumePath == "" {
+		return
+	}
+	if err := os.Remove(c.resumePath); err != nil && !os.IsNotExist(err) {
+		c.logger.Printf("[ERROR] Failed to remove resume file %s: %v\n", c.resumePath, err)
+	}
+}
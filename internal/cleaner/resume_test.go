@@ -0,0 +1,74 @@
+package cleaner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestResume(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	resumePath := filepath.Join(t.TempDir(), ResumeFileName)
+
+	if resume, err := LoadResume(resumePath); err != nil || len(resume.Remaining) != 0 {
+		t.Fatalf("LoadResume() without a file = %+v, %v; want empty", resume, err)
+	}
+
+	var items []types.ScanResult
+	for _, name := range []string{"a/node_modules", "b/target"} {
+		path := filepath.Join(home, name)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, types.ScanResult{Path: path, Name: name, Size: 10})
+	}
+
+	dir := t.TempDir()
+	opts := types.CleanOptions{
+		LogPath:      filepath.Join(dir, "clean.log"),
+		StatsPath:    filepath.Join(dir, StatsFileName),
+		ResumePath:   resumePath,
+		AllowedRoots: []string{"/Volumes/Work"},
+	}
+
+	// Dry-runs never leave anything to resume
+	dry, err := NewWithOptions(types.CleanOptions{DryRun: true, LogPath: opts.LogPath, ResumePath: resumePath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dry.Close()
+	dry.SaveResume(items)
+	if _, err := os.Stat(resumePath); !os.IsNotExist(err) {
+		t.Fatalf("dry-run wrote resume file (err = %v)", err)
+	}
+
+	c, err := NewWithOptions(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// What an interrupted session leaves behind
+	c.SaveResume(items[1:])
+	resume, err := LoadResume(resumePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resume.Remaining) != 1 || resume.Remaining[0].Path != items[1].Path {
+		t.Errorf("Remaining = %+v, want %s", resume.Remaining, items[1].Path)
+	}
+	if len(resume.AllowedRoots) != 1 || resume.AllowedRoots[0] != "/Volumes/Work" {
+		t.Errorf("AllowedRoots = %v, want the session's roots", resume.AllowedRoots)
+	}
+
+	// A completed clean clears it
+	if _, err := c.Clean(items); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(resumePath); !os.IsNotExist(err) {
+		t.Errorf("resume file left after a completed clean (err = %v)", err)
+	}
+}
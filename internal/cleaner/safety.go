@@ -3,7 +3,10 @@ package cleaner
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
 
 // dangerousPaths are system paths that should never be deleted
@@ -30,10 +33,87 @@ var protectedPatterns = []string{
 	"Keychains",
 }
 
+// sourceMarkers are files and folders found at the top of a project or
+// repository. Build output, dependency and cache folders never hold them
+// directly, so a target that does is a misdetected project (e.g. a project
+// named "build" or "target").
+var sourceMarkers = []string{
+	".git",
+	".hg",
+	".svn",
+	"package.json",
+	"Cargo.toml",
+	"go.mod",
+	"pyproject.toml",
+	"setup.py",
+	"pom.xml",
+	"build.gradle",
+	"build.gradle.kts",
+	"settings.gradle",
+	"settings.gradle.kts",
+	"composer.json",
+	"Gemfile",
+	"mix.exs",
+	"pubspec.yaml",
+	"Package.swift",
+	"stack.yaml",
+	"cabal.project",
+}
+
+// CheckSourceMarkers refuses a folder that directly contains a project
+// manifest or version control folder (sourceMarkers). Paths that are not
+// readable folders pass; ValidatePath covers those.
+func CheckSourceMarkers(path string) error {
+	if types.IsPseudoPath(path) {
+		return nil
+	}
+	for _, marker := range sourceMarkers {
+		if _, err := os.Lstat(filepath.Join(path, marker)); err == nil {
+			return fmt.Errorf("refusing to delete %s: it contains %s, so it looks like a project rather than a build or cache folder", path, marker)
+		}
+	}
+	return nil
+}
+
+// CheckWorkingDir refuses the working directory and its ancestors:
+// removing them would leave the process in a deleted directory. Symlinks
+// are resolved on both sides, so /tmp and /private/tmp compare equal.
+func CheckWorkingDir(path string) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil // Nothing to protect
+	}
+	for _, dir := range resolvedPaths(wd) {
+		for _, target := range resolvedPaths(path) {
+			if rel, err := filepath.Rel(target, dir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return fmt.Errorf("refusing to delete %s: it contains the current working directory %s", path, wd)
+			}
+		}
+	}
+	return nil
+}
+
+// resolvedPaths returns path cleaned, and with its symlinks resolved when
+// that differs
+func resolvedPaths(path string) []string {
+	paths := []string{filepath.Clean(path)}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil && resolved != paths[0] {
+		paths = append(paths, resolved)
+	}
+	return paths
+}
+
 // ValidatePath checks if a path is safe to delete
 func ValidatePath(path string) error {
-	// Allow Docker pseudo-paths
-	if strings.HasPrefix(path, "docker:") {
+	return ValidatePathWithRoots(path, nil)
+}
+
+// ValidatePathWithRoots checks like ValidatePath but also accepts paths
+// strictly below one of allowedRoots (e.g. a project root on another
+// volume). System paths and protected patterns are refused regardless.
+func ValidatePathWithRoots(path string, allowedRoots []string) error {
+	// Allow Docker and simctl pseudo-paths
+	if types.IsPseudoPath(path) {
 		return nil
 	}
 
@@ -42,6 +122,11 @@ func ValidatePath(path string) error {
 		return fmt.Errorf("path must be absolute: %s", path)
 	}
 
+	// Never the directory the process runs in, or one above it
+	if err := CheckWorkingDir(path); err != nil {
+		return err
+	}
+
 	// Check against dangerous system paths
 	for _, dangerous := range dangerousPaths {
 		if strings.HasPrefix(path, dangerous) {
@@ -72,6 +157,14 @@ func ValidatePath(path string) error {
 		return nil
 	}
 
+	// Allow paths below explicitly approved roots, never a root itself
+	for _, root := range allowedRoots {
+		root = filepath.Clean(root)
+		if filepath.IsAbs(root) && strings.HasPrefix(filepath.Clean(path), root+string(filepath.Separator)) {
+			return nil
+		}
+	}
+
 	return fmt.Errorf("path outside home directory: %s", path)
 }
 
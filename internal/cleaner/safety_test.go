@@ -36,6 +36,62 @@ func TestValidatePath(t *testing.T) {
 	}
 }
 
+func TestValidatePathWorkingDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	home := os.Getenv("HOME")
+	project := filepath.Join(home, "proj")
+	wd := filepath.Join(project, "node_modules", "pkg")
+	if err := os.MkdirAll(wd, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(wd)
+
+	// The working directory and its ancestors are refused
+	for _, path := range []string{wd, filepath.Join(project, "node_modules"), project} {
+		if err := ValidatePath(path); err == nil {
+			t.Errorf("ValidatePath(%s) = nil, want the working directory refused", path)
+		}
+	}
+
+	// Siblings and children are not
+	for _, path := range []string{filepath.Join(home, "other"), filepath.Join(project, "node_modules-old"), filepath.Join(wd, "dist")} {
+		if err := ValidatePath(path); err != nil {
+			t.Errorf("ValidatePath(%s) error = %v, want nil", path, err)
+		}
+	}
+}
+
+func TestValidatePathWithRoots(t *testing.T) {
+	roots := []string{"/Volumes/Work/"}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{"under allowed root", "/Volumes/Work/app/node_modules", false},
+		{"allowed root itself", "/Volumes/Work", true},
+		{"sibling prefix", "/Volumes/WorkOld/app", true},
+		{"other volume", "/Volumes/Other/app", true},
+		{"protected under root", "/Volumes/Work/.ssh/id_rsa", true},
+		{"system path", "/usr/local/lib", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePathWithRoots(tt.path, roots)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePathWithRoots(%s) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+		})
+	}
+
+	// A dangerous root grants nothing
+	if err := ValidatePathWithRoots("/usr/local/bin", []string{"/usr"}); err == nil {
+		t.Error("ValidatePathWithRoots() allowed a system path via an allowed root")
+	}
+}
+
 func TestIsSafeToDelete(t *testing.T) {
 	home := os.Getenv("HOME")
 
@@ -56,3 +112,45 @@ func TestIsSafeToDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckSourceMarkers(t *testing.T) {
+	root := t.TempDir()
+	mkdir := func(parts ...string) string {
+		path := filepath.Join(append([]string{root}, parts...)...)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write := func(path string) {
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A project misdetected as an artifact: a repo named "build"
+	build := mkdir("build")
+	mkdir("build", ".git")
+	// A real artifact: manifests only further down
+	nodeModules := mkdir("app", "node_modules", "react")
+	write(filepath.Join(nodeModules, "package.json"))
+	// A project named "target" with a Cargo.toml
+	target := mkdir("target")
+	write(filepath.Join(target, "Cargo.toml"))
+
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{build, true},
+		{target, true},
+		{filepath.Dir(nodeModules), false},
+		{filepath.Join(root, "missing"), false},
+		{"docker:images", false},
+	}
+	for _, tt := range tests {
+		if err := CheckSourceMarkers(tt.path); (err != nil) != tt.wantErr {
+			t.Errorf("CheckSourceMarkers(%s) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+		}
+	}
+}
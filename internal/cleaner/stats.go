@@ -0,0 +1,117 @@
+package cleaner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StatsFileName is the cumulative stats file, kept in the home directory
+const StatsFileName = ".dev-cleaner-stats.json"
+
+// Stats is the running total of everything cleaned on this machine, kept in
+// ~/.dev-cleaner-stats.json for the stats command
+type Stats struct {
+	FirstUse   time.Time `json:"firstUse"`
+	LastClean  time.Time `json:"lastClean"`
+	BytesFreed int64     `json:"bytesFreed"`
+	Deletions  int       `json:"deletions"` // Items deleted, partial removals included
+	Sessions   int       `json:"sessions"`  // Clean runs that deleted something
+}
+
+// DefaultStatsPath returns the default stats location,
+// ~/.dev-cleaner-stats.json
+func DefaultStatsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, StatsFileName), nil
+}
+
+// LoadStats reads the stats file at path. A missing file is empty stats.
+func LoadStats(path string) (Stats, error) {
+	var stats Stats
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return stats, fmt.Errorf("invalid stats file %s: %w", path, err)
+	}
+	return stats, nil
+}
+
+// RecordStats adds a real (non-dry-run) clean session to the stats file at
+// path. Dry-run results and sessions that freed nothing are not recorded.
+func RecordStats(path string, results []CleanResult) error {
+	var freed int64
+	deletions := 0
+	for _, r := range results {
+		if r.WasDryRun || r.FreedSize <= 0 {
+			continue
+		}
+		freed += r.FreedSize
+		deletions++
+	}
+	if deletions == 0 {
+		return nil
+	}
+
+	stats, err := LoadStats(path)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if stats.FirstUse.IsZero() {
+		stats.FirstUse = now
+	}
+	stats.LastClean = now
+	stats.BytesFreed += freed
+	stats.Deletions += deletions
+	stats.Sessions++
+
+	return saveStats(path, stats)
+}
+
+// saveStats replaces the stats file atomically, so an interrupted write
+// never loses the running total
+func saveStats(path string, stats Stats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), StatsFileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// RecordStats adds results to the cleaner's stats file, logging (rather
+// than returning) failures: stats must never fail a cleanup
+func (c *Cleaner) RecordStats(results []CleanResult) {
+	if c.dryRun || c.statsPath == "" {
+		return
+	}
+	if err := RecordStats(c.statsPath, results); err != nil {
+		c.logger.Printf("[ERROR] Failed to update stats %s: %v\n", c.statsPath, err)
+	}
+}
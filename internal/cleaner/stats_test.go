@@ -0,0 +1,41 @@
+package cleaner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordStats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), StatsFileName)
+
+	// Dry-runs and sessions that freed nothing are not recorded
+	if err := RecordStats(path, []CleanResult{{Path: "/a", FreedSize: 100, Success: true, WasDryRun: true}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("dry-run created stats file (err = %v)", err)
+	}
+
+	if err := RecordStats(path, []CleanResult{
+		{Path: "/a", Size: 100, FreedSize: 100, Success: true},
+		{Path: "/b", Size: 50, FreedSize: 20},
+		{Path: "/c", Size: 10},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordStats(path, []CleanResult{{Path: "/d", Size: 30, FreedSize: 30, Success: true}}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := LoadStats(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.BytesFreed != 150 || stats.Deletions != 3 || stats.Sessions != 2 {
+		t.Errorf("stats = %+v, want 150 bytes, 3 deletions, 2 sessions", stats)
+	}
+	if stats.FirstUse.IsZero() || stats.LastClean.Before(stats.FirstUse) {
+		t.Errorf("FirstUse = %v, LastClean = %v", stats.FirstUse, stats.LastClean)
+	}
+}
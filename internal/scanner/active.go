@@ -0,0 +1,90 @@
+package scanner
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// activeProjectScanLimit caps how many files are checked per project when
+// looking for recent edits, so huge monorepos don't stall the scan
+const activeProjectScanLimit = 20000
+
+// buildOutputDirs are generated directories that don't count as edits even
+// when another category owns them (a fresh target/ next to node_modules)
+var buildOutputDirs = map[string]bool{
+	"build": true, "target": true, "_build": true, "deps": true, "dist": true,
+	"bin": true, "obj": true, "vendor": true, "venv": true, "__pycache__": true,
+	"Pods": true, "DerivedData": true,
+}
+
+// errFoundRecent stops a project walk at the first recently edited file
+var errFoundRecent = errors.New("found recent file")
+
+// protectActiveProjects marks project build output (inactive tier results
+// such as node_modules, target or _build) as Risky when a source file in
+// the project was modified within the last days. The project root is the
+// artifact's parent directory; its artifacts and build output are not
+// counted as edits.
+func protectActiveProjects(results []types.ScanResult, days int) {
+	if days <= 0 {
+		return
+	}
+
+	artifacts := make(map[string]bool)
+	for _, result := range results {
+		artifacts[result.Path] = true
+	}
+
+	cutoff := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	active := make(map[string]bool) // Project root -> recently edited
+	for i := range results {
+		result := &results[i]
+		if result.Tier() != types.TierInactive || !filepath.IsAbs(result.Path) {
+			continue
+		}
+
+		root := filepath.Dir(result.Path)
+		recent, checked := active[root]
+		if !checked {
+			recent = editedSince(root, cutoff, artifacts)
+			active[root] = recent
+		}
+		if recent {
+			result.Risky = true
+		}
+	}
+}
+
+// editedSince reports whether any file below root, outside hidden dirs,
+// skipped dirs, build output and the given artifact paths, was modified
+// after cutoff. Projects larger than activeProjectScanLimit files are only
+// partly checked.
+func editedSince(root string, cutoff time.Time, artifacts map[string]bool) bool {
+	files := 0
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (shouldSkipDir(d.Name()) || buildOutputDirs[d.Name()] || artifacts[path]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		files++
+		if files > activeProjectScanLimit {
+			return filepath.SkipAll
+		}
+		info, err := d.Info()
+		if err == nil && info.ModTime().After(cutoff) {
+			return errFoundRecent
+		}
+		return nil
+	})
+	return errors.Is(err, errFoundRecent)
+}
@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// backdate sets the mtime of every file below root to age ago
+func backdate(t *testing.T, root string, age time.Duration) {
+	t.Helper()
+	old := time.Now().Add(-age)
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProtectActiveProjects(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "old", "Cargo.toml"))
+	writeTestFile(t, filepath.Join(root, "old", "target", "debug", "old"))
+	writeTestFile(t, filepath.Join(root, "edited", "package.json"))
+	writeTestFile(t, filepath.Join(root, "edited", "node_modules", "react", "index.js"))
+	backdate(t, root, 30*24*time.Hour)
+
+	// Fresh build output alone doesn't make a project active
+	writeTestFile(t, filepath.Join(root, "old", "target", "debug", "incremental"))
+	writeTestFile(t, filepath.Join(root, "edited", "src", "app.js"))
+
+	results := []types.ScanResult{
+		{Path: filepath.Join(root, "old", "target"), SafetyTier: types.TierInactive},
+		{Path: filepath.Join(root, "edited", "node_modules"), SafetyTier: types.TierInactive},
+		{Path: "docker:containers", SafetyTier: types.TierInactive},
+	}
+
+	protectActiveProjects(results, 0)
+	for _, result := range results {
+		if result.Risky {
+			t.Errorf("%s marked Risky with protection off", result.Path)
+		}
+	}
+
+	protectActiveProjects(results, 7)
+	if results[0].Risky {
+		t.Error("target of project untouched for 30 days marked Risky")
+	}
+	if !results[1].Risky {
+		t.Error("node_modules of recently edited project not marked Risky")
+	}
+	if results[2].Risky {
+		t.Error("non-path result marked Risky")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !darwin && !linux
+
+package scanner
+
+import "io/fs"
+
+// allocatedSize is not implemented on this platform; sparse files count
+// at their full size
+func allocatedSize(info fs.FileInfo) int64 {
+	return info.Size()
+}
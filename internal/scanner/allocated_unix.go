@@ -0,0 +1,17 @@
+//go:build darwin || linux
+
+package scanner
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// allocatedSize returns the disk space the file described by info takes
+// up, which is less than its size for sparse files such as VM disk images
+func allocatedSize(info fs.FileInfo) int64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Blocks * 512
+	}
+	return info.Size()
+}
@@ -1,19 +1,44 @@
 package scanner
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
 
 // AndroidPaths contains default Android-related paths to scan
+// (Gradle caches are handled by ScanGradle, system images and emulators
+// are listed individually)
 var AndroidPaths = []struct {
 	Path string
 	Name string
 }{
-	{"~/.gradle/caches", "Gradle Caches"},
-	{"~/.gradle/wrapper", "Gradle Wrapper"},
 	{"~/.android/cache", "Android SDK Cache"},
 	{"~/.android/build-cache", "Android Build Cache"},
-	{"~/Library/Android/sdk/system-images", "Android System Images"},
+}
+
+// getAndroidSDK returns ANDROID_HOME, ANDROID_SDK_ROOT or the macOS default
+// ~/Library/Android/sdk
+func getAndroidSDK() string {
+	if sdk := os.Getenv("ANDROID_HOME"); sdk != "" {
+		return sdk
+	}
+	if sdk := os.Getenv("ANDROID_SDK_ROOT"); sdk != "" {
+		return sdk
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Library", "Android", "sdk")
+}
+
+// getAVDHome returns ANDROID_AVD_HOME or default ~/.android/avd
+func getAVDHome() string {
+	if avdHome := os.Getenv("ANDROID_AVD_HOME"); avdHome != "" {
+		return avdHome
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".android", "avd")
 }
 
 // ScanAndroid scans for Android development artifacts
@@ -32,13 +57,101 @@ func (s *Scanner) ScanAndroid() []types.ScanResult {
 		}
 
 		results = append(results, types.ScanResult{
-			Path:      path,
-			Type:      types.TypeAndroid,
-			Size:      size,
-			FileCount: count,
-			Name:      target.Name,
+			Path:       path,
+			Type:       types.TypeAndroid,
+			Size:       size,
+			FileCount:  count,
+			Name:       target.Name,
+			SafetyTier: types.TierSafe,
 		})
 	}
 
+	results = append(results, s.scanSystemImages()...)
+	results = append(results, s.scanAVDs()...)
+
+	return results
+}
+
+// scanSystemImages lists each installed system image separately, laid out
+// as system-images/<api>/<tag>/<abi> (e.g. android-29/google_apis/x86), so
+// old images can be removed while keeping current ones
+func (s *Scanner) scanSystemImages() []types.ScanResult {
+	var results []types.ScanResult
+
+	root := filepath.Join(getAndroidSDK(), "system-images")
+	apis, err := os.ReadDir(root)
+	if err != nil {
+		return results
+	}
+
+	for _, api := range apis {
+		if !api.IsDir() {
+			continue
+		}
+		tags, err := os.ReadDir(filepath.Join(root, api.Name()))
+		if err != nil {
+			continue
+		}
+		for _, tag := range tags {
+			if !tag.IsDir() {
+				continue
+			}
+			abis, err := os.ReadDir(filepath.Join(root, api.Name(), tag.Name()))
+			if err != nil {
+				continue
+			}
+			for _, abi := range abis {
+				if !abi.IsDir() {
+					continue
+				}
+				imagePath := filepath.Join(root, api.Name(), tag.Name(), abi.Name())
+				size, count, _ := s.calculateSize(imagePath)
+				if size > 0 {
+					results = append(results, types.ScanResult{
+						Path:       imagePath,
+						Type:       types.TypeAndroid,
+						Size:       size,
+						FileCount:  count,
+						Name:       strings.Join([]string{"system-images", api.Name(), tag.Name(), abi.Name()}, "/"),
+						SafetyTier: types.TierReview,
+					})
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+// scanAVDs lists each emulator's data folder (<name>.avd) separately. The
+// matching <name>.ini is tiny and left in place; the emulator manager then
+// shows the device as broken so it can be removed there.
+func (s *Scanner) scanAVDs() []types.ScanResult {
+	var results []types.ScanResult
+
+	avdHome := getAVDHome()
+	entries, err := os.ReadDir(avdHome)
+	if err != nil {
+		return results
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasSuffix(entry.Name(), ".avd") {
+			continue
+		}
+		avdPath := filepath.Join(avdHome, entry.Name())
+		size, count, _ := s.calculateSize(avdPath)
+		if size > 0 {
+			results = append(results, types.ScanResult{
+				Path:       avdPath,
+				Type:       types.TypeAndroid,
+				Size:       size,
+				FileCount:  count,
+				Name:       "AVD " + strings.TrimSuffix(entry.Name(), ".avd"),
+				SafetyTier: types.TierReview,
+			})
+		}
+	}
+
 	return results
 }
@@ -0,0 +1,49 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestScanAndroidGranular(t *testing.T) {
+	sdk := t.TempDir()
+	avdHome := t.TempDir()
+	t.Setenv("ANDROID_HOME", sdk)
+	t.Setenv("ANDROID_AVD_HOME", avdHome)
+
+	dirs := []string{
+		filepath.Join(sdk, "system-images/android-29/google_apis/x86"),
+		filepath.Join(sdk, "system-images/android-34/google_apis/arm64-v8a"),
+		filepath.Join(avdHome, "Pixel_7.avd"),
+	}
+	for _, dir := range dirs {
+		os.MkdirAll(dir, 0755)
+		os.WriteFile(filepath.Join(dir, "data"), make([]byte, 100), 0644)
+	}
+	os.WriteFile(filepath.Join(avdHome, "Pixel_7.ini"), []byte("path=x"), 0644)
+
+	s, _ := New()
+	results := s.ScanAndroid()
+
+	paths := make(map[string]string)
+	for _, r := range results {
+		if r.Type != types.TypeAndroid {
+			t.Errorf("expected type %s, got %s", types.TypeAndroid, r.Type)
+		}
+		paths[r.Name] = r.Path
+	}
+
+	want := map[string]string{
+		"system-images/android-29/google_apis/x86":       dirs[0],
+		"system-images/android-34/google_apis/arm64-v8a": dirs[1],
+		"AVD Pixel_7": dirs[2],
+	}
+	for name, path := range want {
+		if paths[name] != path {
+			t.Errorf("expected %q at %s, got %v", name, path, paths)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// condaInstallDirs are the usual Anaconda, Miniconda and Miniforge install
+// locations (the macOS .pkg installers use ~/opt)
+var condaInstallDirs = []string{
+	"~/miniconda3",
+	"~/anaconda3",
+	"~/miniforge3",
+	"~/mambaforge",
+	"~/opt/miniconda3",
+	"~/opt/anaconda3",
+}
+
+// condaInstalls returns the conda installations to scan: the usual
+// locations, plus the one CONDA_PREFIX belongs to (it points at the active
+// environment, either the base install or <install>/envs/<name>)
+func (s *Scanner) condaInstalls() []string {
+	var installs []string
+	for _, dir := range condaInstallDirs {
+		installs = append(installs, s.ExpandPath(dir))
+	}
+	if prefix := os.Getenv("CONDA_PREFIX"); prefix != "" {
+		if filepath.Base(filepath.Dir(prefix)) == "envs" {
+			prefix = filepath.Dir(filepath.Dir(prefix))
+		}
+		installs = append(installs, prefix)
+	}
+	return installs
+}
+
+// condaEnvDirs returns the directories holding named conda environments:
+// CONDA_ENVS_PATH, each installation's envs and ~/.conda/envs
+func (s *Scanner) condaEnvDirs(installs []string) []string {
+	dirs := filepath.SplitList(os.Getenv("CONDA_ENVS_PATH"))
+	for _, install := range installs {
+		dirs = append(dirs, filepath.Join(install, "envs"))
+	}
+	return append(dirs, s.ExpandPath("~/.conda/envs"))
+}
+
+// isActiveCondaEnv reports whether the environment at path is the one
+// activated in this shell (CONDA_PREFIX, or CONDA_DEFAULT_ENV by name)
+func isActiveCondaEnv(path string) bool {
+	if prefix := os.Getenv("CONDA_PREFIX"); prefix != "" && filepath.Clean(prefix) == path {
+		return true
+	}
+	name := os.Getenv("CONDA_DEFAULT_ENV")
+	return name != "" && (name == filepath.Base(path) || filepath.Clean(name) == path)
+}
+
+// ScanConda scans conda installations for named environments, each its
+// own result, and package caches. The base environment is the
+// installation itself and is never listed.
+func (s *Scanner) ScanConda() []types.ScanResult {
+	var results []types.ScanResult
+	seen := make(map[string]bool)
+
+	installs := s.condaInstalls()
+	for _, dir := range s.condaEnvDirs(installs) {
+		dir = filepath.Clean(dir)
+		if dir == "." || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		if !s.PathExists(dir) {
+			continue
+		}
+
+		entries, err := s.readDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			env := filepath.Join(dir, entry.Name())
+			// Every environment has a conda-meta folder
+			if !entry.IsDir() || !s.PathExists(filepath.Join(env, "conda-meta")) {
+				continue
+			}
+			size, count, _ := s.calculateSize(env)
+			if size == 0 {
+				continue
+			}
+			name := "conda env " + entry.Name()
+			risky := isActiveCondaEnv(env)
+			if risky {
+				name += " (active)"
+			}
+			results = append(results, types.ScanResult{
+				Path:       env,
+				Type:       types.TypePython,
+				Size:       size,
+				FileCount:  count,
+				Name:       name,
+				SafetyTier: types.TierReview, // Recreating may not give the same versions
+				Risky:      risky,
+			})
+		}
+	}
+
+	// Package caches are what conda clean --all removes
+	pkgDirs := []string{s.ExpandPath("~/.conda/pkgs")}
+	for _, install := range installs {
+		pkgDirs = append(pkgDirs, filepath.Join(install, "pkgs"))
+	}
+	for _, dir := range pkgDirs {
+		dir = filepath.Clean(dir)
+		if seen[dir] || !s.PathExists(dir) {
+			continue
+		}
+		seen[dir] = true
+		results = append(results, s.scanCacheRoot(dir, "conda Package Cache", types.TypePython)...)
+	}
+
+	return results
+}
@@ -0,0 +1,57 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestScanConda(t *testing.T) {
+	s, _ := newFixtureScanner(t)
+	home := s.ExpandPath("~")
+	extra := t.TempDir()
+	t.Setenv("CONDA_PREFIX", "")
+	t.Setenv("CONDA_ENVS_PATH", extra)
+	t.Setenv("CONDA_DEFAULT_ENV", "ml")
+
+	miniconda := filepath.Join(home, "miniconda3")
+	writeTestFile(t, filepath.Join(miniconda, "envs", "ml", "conda-meta", "history"))
+	writeTestFile(t, filepath.Join(miniconda, "envs", "old", "conda-meta", "history"))
+	writeTestFile(t, filepath.Join(miniconda, "envs", "not-an-env", "file"))
+	writeTestFile(t, filepath.Join(miniconda, "pkgs", "numpy-1.26", "info", "index.json"))
+	writeTestFile(t, filepath.Join(home, ".conda", "envs", "torch", "conda-meta", "history"))
+	writeTestFile(t, filepath.Join(extra, "scratch", "conda-meta", "history"))
+
+	results := s.ScanConda()
+	byName := make(map[string]types.ScanResult)
+	for _, result := range results {
+		if result.Type != types.TypePython {
+			t.Errorf("result %+v, want a python result", result)
+		}
+		byName[result.Name] = result
+	}
+
+	want := []string{
+		"conda env ml (active)",
+		"conda env old",
+		"conda env torch",
+		"conda env scratch",
+		"conda Package Cache",
+	}
+	if len(results) != len(want) {
+		t.Errorf("ScanConda() returned %d results, want %d: %v", len(results), len(want), byName)
+	}
+	for _, name := range want {
+		if _, ok := byName[name]; !ok {
+			t.Errorf("ScanConda() missing %q, got %v", name, byName)
+		}
+	}
+
+	if !byName["conda env ml (active)"].Risky || byName["conda env old"].Risky {
+		t.Error("only the active environment should be Risky")
+	}
+	if tier := byName["conda Package Cache"].SafetyTier; tier != types.TierSafe {
+		t.Errorf("package cache tier = %s, want safe", tier)
+	}
+}
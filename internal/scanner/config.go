@@ -0,0 +1,124 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// ConfigFileName is the scanner config file, looked up in the home directory
+const ConfigFileName = ".dev-cleaner.json"
+
+// CustomTarget is a site-specific cache directory from the config file,
+// scanned alongside the built-in targets of its type
+type CustomTarget struct {
+	Path string                `json:"path"` // May use ~ and $ENV_VARS
+	Name string                `json:"name"`
+	Type types.CleanTargetType `json:"type"` // Category it is scanned with, e.g. "node"
+}
+
+// Config is the contents of ~/.dev-cleaner.json
+type Config struct {
+	CustomTargets []CustomTarget `json:"customTargets"`
+}
+
+// loadConfig reads the config file at path. A missing file is an empty
+// config; invalid JSON or a target without a path or with an unknown type
+// is an error.
+func loadConfig(path string) (Config, error) {
+	var config Config
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	for _, target := range config.CustomTargets {
+		if target.Path == "" {
+			return config, fmt.Errorf("invalid config %s: custom target %q has no path", path, target.Name)
+		}
+		if _, known := includesType(types.ScanOptions{}, target.Type); !known {
+			return config, fmt.Errorf("invalid config %s: custom target %s has unknown type %q", path, target.Path, target.Type)
+		}
+	}
+
+	return config, nil
+}
+
+// includesType reports whether opts enables scanning results of type t, and
+// whether t is a known type at all
+func includesType(opts types.ScanOptions, t types.CleanTargetType) (included, known bool) {
+	switch t {
+	case types.TypeXcode:
+		return opts.IncludeXcode, true
+	case types.TypeAndroid:
+		return opts.IncludeAndroid, true
+	case types.TypeNode:
+		return opts.IncludeNode, true
+	case types.TypeReactNative:
+		return opts.IncludeReactNative, true
+	case types.TypeFlutter:
+		return opts.IncludeFlutter, true
+	case types.TypePython:
+		return opts.IncludePython, true
+	case types.TypeRust:
+		return opts.IncludeRust, true
+	case types.TypeGo:
+		return opts.IncludeGo, true
+	case types.TypeHomebrew:
+		return opts.IncludeHomebrew, true
+	case types.TypeDocker:
+		return opts.IncludeDocker, true
+	case types.TypeJava:
+		return opts.IncludeJava, true
+	case types.TypeDeno:
+		return opts.IncludeDeno, true
+	case types.TypeDotNet, types.TypeUnity:
+		return opts.IncludeDotNet, true
+	case types.TypePHP:
+		return opts.IncludePHP, true
+	case types.TypeElixir:
+		return opts.IncludeElixir, true
+	case types.TypeHaskell:
+		return opts.IncludeHaskell, true
+	case types.TypeJetBrains:
+		return opts.IncludeJetBrains, true
+	}
+	return false, false
+}
+
+// ScanCustom scans the config file's custom targets whose type is enabled
+// in opts
+func (s *Scanner) ScanCustom(opts types.ScanOptions) []types.ScanResult {
+	var results []types.ScanResult
+
+	for _, target := range s.customTargets {
+		if included, _ := includesType(opts, target.Type); !included {
+			continue
+		}
+
+		path := filepath.Clean(s.ExpandPath(os.ExpandEnv(target.Path)))
+		if !s.PathExists(path) {
+			continue
+		}
+
+		name := target.Name
+		if name == "" {
+			name = filepath.Base(path)
+		}
+
+		results = append(results, s.scanCacheRoot(path, name, target.Type)...)
+	}
+
+	return results
+}
@@ -0,0 +1,79 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	// Missing file is an empty config
+	config, err := loadConfig(filepath.Join(dir, "missing.json"))
+	if err != nil || len(config.CustomTargets) != 0 {
+		t.Errorf("loadConfig(missing) = %+v, %v; want empty, nil", config, err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"valid", `{"customTargets": [{"path": "~/corp-cache", "name": "Corp Cache", "type": "node"}]}`, false},
+		{"invalid json", `{"customTargets": [`, true},
+		{"no path", `{"customTargets": [{"name": "Empty", "type": "node"}]}`, true},
+		{"unknown type", `{"customTargets": [{"path": "/tmp/x", "type": "cobol"}]}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "config.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := loadConfig(path); (err != nil) != tt.wantErr {
+				t.Errorf("loadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScanCustom(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("CORP_CACHE", filepath.Join(home, "corp"))
+
+	writeTestFile(t, filepath.Join(home, "corp", "blob"))
+	writeTestFile(t, filepath.Join(home, "tools-cache", "blob"))
+
+	config := `{"customTargets": [
+		{"path": "$CORP_CACHE", "name": "Corp Cache", "type": "node"},
+		{"path": "~/tools-cache", "type": "java"}
+	]}`
+	if err := os.WriteFile(filepath.Join(home, ConfigFileName), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// Only targets of enabled types are scanned
+	results := s.ScanCustom(types.ScanOptions{IncludeNode: true})
+	if len(results) != 1 {
+		t.Fatalf("ScanCustom() returned %d results, want 1: %v", len(results), results)
+	}
+	if results[0].Name != "Corp Cache" || results[0].Type != types.TypeNode || results[0].Path != filepath.Join(home, "corp") {
+		t.Errorf("ScanCustom() = %+v, want Corp Cache node result", results[0])
+	}
+
+	// Name defaults to the directory name
+	results = s.ScanCustom(types.ScanOptions{IncludeJava: true})
+	if len(results) != 1 || results[0].Name != "tools-cache" {
+		t.Errorf("ScanCustom() = %+v, want tools-cache result", results)
+	}
+}
@@ -0,0 +1,30 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// getDenoDir returns DENO_DIR or the macOS default ~/Library/Caches/deno
+func getDenoDir() string {
+	if denoDir := os.Getenv("DENO_DIR"); denoDir != "" {
+		return denoDir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Library", "Caches", "deno")
+}
+
+// ScanDeno scans for Deno runtime caches (remote modules, npm packages,
+// compiled output)
+func (s *Scanner) ScanDeno() []types.ScanResult {
+	var results []types.ScanResult
+
+	denoDir := getDenoDir()
+	if s.PathExists(denoDir) {
+		results = append(results, s.scanCacheRoot(denoDir, "Deno Cache", types.TypeDeno)...)
+	}
+
+	return results
+}
@@ -0,0 +1,38 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestScanDeno(t *testing.T) {
+	denoDir := t.TempDir()
+	t.Setenv("DENO_DIR", denoDir)
+
+	if got := getDenoDir(); got != denoDir {
+		t.Errorf("getDenoDir() = %q, want %q", got, denoDir)
+	}
+
+	if err := os.MkdirAll(filepath.Join(denoDir, "deps"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(denoDir, "deps", "mod.ts"), []byte("export {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	results := s.ScanDeno()
+	if len(results) != 1 {
+		t.Fatalf("ScanDeno() returned %d results, want 1", len(results))
+	}
+	if results[0].Type != types.TypeDeno || results[0].Path != denoDir {
+		t.Errorf("ScanDeno() = %+v, want deno result for %s", results[0], denoDir)
+	}
+}
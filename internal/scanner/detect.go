@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"os/exec"
+	"path/filepath"
+)
+
+// lookPath finds a command on PATH (replaced in tests)
+var lookPath = exec.LookPath
+
+// toolchain tells whether an ecosystem is installed: any of its commands on
+// PATH or any of its home directories existing is enough
+type toolchain struct {
+	category string   // Category name as used in settings, e.g. "rust"
+	commands []string // Executables looked up on PATH
+	paths    []string // Directories that only exist once it was used
+}
+
+// toolchains lists the detection rules for every category, resolving the
+// same environment overrides the scanners honor
+func (s *Scanner) toolchains() []toolchain {
+	return []toolchain{
+		{"xcode", []string{"xcodebuild"}, []string{"/Applications/Xcode.app", s.ExpandPath("~/Library/Developer/Xcode")}},
+		{"android", []string{"adb", "sdkmanager"}, []string{getAndroidSDK(), s.ExpandPath("~/.android")}},
+		{"node", []string{"node", "npm", "bun"}, []string{s.ExpandPath("~/.npm")}},
+		{"react-native", []string{"react-native"}, []string{s.ExpandPath("~/.npm")}},
+		{"flutter", []string{"flutter", "dart"}, []string{s.ExpandPath("~/.pub-cache")}},
+		{"python", []string{"python3", "pip3", "poetry", "uv"}, nil},
+		{"rust", []string{"cargo", "rustc"}, []string{getCargoHome()}},
+		{"go", []string{"go"}, []string{getGOCACHE(), filepath.Dir(filepath.Dir(getGOMODCACHE()))}},
+		{"homebrew", []string{"brew"}, nil},
+		{"docker", []string{"docker"}, nil},
+		{"java", []string{"java", "mvn", "gradle"}, []string{s.ExpandPath("~/.m2"), getGradleUserHome()}},
+		{"deno", []string{"deno"}, []string{getDenoDir()}},
+		{"dotnet", []string{"dotnet"}, []string{getNuGetPackages(), "/Applications/Unity/Hub"}},
+		{"php", []string{"php", "composer"}, s.getComposerCacheDirs()},
+		{"elixir", []string{"mix", "elixir", "erl", "rebar3"}, []string{getHexHome(), getMixHome()}},
+		{"haskell", []string{"stack", "cabal", "ghc"}, []string{getStackRoot(), getCabalDir()}},
+		{"jetbrains", nil, []string{s.ExpandPath("~/Library/Caches/JetBrains")}},
+	}
+}
+
+// DetectCategories returns the categories whose toolchain is installed
+// (a command on PATH or its cache/home directory present), so --auto can
+// skip project-tree walks for ecosystems that are not used on this machine
+func (s *Scanner) DetectCategories() []string {
+	var categories []string
+	for _, tc := range s.toolchains() {
+		if s.toolchainInstalled(tc) {
+			categories = append(categories, tc.category)
+		}
+	}
+	return categories
+}
+
+// toolchainInstalled reports whether any of tc's commands or paths exist
+func (s *Scanner) toolchainInstalled(tc toolchain) bool {
+	for _, command := range tc.commands {
+		if _, err := lookPath(command); err == nil {
+			return true
+		}
+	}
+	for _, path := range tc.paths {
+		if s.PathExists(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package scanner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDetectCategories(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, env := range []string{"ANDROID_HOME", "ANDROID_SDK_ROOT", "CARGO_HOME", "GOCACHE", "GOMODCACHE", "GOPATH",
+		"GRADLE_USER_HOME", "DENO_DIR", "NUGET_PACKAGES", "COMPOSER_HOME", "COMPOSER_CACHE_DIR"} {
+		t.Setenv(env, "")
+	}
+
+	// Only "go" is on PATH, and only ~/.cargo exists
+	origLookPath := lookPath
+	defer func() { lookPath = origLookPath }()
+	lookPath = func(command string) (string, error) {
+		if command == "go" {
+			return "/usr/local/go/bin/go", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	if err := os.MkdirAll(filepath.Join(home, ".cargo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got := s.DetectCategories()
+	if !slices.Equal(got, []string{"rust", "go"}) {
+		t.Errorf("DetectCategories() = %v, want [rust go]", got)
+	}
+}
@@ -2,7 +2,9 @@ package scanner
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 
@@ -60,20 +62,119 @@ func parseDockerSize(sizeStr string) int64 {
 	return int64(value * float64(multiplier))
 }
 
-// isDockerAvailable checks if Docker daemon is running
-func isDockerAvailable() bool {
-	cmd := exec.Command("docker", "info")
-	err := cmd.Run()
-	return err == nil
+// parseDockerPercent extracts the percentage from a Reclaimable value like
+// "4.2GB (80%)", returning 0 when there is none
+func parseDockerPercent(reclaimable string) int {
+	start := strings.Index(reclaimable, "(")
+	end := strings.Index(reclaimable, "%)")
+	if start < 0 || end <= start {
+		return 0
+	}
+
+	var percent float64
+	if _, err := fmt.Sscanf(reclaimable[start+1:end], "%f", &percent); err != nil {
+		return 0
+	}
+	return int(percent + 0.5)
+}
+
+// DockerStatus tells whether Docker artifacts can be scanned
+type DockerStatus int
+
+const (
+	DockerNotInstalled DockerStatus = iota // No docker CLI on PATH
+	DockerStopped                          // CLI installed, daemon not running
+	DockerRunning
+)
+
+// DockerStoppedNote is the scan report note for DockerStopped
+const DockerStoppedNote = "Docker is installed but its daemon is not running: start Docker to scan unused images, containers and build cache"
+
+// DockerDiskImageNote is the scan report note when Docker Desktop's disk
+// image is reported in place of the daemon's breakdown
+const DockerDiskImageNote = "Docker's daemon is not running, so only its disk image is listed: start Docker and run docker system prune to reclaim space inside it, or delete the image to reset Docker (removes all images, containers and volumes)"
+
+// dockerDiskImages are Docker Desktop's VM disk images below the home
+// directory: Docker.raw in current releases, Docker.qcow2 in older ones
+var dockerDiskImages = []string{
+	"~/Library/Containers/com.docker.docker/Data/vms/0/data/Docker.raw",
+	"~/Library/Containers/com.docker.docker/Data/vms/0/Docker.qcow2",
+}
+
+// dockerInfo runs `docker info`, which fails when the daemon is down
+// (replaced in tests)
+var dockerInfo = func() error {
+	return exec.Command("docker", "info").Run()
+}
+
+// dockerError describes a failed docker command by its stderr, e.g. a
+// permission error on the daemon socket, rather than "exit status 1"
+func dockerError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if msg := strings.TrimSpace(string(exitErr.Stderr)); msg != "" {
+			return fmt.Errorf("docker system df: %s", msg)
+		}
+	}
+	return fmt.Errorf("docker system df: %w", err)
+}
+
+// getDockerStatus checks for the docker CLI, then for a running daemon
+func getDockerStatus() DockerStatus {
+	if _, err := lookPath("docker"); err != nil {
+		return DockerNotInstalled
+	}
+	if err := dockerInfo(); err != nil {
+		return DockerStopped
+	}
+	return DockerRunning
+}
+
+// scanDockerDiskImage reports Docker Desktop's VM disk image, which holds
+// every image, container and volume, as a single result. Its size is the
+// space the sparse file takes up on disk, not its maximum size.
+func (s *Scanner) scanDockerDiskImage() []types.ScanResult {
+	for _, image := range dockerDiskImages {
+		path := s.ExpandPath(image)
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		size := allocatedSize(info)
+		if size == 0 {
+			continue
+		}
+		return []types.ScanResult{{
+			Path:       path,
+			Type:       types.TypeDocker,
+			Size:       size,
+			FileCount:  1,
+			Name:       "Docker Desktop Disk Image (daemon stopped; prune or reset)",
+			SafetyTier: types.TierReview, // Deleting it resets Docker
+		}}
+	}
+	return nil
 }
 
 // ScanDocker scans for Docker artifacts using docker CLI
 func (s *Scanner) ScanDocker() []types.ScanResult {
 	var results []types.ScanResult
 
-	// Check if Docker is available
-	if !isDockerAvailable() {
-		// Docker not installed or not running - skip silently
+	// Not installed: nothing to report. Stopped: list the disk image, or
+	// say so instead of looking like there is nothing to clean.
+	status := DockerStopped
+	if !s.dockerStopped {
+		status = getDockerStatus()
+	}
+	switch status {
+	case DockerNotInstalled:
+		return results
+	case DockerStopped:
+		if image := s.scanDockerDiskImage(); len(image) > 0 {
+			s.addNote(DockerDiskImageNote)
+			return image
+		}
+		s.addNote(DockerStoppedNote)
 		return results
 	}
 
@@ -81,6 +182,7 @@ func (s *Scanner) ScanDocker() []types.ScanResult {
 	cmd := exec.Command("docker", "system", "df", "--format", "{{json .}}")
 	output, err := cmd.Output()
 	if err != nil {
+		s.addError("docker", dockerError(err))
 		return results
 	}
 
@@ -104,13 +206,16 @@ func (s *Scanner) ScanDocker() []types.ScanResult {
 
 		// Create result for each Docker resource type
 		var name string
+		tier := types.TierSafe
 		switch df.Type {
 		case "Images":
 			name = "Docker Images (unused)"
 		case "Containers":
 			name = "Docker Containers (stopped)"
+			tier = types.TierInactive
 		case "Local Volumes":
 			name = "Docker Volumes (unused)"
+			tier = types.TierReview // Volumes hold data, not rebuildable artifacts
 		case "Build Cache":
 			name = "Docker Build Cache"
 		default:
@@ -118,11 +223,14 @@ func (s *Scanner) ScanDocker() []types.ScanResult {
 		}
 
 		results = append(results, types.ScanResult{
-			Path:      "docker:" + strings.ToLower(strings.ReplaceAll(df.Type, " ", "-")),
-			Type:      types.TypeDocker,
-			Size:      reclaimSize,
-			FileCount: df.TotalCount - df.Active,
-			Name:      name,
+			Path:       types.DockerPathPrefix + strings.ToLower(strings.ReplaceAll(df.Type, " ", "-")),
+			Type:       types.TypeDocker,
+			Size:       reclaimSize,
+			FileCount:  max(df.TotalCount-df.Active, 0), // Active can exceed TotalCount mid-change
+			Name:       name,
+			SafetyTier: tier,
+
+			ReclaimablePercent: parseDockerPercent(df.Reclaimable),
 		})
 	}
 
@@ -0,0 +1,106 @@
+package scanner
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestScanDockerStatus(t *testing.T) {
+	origLookPath, origDockerInfo := lookPath, dockerInfo
+	defer func() { lookPath, dockerInfo = origLookPath, origDockerInfo }()
+
+	installed := func(string) (string, error) { return "/usr/local/bin/docker", nil }
+	missing := func(string) (string, error) { return "", errors.New("not found") }
+	daemonDown := func() error { return errors.New("Cannot connect to the Docker daemon") }
+
+	tests := []struct {
+		name      string
+		lookPath  func(string) (string, error)
+		info      func() error
+		want      DockerStatus
+		wantNotes []string
+	}{
+		{"not installed", missing, daemonDown, DockerNotInstalled, nil},
+		{"daemon stopped", installed, daemonDown, DockerStopped, []string{DockerStoppedNote}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir()) // No Docker Desktop disk image
+			lookPath, dockerInfo = tt.lookPath, tt.info
+			if got := getDockerStatus(); got != tt.want {
+				t.Errorf("getDockerStatus() = %v, want %v", got, tt.want)
+			}
+
+			s, err := New()
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			report, err := s.ScanAllReport(types.ScanOptions{IncludeDocker: true})
+			if err != nil {
+				t.Fatalf("ScanAllReport() error = %v", err)
+			}
+			if len(report.Results) != 0 || !slices.Equal(report.Notes, tt.wantNotes) {
+				t.Errorf("ScanAllReport() = %d results, notes %q; want none, %q", len(report.Results), report.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestScanDockerDiskImage(t *testing.T) {
+	origLookPath, origDockerInfo := lookPath, dockerInfo
+	defer func() { lookPath, dockerInfo = origLookPath, origDockerInfo }()
+	lookPath = func(string) (string, error) { return "/usr/local/bin/docker", nil }
+	dockerInfo = func() error { return errors.New("Cannot connect to the Docker daemon") }
+
+	s, _ := newFixtureScanner(t)
+	image := s.ExpandPath(dockerDiskImages[0])
+	writeTestFile(t, image)
+
+	// A stopped daemon lists the disk image instead
+	report, err := s.ScanAllReport(types.ScanOptions{IncludeDocker: true})
+	if err != nil {
+		t.Fatalf("ScanAllReport() error = %v", err)
+	}
+	if len(report.Results) != 1 || report.Results[0].Path != image || report.Results[0].Size == 0 {
+		t.Fatalf("ScanAllReport() = %+v, want the disk image", report.Results)
+	}
+	if !slices.Equal(report.Notes, []string{DockerDiskImageNote}) {
+		t.Errorf("notes = %q, want the disk image note", report.Notes)
+	}
+
+	// --assume-docker-stopped never asks the daemon
+	dockerInfo = func() error {
+		t.Error("docker info ran with AssumeDockerStopped")
+		return nil
+	}
+	report, err = s.ScanAllReport(types.ScanOptions{IncludeDocker: true, AssumeDockerStopped: true})
+	if err != nil {
+		t.Fatalf("ScanAllReport() error = %v", err)
+	}
+	if len(report.Results) != 1 || report.Results[0].Path != image {
+		t.Errorf("ScanAllReport() = %+v, want the disk image", report.Results)
+	}
+}
+
+func TestParseDockerPercent(t *testing.T) {
+	tests := []struct {
+		reclaimable string
+		want        int
+	}{
+		{"4.2GB (80%)", 80},
+		{"1.5GB (33.4%)", 33},
+		{"0B (0%)", 0},
+		{"512MB", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseDockerPercent(tt.reclaimable); got != tt.want {
+			t.Errorf("parseDockerPercent(%q) = %d, want %d", tt.reclaimable, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// DotNetGlobalPaths contains global .NET caches besides the NuGet package
+// folder (which is resolved by getNuGetPackages)
+var DotNetGlobalPaths = []struct {
+	Path string
+	Name string
+}{
+	{"~/.local/share/NuGet/http-cache", "NuGet HTTP Cache"},
+}
+
+// DotNetMarkerExts identify .NET projects and solutions
+var DotNetMarkerExts = []string{".csproj", ".fsproj", ".vbproj", ".sln"}
+
+// DotNetBuildDirs are per-project build output directories
+var DotNetBuildDirs = []string{"bin", "obj"}
+
+// getNuGetPackages returns NUGET_PACKAGES or default ~/.nuget/packages
+func getNuGetPackages() string {
+	if packages := os.Getenv("NUGET_PACKAGES"); packages != "" {
+		return packages
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".nuget", "packages")
+}
+
+// ScanDotNet scans for .NET/NuGet and Unity development artifacts
+func (s *Scanner) ScanDotNet(ctx context.Context, maxDepth int) []types.ScanResult {
+	var results []types.ScanResult
+
+	// Global NuGet package folder (using NUGET_PACKAGES)
+	packages := getNuGetPackages()
+	if s.PathExists(packages) {
+		results = append(results, s.scanCacheRoot(packages, "NuGet Packages", types.TypeDotNet)...)
+	}
+
+	for _, target := range DotNetGlobalPaths {
+		path := s.ExpandPath(target.Path)
+		if !s.PathExists(path) {
+			continue
+		}
+
+		results = append(results, s.scanCacheRoot(path, target.Name, types.TypeDotNet)...)
+	}
+
+	// Scan for .NET and Unity projects in common development directories
+	ctx = s.withSymlinkVisits(ctx)
+	for _, dir := range s.projectRoots() {
+		expandedDir := s.ExpandPath(dir)
+		if !s.PathExists(expandedDir) {
+			continue
+		}
+
+		dotnetArtifacts := s.findDotNetArtifacts(ctx, expandedDir, maxDepth)
+		results = append(results, dotnetArtifacts...)
+	}
+
+	return results
+}
+
+// findDotNetArtifacts recursively finds .NET bin/obj folders and Unity
+// Library folders
+func (s *Scanner) findDotNetArtifacts(ctx context.Context, root string, maxDepth int) []types.ScanResult {
+	var results []types.ScanResult
+
+	if maxDepth <= 0 || ctx.Err() != nil {
+		return results
+	}
+
+	entries, err := s.readDir(root)
+	if err != nil {
+		return results
+	}
+
+	hasProject := false
+	hasSolution := false
+	dirs := make(map[string]bool)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			dirs[name] = true
+			continue
+		}
+		if isDotNetMarker(name) {
+			if strings.HasSuffix(name, ".sln") {
+				hasSolution = true
+			} else {
+				hasProject = true
+			}
+		}
+	}
+
+	projectName := filepath.Base(root)
+
+	// Unity project: Library is regenerated from Assets on next open
+	isUnity := dirs["Assets"] && dirs["ProjectSettings"]
+	if isUnity && dirs["Library"] {
+		libraryPath := filepath.Join(root, "Library")
+		size, count, _ := s.calculateSize(libraryPath)
+		if size > 0 {
+			results = append(results, types.ScanResult{
+				Path:       libraryPath,
+				Type:       types.TypeUnity,
+				Size:       size,
+				FileCount:  count,
+				Name:       projectName + "/Library (Unity)",
+				SafetyTier: types.TierInactive,
+			})
+		}
+	}
+
+	// .NET project or solution: bin/obj build output
+	if hasProject || hasSolution {
+		for _, buildDir := range DotNetBuildDirs {
+			if !dirs[buildDir] {
+				continue
+			}
+			buildPath := filepath.Join(root, buildDir)
+			size, count, _ := s.calculateSize(buildPath)
+			if size > 0 {
+				results = append(results, types.ScanResult{
+					Path:       buildPath,
+					Type:       types.TypeDotNet,
+					Size:       size,
+					FileCount:  count,
+					Name:       projectName + "/" + buildDir,
+					SafetyTier: types.TierInactive,
+				})
+			}
+		}
+	}
+
+	// Don't recurse into Unity or single .NET projects; solutions keep
+	// their projects in subdirectories
+	if isUnity || hasProject {
+		return results
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			// Symlinked directories only with ScanOptions.FollowSymlinks
+			if linked := filepath.Join(root, entry.Name()); s.followSymlink(ctx, linked, entry) {
+				results = append(results, s.findDotNetArtifacts(ctx, linked, maxDepth-1)...)
+			}
+			continue
+		}
+
+		name := entry.Name()
+
+		// Skip hidden and known non-project directories
+		if s.skipDir(root, name) {
+			continue
+		}
+
+		// Already reported above for solutions
+		if hasSolution && (name == "bin" || name == "obj") {
+			continue
+		}
+
+		fullPath := filepath.Join(root, name)
+		subResults := s.findDotNetArtifacts(ctx, fullPath, maxDepth-1)
+		results = append(results, subResults...)
+	}
+
+	return results
+}
+
+// isDotNetMarker checks if a file name is a .NET project or solution file
+func isDotNetMarker(name string) bool {
+	for _, ext := range DotNetMarkerExts {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func writeTestFile(t testing.TB, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetNuGetPackages(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("NUGET_PACKAGES", dir)
+
+	if got := getNuGetPackages(); got != dir {
+		t.Errorf("getNuGetPackages() = %q, want %q", got, dir)
+	}
+}
+
+func TestFindDotNetArtifacts(t *testing.T) {
+	root := t.TempDir()
+
+	// Solution with one project below it
+	writeTestFile(t, filepath.Join(root, "shop", "Shop.sln"))
+	writeTestFile(t, filepath.Join(root, "shop", "Api", "Api.csproj"))
+	writeTestFile(t, filepath.Join(root, "shop", "Api", "bin", "Debug", "Api.dll"))
+	writeTestFile(t, filepath.Join(root, "shop", "Api", "obj", "project.assets.json"))
+
+	// Unity project
+	writeTestFile(t, filepath.Join(root, "game", "Assets", "Player.cs"))
+	writeTestFile(t, filepath.Join(root, "game", "ProjectSettings", "ProjectVersion.txt"))
+	writeTestFile(t, filepath.Join(root, "game", "Library", "ArtifactDB"))
+
+	// bin without a project marker is left alone
+	writeTestFile(t, filepath.Join(root, "scripts", "bin", "run.sh"))
+
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got := make(map[string]types.CleanTargetType)
+	for _, result := range s.findDotNetArtifacts(context.Background(), root, 4) {
+		rel, _ := filepath.Rel(root, result.Path)
+		got[rel] = result.Type
+	}
+
+	want := map[string]types.CleanTargetType{
+		filepath.Join("shop", "Api", "bin"): types.TypeDotNet,
+		filepath.Join("shop", "Api", "obj"): types.TypeDotNet,
+		filepath.Join("game", "Library"):    types.TypeUnity,
+	}
+	if len(got) != len(want) {
+		t.Errorf("findDotNetArtifacts() = %v, want %v", got, want)
+	}
+	for path, cleanType := range want {
+		if got[path] != cleanType {
+			t.Errorf("findDotNetArtifacts()[%s] = %q, want %q", path, got[path], cleanType)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// duCommand runs du -sk on path (replaced in tests). du still prints the
+// total when some subfolder is unreadable, exiting non-zero, so its output
+// is returned along with any error.
+var duCommand = func(path string) ([]byte, error) {
+	return exec.Command("du", "-sk", path).Output()
+}
+
+// duSize returns the disk usage of path in bytes using du (ScanOptions.UseDu).
+// du counts allocated blocks, so sizes differ slightly from the Go walk's
+// file sizes; it gives no file count.
+func duSize(path string) (int64, error) {
+	out, err := duCommand(path)
+	size, parseErr := parseDuOutput(out)
+	if parseErr != nil {
+		if err != nil {
+			return 0, err
+		}
+		return 0, parseErr
+	}
+	return size, nil
+}
+
+// parseDuOutput parses du -sk output, "<kilobytes>\t<path>", into bytes
+func parseDuOutput(out []byte) (int64, error) {
+	fields := strings.Fields(string(out))
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("unexpected du output %q", out)
+	}
+	kb, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected du output %q", out)
+	}
+	return kb * 1024, nil
+}
+
+// measureSize sizes path with du when ScanOptions.UseDu is set, falling
+// back to the Go walk when du is missing or fails, or estimates it with
+// ScanOptions.EstimateOnly
+func (s *Scanner) measureSize(path string) (int64, int, error) {
+	if s.estimateOnly {
+		return s.estimateSize(path)
+	}
+	if s.useDu {
+		if size, err := duSize(path); err == nil {
+			return size, 0, nil
+		}
+	}
+	return s.calculateSizeProgress(path, s.sizeProgress)
+}
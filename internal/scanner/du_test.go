@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDuOutput(t *testing.T) {
+	size, err := parseDuOutput([]byte("2048\t/Users/me/Library/Caches\n"))
+	if err != nil || size != 2048*1024 {
+		t.Errorf("parseDuOutput() = %d, %v; want %d", size, err, 2048*1024)
+	}
+	for _, out := range []string{"", "du: /x: No such file or directory\n", "12K\t/x\n"} {
+		if _, err := parseDuOutput([]byte(out)); err == nil {
+			t.Errorf("parseDuOutput(%q) accepted", out)
+		}
+	}
+}
+
+func TestMeasureSizeDu(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "a", "f"))
+	writeTestFile(t, filepath.Join(dir, "b", "f"))
+
+	origDu := duCommand
+	defer func() { duCommand = origDu }()
+	s := &Scanner{useDu: true}
+
+	// du's total, no file count; a non-zero exit with a total still counts
+	duCommand = func(path string) ([]byte, error) {
+		return []byte("8\t" + path + "\n"), errors.New("exit status 1")
+	}
+	if size, count, _ := s.measureSize(dir); size != 8*1024 || count != 0 {
+		t.Errorf("with du: %d bytes, %d files; want %d, 0", size, count, 8*1024)
+	}
+
+	// du missing: the Go walk
+	duCommand = func(string) ([]byte, error) { return nil, exec.ErrNotFound }
+	if size, count, _ := s.measureSize(dir); size != 2 || count != 2 {
+		t.Errorf("without du: %d bytes, %d files; want 2, 2", size, count)
+	}
+}
+
+// BenchmarkCalculateSize compares the Go walk with du -sk on the same tree
+// (go test -bench CalculateSize ./internal/scanner)
+func BenchmarkCalculateSize(b *testing.B) {
+	dir := b.TempDir()
+	for i := 0; i < 5000; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("d%d", i%50), fmt.Sprintf("s%d", i%7), fmt.Sprintf("f%d", i))
+		writeTestFile(b, path)
+	}
+
+	b.Run("walk", func(b *testing.B) {
+		s := &Scanner{}
+		for i := 0; i < b.N; i++ {
+			s.measureSize(dir)
+		}
+	})
+	b.Run("du", func(b *testing.B) {
+		if _, err := exec.LookPath("du"); err != nil {
+			b.Skip("du not installed")
+		}
+		s := &Scanner{useDu: true}
+		for i := 0; i < b.N; i++ {
+			s.measureSize(dir)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// packageJSON is the part of a project's package.json the scanner reads
+type packageJSON struct {
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+}
+
+// readPackageJSON reads the package.json in dir
+func readPackageJSON(dir string) (packageJSON, error) {
+	var pkg packageJSON
+
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return pkg, err
+	}
+	err = json.Unmarshal(data, &pkg)
+	return pkg, err
+}
+
+// fingerprint hashes the sorted name@version list of the direct
+// dependencies (dev ones included, they are installed too) and returns it
+// with the number of dependencies
+func (p packageJSON) fingerprint() (string, int) {
+	var deps []string
+	for name, version := range p.Dependencies {
+		deps = append(deps, name+"@"+version)
+	}
+	for name, version := range p.DevDependencies {
+		deps = append(deps, "dev:"+name+"@"+version)
+	}
+	sort.Strings(deps)
+
+	sum := sha256.Sum256([]byte(strings.Join(deps, "\n")))
+	return hex.EncodeToString(sum[:6]), len(deps)
+}
+
+// FindDuplicateNodeModules groups the node_modules among results by the
+// direct dependencies their project's package.json declares, and returns
+// the sets shared by more than one project, largest first. Projects
+// without a readable package.json or without dependencies are left out.
+// Only package.json is read; node_modules contents are not compared.
+func FindDuplicateNodeModules(results []types.ScanResult) []types.DuplicateDeps {
+	sets := make(map[string]*types.DuplicateDeps)
+	for _, result := range results {
+		if filepath.Base(result.Path) != "node_modules" {
+			continue
+		}
+		pkg, err := readPackageJSON(filepath.Dir(result.Path))
+		if err != nil {
+			continue
+		}
+		fingerprint, count := pkg.fingerprint()
+		if count == 0 {
+			continue
+		}
+
+		set := sets[fingerprint]
+		if set == nil {
+			set = &types.DuplicateDeps{Fingerprint: fingerprint, Dependencies: count}
+			sets[fingerprint] = set
+		}
+		set.Results = append(set.Results, result)
+		set.Size += result.Size
+	}
+
+	var dupes []types.DuplicateDeps
+	for _, set := range sets {
+		if len(set.Results) < 2 {
+			continue
+		}
+		sort.SliceStable(set.Results, func(i, j int) bool {
+			return set.Results[i].Size > set.Results[j].Size
+		})
+		dupes = append(dupes, *set)
+	}
+	sort.Slice(dupes, func(i, j int) bool {
+		if dupes[i].Size != dupes[j].Size {
+			return dupes[i].Size > dupes[j].Size
+		}
+		return dupes[i].Fingerprint < dupes[j].Fingerprint
+	})
+	return dupes
+}
@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestFindDuplicateNodeModules(t *testing.T) {
+	root := t.TempDir()
+	packages := map[string]string{
+		"app-a":  `{"dependencies": {"react": "^18.2.0", "lodash": "4.17.21"}}`,
+		"app-b":  `{"dependencies": {"lodash": "4.17.21", "react": "^18.2.0"}}`,
+		"app-c":  `{"dependencies": {"react": "^18.2.0"}, "devDependencies": {"lodash": "4.17.21"}}`,
+		"empty":  `{}`,
+		"broken": `{"dependencies":`,
+	}
+	var results []types.ScanResult
+	for name, pkg := range packages {
+		project := filepath.Join(root, name)
+		if err := os.MkdirAll(project, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(project, "package.json"), []byte(pkg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, types.ScanResult{Path: filepath.Join(project, "node_modules"), Size: int64(len(name))})
+	}
+	results = append(results, types.ScanResult{Path: filepath.Join(root, "app-a", "target"), Size: 1})
+
+	dupes := FindDuplicateNodeModules(results)
+	if len(dupes) != 1 {
+		t.Fatalf("got %d duplicate sets, want 1: %+v", len(dupes), dupes)
+	}
+	set := dupes[0]
+	if set.Dependencies != 2 || set.Size != 10 || len(set.Results) != 2 {
+		t.Errorf("got %d deps, size %d, %d results; want 2, 10, 2", set.Dependencies, set.Size, len(set.Results))
+	}
+	for _, result := range set.Results {
+		if project := filepath.Base(filepath.Dir(result.Path)); project != "app-a" && project != "app-b" {
+			t.Errorf("unexpected project %s in the set", project)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// getHexHome returns HEX_HOME or default ~/.hex
+func getHexHome() string {
+	if hexHome := os.Getenv("HEX_HOME"); hexHome != "" {
+		return hexHome
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".hex")
+}
+
+// getMixHome returns MIX_HOME or default ~/.mix
+func getMixHome() string {
+	if mixHome := os.Getenv("MIX_HOME"); mixHome != "" {
+		return mixHome
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".mix")
+}
+
+// elixirProjectMarkers identify a Mix or rebar3 project root
+var elixirProjectMarkers = []string{"mix.exs", "rebar.config"}
+
+// elixirBuildDirs are the per-project directories Mix and rebar3 recreate
+// (mix compile, mix deps.get)
+var elixirBuildDirs = []string{"_build", "deps"}
+
+// ScanElixir scans for Elixir/Mix and Erlang/rebar3 development artifacts
+func (s *Scanner) ScanElixir(ctx context.Context, maxDepth int) []types.ScanResult {
+	var results []types.ScanResult
+
+	// Scan global package caches (using HEX_HOME)
+	globalPaths := []struct {
+		Path string
+		Name string
+	}{
+		{filepath.Join(getHexHome(), "packages"), "Hex Package Cache"},
+		{s.ExpandPath("~/Library/Caches/mix"), "Mix Cache"},
+		{s.ExpandPath("~/.cache/mix"), "Mix Cache"},
+		{s.ExpandPath("~/.cache/rebar3"), "rebar3 Cache"},
+	}
+
+	for _, target := range globalPaths {
+		if !s.PathExists(target.Path) {
+			continue
+		}
+
+		results = append(results, s.scanCacheRoot(target.Path, target.Name, types.TypeElixir)...)
+	}
+
+	// MIX_HOME holds installed archives (Hex itself, phx_new), which are
+	// reinstalled with mix local.hex rather than fetched on demand
+	if mixHome := getMixHome(); s.PathExists(mixHome) {
+		size, count, _ := s.calculateSize(mixHome)
+		if size > 0 {
+			results = append(results, types.ScanResult{
+				Path:       mixHome,
+				Type:       types.TypeElixir,
+				Size:       size,
+				FileCount:  count,
+				Name:       "Mix Home (reinstall with mix local.hex)",
+				SafetyTier: types.TierReview,
+			})
+		}
+	}
+
+	// Scan for Elixir projects' _build and deps directories
+	ctx = s.withSymlinkVisits(ctx)
+	for _, dir := range s.projectRoots() {
+		expandedDir := s.ExpandPath(dir)
+		if !s.PathExists(expandedDir) {
+			continue
+		}
+
+		elixirTargets := s.findElixirTargets(ctx, expandedDir, maxDepth)
+		results = append(results, elixirTargets...)
+	}
+
+	return results
+}
+
+// findElixirTargets recursively finds _build and deps directories of
+// Mix/rebar3 projects
+func (s *Scanner) findElixirTargets(ctx context.Context, root string, maxDepth int) []types.ScanResult {
+	var results []types.ScanResult
+
+	if maxDepth <= 0 || ctx.Err() != nil {
+		return results
+	}
+
+	entries, err := s.readDir(root)
+	if err != nil {
+		return results
+	}
+
+	// Check if this directory contains mix.exs or rebar.config (is an
+	// Elixir/Erlang project)
+	isElixirProject := false
+	for _, entry := range entries {
+		if !entry.IsDir() && slices.Contains(elixirProjectMarkers, entry.Name()) {
+			isElixirProject = true
+			break
+		}
+	}
+
+	// If Elixir project, add its build directories
+	if isElixirProject {
+		projectName := filepath.Base(root)
+		for _, dirName := range elixirBuildDirs {
+			targetPath := filepath.Join(root, dirName)
+			if !s.PathExists(targetPath) {
+				continue
+			}
+			size, count, _ := s.calculateSize(targetPath)
+			if size > 0 {
+				results = append(results, types.ScanResult{
+					Path:       targetPath,
+					Type:       types.TypeElixir,
+					Size:       size,
+					FileCount:  count,
+					Name:       projectName + "/" + dirName,
+					SafetyTier: types.TierInactive,
+				})
+			}
+		}
+		// Don't recurse into Elixir projects (umbrella apps share the
+		// root's _build and deps)
+		return results
+	}
+
+	// Recurse into subdirectories
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			// Symlinked directories only with ScanOptions.FollowSymlinks
+			if linked := filepath.Join(root, entry.Name()); s.followSymlink(ctx, linked, entry) {
+				results = append(results, s.findElixirTargets(ctx, linked, maxDepth-1)...)
+			}
+			continue
+		}
+
+		name := entry.Name()
+
+		// Skip hidden and common non-project dirs
+		if s.skipDir(root, name) {
+			continue
+		}
+
+		fullPath := filepath.Join(root, name)
+		subResults := s.findElixirTargets(ctx, fullPath, maxDepth-1)
+		results = append(results, subResults...)
+	}
+
+	return results
+}
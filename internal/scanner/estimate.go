@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// estimateDepth is how many levels below a folder ScanOptions.EstimateOnly
+// reads before extrapolating
+const estimateDepth = 3
+
+// estimateSize approximates the size of root for ScanOptions.EstimateOnly.
+// It reads estimateDepth levels of folders and counts each folder below
+// them as holding as much as an average folder it did read. The file
+// count is only the files it saw.
+func (s *Scanner) estimateSize(root string) (int64, int, error) {
+	var size int64
+	var count int
+	var listed, pruned int64
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip errors, continue
+		}
+		if !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+				count++
+			}
+			return nil
+		}
+		if path != root && walkDepth(root, path) >= estimateDepth {
+			pruned++
+			return filepath.SkipDir
+		}
+		listed++
+		return nil
+	})
+	s.dirsWalked.Add(listed)
+
+	if listed > 0 {
+		size += size / listed * pruned
+	}
+	return size, count, err
+}
+
+// walkDepth returns how many levels path is below root
+func walkDepth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
+
+// markEstimated flags the sizes the scanner extrapolated; tool-managed
+// results (Docker, simctl) are reported by their tools and stay exact
+func markEstimated(results []types.ScanResult) {
+	for i := range results {
+		if !types.IsPseudoPath(results[i].Path) {
+			results[i].Estimated = true
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestEstimateSize(t *testing.T) {
+	s, root := newFixtureScanner(t)
+
+	// Shallow trees are read completely, so the estimate is exact
+	shallow := filepath.Join(root, "shallow")
+	writeTestFile(t, filepath.Join(shallow, "a"))
+	writeTestFile(t, filepath.Join(shallow, "pkg", "b"))
+	size, count, err := s.estimateSize(shallow)
+	if err != nil || size != 2 || count != 2 {
+		t.Errorf("estimateSize(shallow) = %d, %d, %v; want 2, 2, nil", size, count, err)
+	}
+
+	// deep/x/y is read (3 folders, 3 bytes), deep/x/y/z and deep/x/y/w are
+	// not and count as an average folder (1 byte) each
+	deep := filepath.Join(root, "deep")
+	writeTestFile(t, filepath.Join(deep, "a"))
+	writeTestFile(t, filepath.Join(deep, "x", "b"))
+	writeTestFile(t, filepath.Join(deep, "x", "y", "c"))
+	writeTestFile(t, filepath.Join(deep, "x", "y", "z", "d"))
+	writeTestFile(t, filepath.Join(deep, "x", "y", "z", "e", "f"))
+	writeTestFile(t, filepath.Join(deep, "x", "y", "w", "g"))
+	size, count, err = s.estimateSize(deep)
+	if err != nil || size != 5 || count != 3 {
+		t.Errorf("estimateSize(deep) = %d, %d, %v; want 5, 3, nil", size, count, err)
+	}
+}
+
+func TestMarkEstimated(t *testing.T) {
+	results := []types.ScanResult{
+		{Path: "/work/app/node_modules"},
+		{Path: types.DockerPathPrefix + "images"},
+	}
+	markEstimated(results)
+	if !results[0].Estimated || results[1].Estimated {
+		t.Errorf("markEstimated() = %+v, want only the folder estimated", results)
+	}
+}
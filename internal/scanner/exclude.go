@@ -0,0 +1,117 @@
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// ValidateGlobs checks --exclude-glob patterns, so a typo is reported
+// before scanning instead of silently matching nothing
+func ValidateGlobs(patterns []string) error {
+	for _, pattern := range patterns {
+		for _, segment := range strings.Split(filepath.ToSlash(pattern), "/") {
+			if _, err := filepath.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// MatchGlob reports whether path matches pattern. Each path segment is
+// matched with filepath.Match, except "**", which matches any number of
+// segments (including none). Patterns are anchored at both ends.
+func MatchGlob(pattern, path string) bool {
+	return matchSegments(splitPath(pattern), splitPath(path))
+}
+
+// splitPath splits a slash-separated path into its non-empty segments
+func splitPath(path string) []string {
+	return strings.FieldsFunc(filepath.ToSlash(path), func(r rune) bool { return r == '/' })
+}
+
+// matchSegments matches path segments against pattern segments
+func matchSegments(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Try every split of the remaining path
+			for i := 0; i <= len(path); i++ {
+				if matchSegments(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(path) == 0 {
+			return false
+		}
+		if ok, _ := filepath.Match(pattern[0], path[0]); !ok {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
+
+// LiteralGlob escapes glob metacharacters in path, so the pattern only
+// matches path itself (settings ExcludePaths are plain paths)
+func LiteralGlob(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`*?[\\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// globPatterns expands ~ in patterns and lets relative ones, e.g.
+// "legacy/target", match below any directory
+func (s *Scanner) globPatterns(patterns []string) []string {
+	expanded := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = s.ExpandPath(pattern)
+		if !filepath.IsAbs(pattern) && !strings.HasPrefix(pattern, "**") {
+			pattern = "**/" + pattern
+		}
+		expanded = append(expanded, pattern)
+	}
+	return expanded
+}
+
+// excludeGlobs drops results whose path, or a parent of it, matches one of
+// patterns (ScanOptions.ExcludeGlobs), so "~/work/important-*" protects
+// everything below those folders
+func (s *Scanner) excludeGlobs(results []types.ScanResult, patterns []string) []types.ScanResult {
+	if len(patterns) == 0 {
+		return results
+	}
+	patterns = s.globPatterns(patterns)
+
+	kept := results[:0]
+	for _, result := range results {
+		if !matchesAnyParent(patterns, result.Path) {
+			kept = append(kept, result)
+		}
+	}
+	return kept
+}
+
+// matchesAnyParent reports whether path or one of its parents matches one
+// of patterns
+func matchesAnyParent(patterns []string, path string) bool {
+	segments := splitPath(path)
+	for _, pattern := range patterns {
+		patternSegments := splitPath(pattern)
+		for i := len(segments); i > 0; i-- {
+			if matchSegments(patternSegments, segments[:i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/home/u/work/*/target", "/home/u/work/app/target", true},
+		{"/home/u/work/*/target", "/home/u/work/a/b/target", false},
+		{"/home/u/work/important-*", "/home/u/work/important-api", true},
+		{"**/node_modules/.cache", "/home/u/src/app/node_modules/.cache", true},
+		{"**/node_modules/.cache", "/home/u/src/app/node_modules", false},
+		{"/home/**/target", "/home/target", true},
+		{"/home/**/target", "/home/u/a/b/target", true},
+		{"/home/**/target", "/home/u/a/b/target/debug", false},
+	}
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestExcludeGlobs(t *testing.T) {
+	s := &Scanner{homeDir: "/home/u"}
+	results := []types.ScanResult{
+		{Path: "/home/u/work/important-api/target"},
+		{Path: "/home/u/work/scratch/target"},
+		{Path: "/home/u/src/app/node_modules/.cache"},
+		{Path: "/home/u/.npm"},
+	}
+
+	kept := s.excludeGlobs(results, []string{"~/work/important-*", "node_modules/.cache"})
+	var paths []string
+	for _, result := range kept {
+		paths = append(paths, result.Path)
+	}
+	if len(paths) != 2 || paths[0] != "/home/u/work/scratch/target" || paths[1] != "/home/u/.npm" {
+		t.Errorf("kept %v, want the scratch target and ~/.npm", paths)
+	}
+}
+
+func TestValidateGlobs(t *testing.T) {
+	if err := ValidateGlobs([]string{"~/work/*/target", "**/.cache"}); err != nil {
+		t.Errorf("valid patterns: %v", err)
+	}
+	if err := ValidateGlobs([]string{"~/work/[a-/target"}); err == nil {
+		t.Error("unterminated class was accepted")
+	}
+}
+
+func TestLiteralGlob(t *testing.T) {
+	path := "/home/u/work/[old] app*/target"
+	if !MatchGlob(LiteralGlob(path), path) {
+		t.Errorf("LiteralGlob(%q) does not match the path itself", path)
+	}
+	if MatchGlob(LiteralGlob(path), "/home/u/work/o app1/target") {
+		t.Error("LiteralGlob pattern matched a different path")
+	}
+}
@@ -0,0 +1,218 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// newFixtureScanner returns a scanner whose home directory and project
+// roots are empty temp dirs, so tests only see the trees they create. It
+// returns the scanner and the project root.
+func newFixtureScanner(t *testing.T) (*Scanner, string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	root := filepath.Join(home, "src")
+	s.SetProjectRoots([]string{root})
+	return s, root
+}
+
+// resultPaths maps results to their path relative to root
+func resultPaths(t *testing.T, root string, results []types.ScanResult) map[string]bool {
+	t.Helper()
+	paths := make(map[string]bool)
+	for _, result := range results {
+		rel, err := filepath.Rel(root, result.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths[rel] = true
+	}
+	return paths
+}
+
+// assertPaths checks that got holds exactly the want paths
+func assertPaths(t *testing.T, got map[string]bool, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for _, path := range want {
+		if !got[filepath.FromSlash(path)] {
+			t.Errorf("missing %s in %v", path, got)
+		}
+	}
+}
+
+func TestProjectRoots(t *testing.T) {
+	s := &Scanner{}
+	if got := s.projectRoots("~/IdeaProjects"); len(got) != len(ProjectRoots)+1 || got[len(got)-1] != "~/IdeaProjects" {
+		t.Errorf("projectRoots() = %v, want ProjectRoots plus ~/IdeaProjects", got)
+	}
+
+	s.SetProjectRoots([]string{"/fixture"})
+	s.SetExtraRoots([]string{"/Volumes/Work"})
+	got := s.projectRoots("~/IdeaProjects")
+	if len(got) != 2 || got[0] != "/fixture" || got[1] != "/Volumes/Work" {
+		t.Errorf("projectRoots() = %v, want [/fixture /Volumes/Work]", got)
+	}
+}
+
+func TestScanNodeHiddenRoots(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, ".archive", "old", "node_modules", "react", "index.js"))
+	writeTestFile(t, filepath.Join(root, "web", ".cache", "app", "node_modules", "react", "index.js"))
+	writeTestFile(t, filepath.Join(root, ".git", "hooks", "node_modules", "husky", "index.js"))
+
+	// Hidden directories are skipped by default
+	assertPaths(t, resultPaths(t, root, s.ScanNode(context.Background(), 4)))
+
+	// Only hidden directories directly below the root are searched, and
+	// .git stays a boundary
+	s.SetIncludeHiddenRoots(true)
+	got := resultPaths(t, root, s.ScanNode(context.Background(), 4))
+	assertPaths(t, got, ".archive/old/node_modules")
+}
+
+func TestScanNodeFixture(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "web", "package.json"))
+	writeTestFile(t, filepath.Join(root, "web", "node_modules", "react", "index.js"))
+	writeTestFile(t, filepath.Join(root, "team", "api", "node_modules", "express", "index.js"))
+	// Nested node_modules are part of their parent, not separate results
+	writeTestFile(t, filepath.Join(root, "web", "node_modules", "a", "node_modules", "b", "index.js"))
+
+	got := resultPaths(t, root, s.ScanNode(context.Background(), 3))
+	assertPaths(t, got, "web/node_modules", "team/api/node_modules")
+}
+
+func TestScanNodeFixtureMaxDepth(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	// Monorepo package five levels below the project root
+	writeTestFile(t, filepath.Join(root, "corp", "apps", "web", "packages", "ui", "node_modules", "react", "index.js"))
+
+	if got := resultPaths(t, root, s.ScanNode(context.Background(), types.DefaultProjectSearchDepth)); len(got) != 0 {
+		t.Errorf("default depth found %v, want nothing", got)
+	}
+	got := resultPaths(t, root, s.ScanNode(context.Background(), 6))
+	assertPaths(t, got, "corp/apps/web/packages/ui/node_modules")
+}
+
+func TestScanRustFixture(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "cli", "Cargo.toml"))
+	writeTestFile(t, filepath.Join(root, "cli", "target", "debug", "cli"))
+	// target without Cargo.toml is not a Rust build dir
+	writeTestFile(t, filepath.Join(root, "site", "target", "index.html"))
+
+	got := resultPaths(t, root, s.ScanRust(context.Background(), 3))
+	assertPaths(t, got, "cli/target")
+}
+
+func TestScanRustHiddenRoots(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, ".archive", "cli", "Cargo.toml"))
+	writeTestFile(t, filepath.Join(root, ".archive", "cli", "target", "debug", "cli"))
+	writeTestFile(t, filepath.Join(root, "tools", ".old", "cli", "Cargo.toml"))
+	writeTestFile(t, filepath.Join(root, "tools", ".old", "cli", "target", "debug", "cli"))
+
+	assertPaths(t, resultPaths(t, root, s.ScanRust(context.Background(), 4)))
+
+	s.SetIncludeHiddenRoots(true)
+	got := resultPaths(t, root, s.ScanRust(context.Background(), 4))
+	assertPaths(t, got, ".archive/cli/target")
+}
+
+func TestScanJavaHiddenRoots(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, ".archive", "svc", "pom.xml"))
+	writeTestFile(t, filepath.Join(root, ".archive", "svc", "target", "svc.jar"))
+
+	s.SetIncludeHiddenRoots(true)
+	got := resultPaths(t, root, s.ScanJava(context.Background(), 4))
+	assertPaths(t, got, ".archive/svc/target")
+}
+
+func TestScanFlutterFixture(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "app", "pubspec.yaml"))
+	writeTestFile(t, filepath.Join(root, "app", "build", "app.apk"))
+	writeTestFile(t, filepath.Join(root, "app", ".dart_tool", "package_config.json"))
+	writeTestFile(t, filepath.Join(root, "app", "ios", "build", "Runner.app"))
+	// build without pubspec.yaml is left alone
+	writeTestFile(t, filepath.Join(root, "docs", "build", "index.html"))
+
+	got := resultPaths(t, root, s.ScanFlutter(context.Background(), 3))
+	assertPaths(t, got, "app/build", "app/.dart_tool", "app/ios/build")
+}
+
+func TestScanPythonFixture(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "api", "pyproject.toml"))
+	writeTestFile(t, filepath.Join(root, "api", ".venv", "bin", "python"))
+	writeTestFile(t, filepath.Join(root, "api", "app", "__pycache__", "main.cpython-312.pyc"))
+
+	got := resultPaths(t, root, s.ScanPython(context.Background(), 4))
+	assertPaths(t, got, "api/.venv", "api/app/__pycache__")
+}
+
+func TestScanElixirFixture(t *testing.T) {
+	t.Setenv("HEX_HOME", "")
+	t.Setenv("MIX_HOME", "")
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "chat", "mix.exs"))
+	writeTestFile(t, filepath.Join(root, "chat", "_build", "dev", "lib", "chat.beam"))
+	writeTestFile(t, filepath.Join(root, "chat", "deps", "phoenix", "mix.exs"))
+	writeTestFile(t, filepath.Join(root, "relay", "rebar.config"))
+	writeTestFile(t, filepath.Join(root, "relay", "_build", "default", "lib", "relay.beam"))
+	// deps without mix.exs is left alone
+	writeTestFile(t, filepath.Join(root, "infra", "deps", "terraform.lock"))
+
+	got := resultPaths(t, root, s.ScanElixir(context.Background(), 3))
+	assertPaths(t, got, "chat/_build", "chat/deps", "relay/_build")
+}
+
+func TestScanHaskellFixture(t *testing.T) {
+	t.Setenv("STACK_ROOT", "")
+	t.Setenv("CABAL_DIR", "")
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "parser", "stack.yaml"))
+	writeTestFile(t, filepath.Join(root, "parser", ".stack-work", "dist", "Parser.o"))
+	writeTestFile(t, filepath.Join(root, "cli", "cli.cabal"))
+	writeTestFile(t, filepath.Join(root, "cli", "dist-newstyle", "build", "cli"))
+	// dist-newstyle without a Stack or Cabal marker is left alone
+	writeTestFile(t, filepath.Join(root, "notes", "dist-newstyle", "readme.txt"))
+
+	got := resultPaths(t, root, s.ScanHaskell(context.Background(), 3))
+	assertPaths(t, got, "parser/.stack-work", "cli/dist-newstyle")
+}
+
+func TestScanHaskellMultiPackage(t *testing.T) {
+	t.Setenv("STACK_ROOT", "")
+	t.Setenv("CABAL_DIR", "")
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "mono", "stack.yaml"))
+	writeTestFile(t, filepath.Join(root, "mono", ".stack-work", "install", "lib.o"))
+	writeTestFile(t, filepath.Join(root, "mono", "core", "core.cabal"))
+	writeTestFile(t, filepath.Join(root, "mono", "core", ".stack-work", "dist", "Core.o"))
+	writeTestFile(t, filepath.Join(root, "mono", "app", "app.cabal"))
+	writeTestFile(t, filepath.Join(root, "mono", "app", ".stack-work", "dist", "Main.o"))
+	// A hidden folder at the top level only with --include-hidden
+	writeTestFile(t, filepath.Join(root, ".attic", "old", "stack.yaml"))
+	writeTestFile(t, filepath.Join(root, ".attic", "old", ".stack-work", "dist", "Old.o"))
+
+	got := resultPaths(t, root, s.ScanHaskell(context.Background(), 4))
+	assertPaths(t, got, "mono/.stack-work", "mono/core/.stack-work", "mono/app/.stack-work")
+
+	s.SetIncludeHiddenRoots(true)
+	got = resultPaths(t, root, s.ScanHaskell(context.Background(), 4))
+	assertPaths(t, got, "mono/.stack-work", "mono/core/.stack-work", "mono/app/.stack-work", ".attic/old/.stack-work")
+}
@@ -1,7 +1,7 @@
 package scanner
 
 import (
-	"os"
+	"context"
 	"path/filepath"
 
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
@@ -19,7 +19,7 @@ var FlutterGlobalPaths = []struct {
 }
 
 // ScanFlutter scans for Flutter/Dart development artifacts
-func (s *Scanner) ScanFlutter(maxDepth int) []types.ScanResult {
+func (s *Scanner) ScanFlutter(ctx context.Context, maxDepth int) []types.ScanResult {
 	var results []types.ScanResult
 
 	// Scan global caches
@@ -29,38 +29,18 @@ func (s *Scanner) ScanFlutter(maxDepth int) []types.ScanResult {
 			continue
 		}
 
-		size, count, err := s.calculateSize(path)
-		if err != nil || size == 0 {
-			continue
-		}
-
-		results = append(results, types.ScanResult{
-			Path:      path,
-			Type:      types.TypeFlutter,
-			Size:      size,
-			FileCount: count,
-			Name:      target.Name,
-		})
+		results = append(results, s.scanCacheRoot(path, target.Name, types.TypeFlutter)...)
 	}
 
 	// Scan for Flutter projects in common development directories
-	projectDirs := []string{
-		"~/Documents",
-		"~/Projects",
-		"~/Development",
-		"~/Developer",
-		"~/Code",
-		"~/repos",
-		"~/workspace",
-	}
-
-	for _, dir := range projectDirs {
+	ctx = s.withSymlinkVisits(ctx)
+	for _, dir := range s.projectRoots() {
 		expandedDir := s.ExpandPath(dir)
 		if !s.PathExists(expandedDir) {
 			continue
 		}
 
-		flutterProjects := s.findFlutterProjects(expandedDir, maxDepth)
+		flutterProjects := s.findFlutterProjects(ctx, expandedDir, maxDepth)
 		results = append(results, flutterProjects...)
 	}
 
@@ -68,14 +48,14 @@ func (s *Scanner) ScanFlutter(maxDepth int) []types.ScanResult {
 }
 
 // findFlutterProjects recursively finds Flutter projects via pubspec.yaml
-func (s *Scanner) findFlutterProjects(root string, maxDepth int) []types.ScanResult {
+func (s *Scanner) findFlutterProjects(ctx context.Context, root string, maxDepth int) []types.ScanResult {
 	var results []types.ScanResult
 
-	if maxDepth <= 0 {
+	if maxDepth <= 0 || ctx.Err() != nil {
 		return results
 	}
 
-	entries, err := os.ReadDir(root)
+	entries, err := s.readDir(root)
 	if err != nil {
 		return results
 	}
@@ -115,11 +95,12 @@ func (s *Scanner) findFlutterProjects(root string, maxDepth int) []types.ScanRes
 			size, count, _ := s.calculateSize(buildPath)
 			if size > 0 {
 				results = append(results, types.ScanResult{
-					Path:      buildPath,
-					Type:      types.TypeFlutter,
-					Size:      size,
-					FileCount: count,
-					Name:      projectName + "/" + target.name,
+					Path:       buildPath,
+					Type:       types.TypeFlutter,
+					Size:       size,
+					FileCount:  count,
+					Name:       projectName + "/" + target.name,
+					SafetyTier: types.TierInactive,
 				})
 			}
 		}
@@ -131,18 +112,22 @@ func (s *Scanner) findFlutterProjects(root string, maxDepth int) []types.ScanRes
 	// Recurse into subdirectories
 	for _, entry := range entries {
 		if !entry.IsDir() {
+			// Symlinked directories only with ScanOptions.FollowSymlinks
+			if linked := filepath.Join(root, entry.Name()); s.followSymlink(ctx, linked, entry) {
+				results = append(results, s.findFlutterProjects(ctx, linked, maxDepth-1)...)
+			}
 			continue
 		}
 
 		name := entry.Name()
 
 		// Skip hidden and known non-project directories
-		if shouldSkipDir(name) {
+		if s.skipDir(root, name) {
 			continue
 		}
 
 		fullPath := filepath.Join(root, name)
-		subResults := s.findFlutterProjects(fullPath, maxDepth-1)
+		subResults := s.findFlutterProjects(ctx, fullPath, maxDepth-1)
 		results = append(results, subResults...)
 	}
 
@@ -32,7 +32,7 @@ func getGOMODCACHE() string {
 }
 
 // ScanGo scans for Go development artifacts
-func (s *Scanner) ScanGo(maxDepth int) []types.ScanResult {
+func (s *Scanner) ScanGo() []types.ScanResult {
 	var results []types.ScanResult
 
 	// Go build cache
@@ -41,11 +41,12 @@ func (s *Scanner) ScanGo(maxDepth int) []types.ScanResult {
 		size, count, err := s.calculateSize(gocache)
 		if err == nil && size > 0 {
 			results = append(results, types.ScanResult{
-				Path:      gocache,
-				Type:      types.TypeGo,
-				Size:      size,
-				FileCount: count,
-				Name:      "Go Build Cache",
+				Path:       gocache,
+				Type:       types.TypeGo,
+				Size:       size,
+				FileCount:  count,
+				Name:       "Go Build Cache",
+				SafetyTier: types.TierSafe,
 			})
 		}
 	}
@@ -56,11 +57,12 @@ func (s *Scanner) ScanGo(maxDepth int) []types.ScanResult {
 		size, count, err := s.calculateSize(gomodcache)
 		if err == nil && size > 0 {
 			results = append(results, types.ScanResult{
-				Path:      gomodcache,
-				Type:      types.TypeGo,
-				Size:      size,
-				FileCount: count,
-				Name:      "Go Module Cache",
+				Path:       gomodcache,
+				Type:       types.TypeGo,
+				Size:       size,
+				FileCount:  count,
+				Name:       "Go Module Cache",
+				SafetyTier: types.TierSafe,
 			})
 		}
 	}
@@ -71,11 +73,12 @@ func (s *Scanner) ScanGo(maxDepth int) []types.ScanResult {
 		size, count, err := s.calculateSize(gotestcache)
 		if err == nil && size > 0 {
 			results = append(results, types.ScanResult{
-				Path:      gotestcache,
-				Type:      types.TypeGo,
-				Size:      size,
-				FileCount: count,
-				Name:      "Go Test Cache",
+				Path:       gotestcache,
+				Type:       types.TypeGo,
+				Size:       size,
+				FileCount:  count,
+				Name:       "Go Test Cache",
+				SafetyTier: types.TierSafe,
 			})
 		}
 	}
@@ -0,0 +1,119 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// GradleCacheNames maps well-known ~/.gradle/caches subfolders to display names
+var GradleCacheNames = map[string]string{
+	"build-cache-1": "Gradle Build Cache",
+	"modules-2":     "Gradle Dependency Cache (modules-2)",
+	"jars-9":        "Gradle Jars Cache",
+	"journal-1":     "Gradle Journal",
+}
+
+// GradleHomePaths contains Gradle user home subfolders scanned as a whole
+var GradleHomePaths = []struct {
+	Path string
+	Name string
+}{
+	{"daemon", "Gradle Daemon Logs"},
+	{"wrapper/dists", "Gradle Wrapper Distributions"},
+	{"native", "Gradle Native Libraries"},
+}
+
+// getGradleUserHome returns GRADLE_USER_HOME or default ~/.gradle
+func getGradleUserHome() string {
+	if gradleHome := os.Getenv("GRADLE_USER_HOME"); gradleHome != "" {
+		return gradleHome
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".gradle")
+}
+
+// gradleCacheName returns the display name for a ~/.gradle/caches subfolder
+func gradleCacheName(dir string) string {
+	if name, ok := GradleCacheNames[dir]; ok {
+		return name
+	}
+	if strings.HasPrefix(dir, "transforms-") {
+		return "Gradle Transforms Cache (" + dir + ")"
+	}
+	// Version-specific caches, e.g. caches/8.5
+	if len(dir) > 0 && dir[0] >= '0' && dir[0] <= '9' {
+		return "Gradle " + dir + " Caches"
+	}
+	return "Gradle Caches/" + dir
+}
+
+// gradleCacheTier returns TierReview for the dependency caches (modules-2
+// and jars-*): offline builds and private or since-removed artifacts can't
+// be re-downloaded. Build caches, transforms and journals are TierSafe.
+func gradleCacheTier(dir string) types.SafetyTier {
+	if dir == "modules-2" || strings.HasPrefix(dir, "jars-") {
+		return types.TierReview
+	}
+	return types.TierSafe
+}
+
+// ScanGradle scans the Gradle user home, splitting it into one result per
+// meaningful subfolder instead of a single opaque "Gradle Caches" entry.
+// This is the only scanner that reports global ~/.gradle paths; the
+// Android and Java scanners leave them to it to avoid duplicates.
+func (s *Scanner) ScanGradle() []types.ScanResult {
+	var results []types.ScanResult
+
+	gradleHome := getGradleUserHome()
+
+	// Split caches/ by subfolder (build-cache-1, modules-2, transforms-*, ...)
+	cachesPath := filepath.Join(gradleHome, "caches")
+	if s.PathExists(cachesPath) {
+		entries, err := os.ReadDir(cachesPath)
+		if err == nil {
+			for _, entry := range entries {
+				if !entry.IsDir() {
+					continue
+				}
+				subPath := filepath.Join(cachesPath, entry.Name())
+				size, count, _ := s.calculateSize(subPath)
+				if size > 0 {
+					results = append(results, types.ScanResult{
+						Path:       subPath,
+						Type:       types.TypeJava,
+						Size:       size,
+						FileCount:  count,
+						Name:       gradleCacheName(entry.Name()),
+						SafetyTier: gradleCacheTier(entry.Name()),
+					})
+				}
+			}
+		}
+	}
+
+	for _, target := range GradleHomePaths {
+		path := filepath.Join(gradleHome, target.Path)
+		if !s.PathExists(path) {
+			continue
+		}
+
+		size, count, err := s.calculateSize(path)
+		if err != nil || size == 0 {
+			continue
+		}
+
+		results = append(results, types.ScanResult{
+			Path:       path,
+			Type:       types.TypeJava,
+			Size:       size,
+			FileCount:  count,
+			Name:       target.Name,
+			SafetyTier: types.TierSafe,
+		})
+	}
+
+	return results
+}
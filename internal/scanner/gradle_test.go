@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestGradleCacheName(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{"build-cache-1", "Gradle Build Cache"},
+		{"modules-2", "Gradle Dependency Cache (modules-2)"},
+		{"transforms-3", "Gradle Transforms Cache (transforms-3)"},
+		{"8.5", "Gradle 8.5 Caches"},
+		{"kotlin-dsl", "Gradle Caches/kotlin-dsl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			if got := gradleCacheName(tt.dir); got != tt.want {
+				t.Errorf("gradleCacheName(%s) = %s, want %s", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanGradle(t *testing.T) {
+	gradleHome := t.TempDir()
+	t.Setenv("GRADLE_USER_HOME", gradleHome)
+
+	for _, dir := range []string{"caches/build-cache-1", "caches/modules-2", "caches/jars-9", "daemon/8.5", "wrapper/dists/gradle-8.5-bin"} {
+		path := filepath.Join(gradleHome, dir)
+		os.MkdirAll(path, 0755)
+		os.WriteFile(filepath.Join(path, "data"), make([]byte, 100), 0644)
+	}
+
+	s, _ := New()
+	results := s.ScanGradle()
+
+	names := make(map[string]bool)
+	for _, r := range results {
+		if r.Type != types.TypeJava {
+			t.Errorf("expected type %s, got %s", types.TypeJava, r.Type)
+		}
+		names[r.Name] = true
+
+		wantTier := types.TierSafe
+		if base := filepath.Base(r.Path); base == "modules-2" || base == "jars-9" {
+			wantTier = types.TierReview
+		}
+		if r.SafetyTier != wantTier {
+			t.Errorf("%s tier = %s, want %s", r.Name, r.SafetyTier, wantTier)
+		}
+	}
+
+	for _, want := range []string{
+		"Gradle Build Cache",
+		"Gradle Dependency Cache (modules-2)",
+		"Gradle Daemon Logs",
+		"Gradle Wrapper Distributions",
+	} {
+		if !names[want] {
+			t.Errorf("expected result %q, got %v", want, names)
+		}
+	}
+}
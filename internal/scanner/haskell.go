@@ -0,0 +1,181 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// getStackRoot returns STACK_ROOT or default ~/.stack
+func getStackRoot() string {
+	if stackRoot := os.Getenv("STACK_ROOT"); stackRoot != "" {
+		return stackRoot
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".stack")
+}
+
+// getCabalDir returns CABAL_DIR or default ~/.cabal
+func getCabalDir() string {
+	if cabalDir := os.Getenv("CABAL_DIR"); cabalDir != "" {
+		return cabalDir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".cabal")
+}
+
+// haskellProjectMarkers identify a Stack or Cabal project root; any
+// *.cabal package description counts too
+var haskellProjectMarkers = []string{"stack.yaml", "cabal.project", "package.yaml"}
+
+// haskellBuildDirs are the per-project directories Stack and Cabal
+// recreate (stack build, cabal build)
+var haskellBuildDirs = []string{".stack-work", "dist-newstyle"}
+
+// ScanHaskell scans for Haskell/Stack and Cabal development artifacts
+func (s *Scanner) ScanHaskell(ctx context.Context, maxDepth int) []types.ScanResult {
+	var results []types.ScanResult
+
+	stackRoot := getStackRoot()
+	cabalDir := getCabalDir()
+
+	// Scan global package caches and built dependencies (using STACK_ROOT
+	// and CABAL_DIR; newer Cabal versions use the XDG directories)
+	globalPaths := []struct {
+		Path string
+		Name string
+	}{
+		{filepath.Join(stackRoot, "pantry"), "Stack Pantry Cache"},
+		{filepath.Join(stackRoot, "snapshots"), "Stack Snapshot Builds"},
+		{filepath.Join(cabalDir, "packages"), "Cabal Package Cache"},
+		{filepath.Join(cabalDir, "store"), "Cabal Store"},
+		{s.ExpandPath("~/.cache/cabal/packages"), "Cabal Package Cache"},
+		{s.ExpandPath("~/.local/state/cabal/store"), "Cabal Store"},
+	}
+
+	for _, target := range globalPaths {
+		if !s.PathExists(target.Path) {
+			continue
+		}
+
+		results = append(results, s.scanCacheRoot(target.Path, target.Name, types.TypeHaskell)...)
+	}
+
+	// Stack-installed GHC versions are reinstalled with stack setup, a
+	// large download, rather than fetched on demand
+	if programs := filepath.Join(stackRoot, "programs"); s.PathExists(programs) {
+		size, count, _ := s.calculateSize(programs)
+		if size > 0 {
+			results = append(results, types.ScanResult{
+				Path:       programs,
+				Type:       types.TypeHaskell,
+				Size:       size,
+				FileCount:  count,
+				Name:       "Stack GHC Installs (reinstall with stack setup)",
+				SafetyTier: types.TierReview,
+			})
+		}
+	}
+
+	// Scan for Haskell projects' .stack-work and dist-newstyle directories
+	ctx = s.withSymlinkVisits(ctx)
+	for _, dir := range s.projectRoots() {
+		expandedDir := s.ExpandPath(dir)
+		if !s.PathExists(expandedDir) {
+			continue
+		}
+
+		haskellTargets := s.findHaskellTargets(ctx, expandedDir, maxDepth)
+		results = append(results, haskellTargets...)
+	}
+
+	return results
+}
+
+// isHaskellMarker reports whether a file name marks a Stack or Cabal
+// project root
+func isHaskellMarker(name string) bool {
+	return slices.Contains(haskellProjectMarkers, name) || strings.HasSuffix(name, ".cabal")
+}
+
+// findHaskellTargets recursively finds .stack-work and dist-newstyle
+// directories of Stack/Cabal projects, including those of the packages of
+// a multi-package project
+func (s *Scanner) findHaskellTargets(ctx context.Context, root string, maxDepth int) []types.ScanResult {
+	var results []types.ScanResult
+
+	if maxDepth <= 0 || ctx.Err() != nil {
+		return results
+	}
+
+	entries, err := s.readDir(root)
+	if err != nil {
+		return results
+	}
+
+	// Check if this directory contains stack.yaml, cabal.project or a
+	// *.cabal file (is a Haskell project)
+	isHaskellProject := false
+	for _, entry := range entries {
+		if !entry.IsDir() && isHaskellMarker(entry.Name()) {
+			isHaskellProject = true
+			break
+		}
+	}
+
+	// If Haskell project, add its build directories
+	if isHaskellProject {
+		projectName := filepath.Base(root)
+		for _, dirName := range haskellBuildDirs {
+			targetPath := filepath.Join(root, dirName)
+			if !s.PathExists(targetPath) {
+				continue
+			}
+			size, count, _ := s.calculateSize(targetPath)
+			if size > 0 {
+				results = append(results, types.ScanResult{
+					Path:       targetPath,
+					Type:       types.TypeHaskell,
+					Size:       size,
+					FileCount:  count,
+					Name:       projectName + "/" + dirName,
+					SafetyTier: types.TierInactive,
+				})
+			}
+		}
+	}
+
+	// Recurse into subdirectories: Stack builds each package of a
+	// multi-package project in the package's own .stack-work
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			// Symlinked directories only with ScanOptions.FollowSymlinks
+			if linked := filepath.Join(root, entry.Name()); s.followSymlink(ctx, linked, entry) {
+				results = append(results, s.findHaskellTargets(ctx, linked, maxDepth-1)...)
+			}
+			continue
+		}
+
+		name := entry.Name()
+
+		// Build directories were reported above, or are not a project's
+		if slices.Contains(haskellBuildDirs, name) {
+			continue
+		}
+
+		// Skip hidden and common non-project dirs
+		if s.skipDir(root, name) {
+			continue
+		}
+
+		fullPath := filepath.Join(root, name)
+		subResults := s.findHaskellTargets(ctx, fullPath, maxDepth-1)
+		results = append(results, subResults...)
+	}
+
+	return results
+}
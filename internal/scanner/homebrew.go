@@ -27,18 +27,7 @@ func (s *Scanner) ScanHomebrew() []types.ScanResult {
 			continue
 		}
 
-		size, count, err := s.calculateSize(path)
-		if err != nil || size == 0 {
-			continue
-		}
-
-		results = append(results, types.ScanResult{
-			Path:      path,
-			Type:      types.TypeHomebrew,
-			Size:      size,
-			FileCount: count,
-			Name:      target.Name,
-		})
+		results = append(results, s.scanCacheRoot(path, target.Name, types.TypeHomebrew)...)
 	}
 
 	return results
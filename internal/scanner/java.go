@@ -1,9 +1,8 @@
 package scanner
 
 import (
-	"os"
+	"context"
 	"path/filepath"
-	"strings"
 
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
@@ -13,11 +12,8 @@ var JavaGlobalPaths = []struct {
 	Path string
 	Name string
 }{
-	// Maven
+	// Maven (global Gradle paths are handled by ScanGradle)
 	{"~/.m2/repository", "Maven Local Repository"},
-	// Gradle (note: ~/.gradle/caches already in Android scanner)
-	{"~/.gradle/wrapper", "Gradle Wrapper Distributions"},
-	{"~/.gradle/daemon", "Gradle Daemon Logs"},
 }
 
 // JavaMarkerFiles identify Java/Kotlin projects
@@ -29,7 +25,7 @@ var JavaMarkerFiles = map[string]string{
 }
 
 // ScanJava scans for Java/Kotlin development artifacts
-func (s *Scanner) ScanJava(maxDepth int) []types.ScanResult {
+func (s *Scanner) ScanJava(ctx context.Context, maxDepth int) []types.ScanResult {
 	var results []types.ScanResult
 
 	// Scan global caches
@@ -45,33 +41,25 @@ func (s *Scanner) ScanJava(maxDepth int) []types.ScanResult {
 		}
 
 		results = append(results, types.ScanResult{
-			Path:      path,
-			Type:      types.TypeJava,
-			Size:      size,
-			FileCount: count,
-			Name:      target.Name,
+			Path:       path,
+			Type:       types.TypeJava,
+			Size:       size,
+			FileCount:  count,
+			Name:       target.Name,
+			SafetyTier: types.TierSafe,
 		})
 	}
 
-	// Scan for Java projects in common development directories
-	projectDirs := []string{
-		"~/Documents",
-		"~/Projects",
-		"~/Development",
-		"~/Developer",
-		"~/Code",
-		"~/repos",
-		"~/workspace",
-		"~/IdeaProjects", // IntelliJ default
-	}
-
-	for _, dir := range projectDirs {
+	// Scan for Java projects in common development directories and the
+	// IntelliJ default
+	ctx = s.withSymlinkVisits(ctx)
+	for _, dir := range s.projectRoots("~/IdeaProjects") {
 		expandedDir := s.ExpandPath(dir)
 		if !s.PathExists(expandedDir) {
 			continue
 		}
 
-		javaArtifacts := s.findJavaArtifacts(expandedDir, maxDepth)
+		javaArtifacts := s.findJavaArtifacts(ctx, expandedDir, maxDepth)
 		results = append(results, javaArtifacts...)
 	}
 
@@ -79,14 +67,14 @@ func (s *Scanner) ScanJava(maxDepth int) []types.ScanResult {
 }
 
 // findJavaArtifacts recursively finds Java project build artifacts
-func (s *Scanner) findJavaArtifacts(root string, maxDepth int) []types.ScanResult {
+func (s *Scanner) findJavaArtifacts(ctx context.Context, root string, maxDepth int) []types.ScanResult {
 	var results []types.ScanResult
 
-	if maxDepth <= 0 {
+	if maxDepth <= 0 || ctx.Err() != nil {
 		return results
 	}
 
-	entries, err := os.ReadDir(root)
+	entries, err := s.readDir(root)
 	if err != nil {
 		return results
 	}
@@ -123,11 +111,12 @@ func (s *Scanner) findJavaArtifacts(root string, maxDepth int) []types.ScanResul
 			size, count, _ := s.calculateSize(targetPath)
 			if size > 0 {
 				results = append(results, types.ScanResult{
-					Path:      targetPath,
-					Type:      types.TypeJava,
-					Size:      size,
-					FileCount: count,
-					Name:      projectName + "/target (Maven)",
+					Path:       targetPath,
+					Type:       types.TypeJava,
+					Size:       size,
+					FileCount:  count,
+					Name:       projectName + "/target (Maven)",
+					SafetyTier: types.TierInactive,
 				})
 			}
 		}
@@ -138,11 +127,12 @@ func (s *Scanner) findJavaArtifacts(root string, maxDepth int) []types.ScanResul
 			size, count, _ := s.calculateSize(buildPath)
 			if size > 0 {
 				results = append(results, types.ScanResult{
-					Path:      buildPath,
-					Type:      types.TypeJava,
-					Size:      size,
-					FileCount: count,
-					Name:      projectName + "/build (Gradle)",
+					Path:       buildPath,
+					Type:       types.TypeJava,
+					Size:       size,
+					FileCount:  count,
+					Name:       projectName + "/build (Gradle)",
+					SafetyTier: types.TierInactive,
 				})
 			}
 		}
@@ -153,11 +143,12 @@ func (s *Scanner) findJavaArtifacts(root string, maxDepth int) []types.ScanResul
 			size, count, _ := s.calculateSize(dotGradlePath)
 			if size > 0 {
 				results = append(results, types.ScanResult{
-					Path:      dotGradlePath,
-					Type:      types.TypeJava,
-					Size:      size,
-					FileCount: count,
-					Name:      projectName + "/.gradle",
+					Path:       dotGradlePath,
+					Type:       types.TypeJava,
+					Size:       size,
+					FileCount:  count,
+					Name:       projectName + "/.gradle",
+					SafetyTier: types.TierInactive,
 				})
 			}
 		}
@@ -169,18 +160,17 @@ func (s *Scanner) findJavaArtifacts(root string, maxDepth int) []types.ScanResul
 	// Recurse into subdirectories
 	for _, entry := range entries {
 		if !entry.IsDir() {
+			// Symlinked directories only with ScanOptions.FollowSymlinks
+			if linked := filepath.Join(root, entry.Name()); s.followSymlink(ctx, linked, entry) {
+				results = append(results, s.findJavaArtifacts(ctx, linked, maxDepth-1)...)
+			}
 			continue
 		}
 
 		name := entry.Name()
 
-		// Skip hidden directories
-		if strings.HasPrefix(name, ".") {
-			continue
-		}
-
-		// Skip common non-project dirs
-		if shouldSkipDir(name) {
+		// Skip hidden and common non-project dirs
+		if s.skipDir(root, name) {
 			continue
 		}
 
@@ -190,7 +180,7 @@ func (s *Scanner) findJavaArtifacts(root string, maxDepth int) []types.ScanResul
 		}
 
 		fullPath := filepath.Join(root, name)
-		subResults := s.findJavaArtifacts(fullPath, maxDepth-1)
+		subResults := s.findJavaArtifacts(ctx, fullPath, maxDepth-1)
 		results = append(results, subResults...)
 	}
 
@@ -0,0 +1,53 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// jetBrainsRoots are the folders where JetBrains IDEs keep one subfolder
+// per product and version (e.g. IntelliJIdea2024.1), regenerated on the
+// next launch
+var jetBrainsRoots = []struct {
+	Path string
+	Name string
+}{
+	{"~/Library/Caches/JetBrains", "JetBrains Caches"},
+	{"~/Library/Logs/JetBrains", "JetBrains Logs"},
+}
+
+// ScanJetBrains scans for JetBrains IDE (IntelliJ, PyCharm, GoLand...)
+// caches, indexes and logs, one result per product folder
+func (s *Scanner) ScanJetBrains() []types.ScanResult {
+	var results []types.ScanResult
+
+	for _, root := range jetBrainsRoots {
+		path := s.ExpandPath(root.Path)
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			productPath := filepath.Join(path, entry.Name())
+			size, count, _ := s.calculateSize(productPath)
+			if size > 0 {
+				results = append(results, types.ScanResult{
+					Path:       productPath,
+					Type:       types.TypeJetBrains,
+					Size:       size,
+					FileCount:  count,
+					Name:       root.Name + "/" + entry.Name(),
+					SafetyTier: types.TierSafe,
+				})
+			}
+		}
+	}
+
+	return results
+}
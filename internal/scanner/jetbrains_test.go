@@ -0,0 +1,43 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestScanJetBrains(t *testing.T) {
+	s, _ := newFixtureScanner(t)
+	home := s.ExpandPath("~")
+
+	caches := filepath.Join(home, "Library", "Caches", "JetBrains")
+	logs := filepath.Join(home, "Library", "Logs", "JetBrains")
+	writeTestFile(t, filepath.Join(caches, "IntelliJIdea2024.1", "index", "shard"))
+	writeTestFile(t, filepath.Join(caches, "GoLand2024.1", "caches", "content.dat"))
+	writeTestFile(t, filepath.Join(caches, "stray.txt"))
+	writeTestFile(t, filepath.Join(logs, "PyCharm2023.3", "idea.log"))
+
+	results := s.ScanJetBrains()
+	names := make(map[string]bool)
+	for _, result := range results {
+		if result.Type != types.TypeJetBrains || result.SafetyTier != types.TierSafe {
+			t.Errorf("result %+v, want a safe jetbrains result", result)
+		}
+		names[result.Name] = true
+	}
+
+	want := []string{
+		"JetBrains Caches/IntelliJIdea2024.1",
+		"JetBrains Caches/GoLand2024.1",
+		"JetBrains Logs/PyCharm2023.3",
+	}
+	if len(results) != len(want) {
+		t.Errorf("ScanJetBrains() returned %d results, want %d", len(results), len(want))
+	}
+	for _, name := range want {
+		if !names[name] {
+			t.Errorf("ScanJetBrains() missing %q, got %v", name, names)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// LastScanFileName is the snapshot of the previous scan's results, kept in
+// the home directory, that scan --diff compares against
+const LastScanFileName = ".dev-cleaner-lastscan.json"
+
+// LastScan is the saved result list of the most recent scan
+type LastScan struct {
+	Saved   time.Time          `json:"saved"`
+	Results []types.ScanResult `json:"results"`
+}
+
+// DefaultLastScanPath returns the default snapshot location,
+// ~/.dev-cleaner-lastscan.json
+func DefaultLastScanPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, LastScanFileName), nil
+}
+
+// LoadLastScan reads the snapshot at path. A missing file is an empty
+// LastScan (no previous scan).
+func LoadLastScan(path string) (LastScan, error) {
+	var last LastScan
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return last, nil
+	}
+	if err != nil {
+		return last, err
+	}
+
+	if err := json.Unmarshal(data, &last); err != nil {
+		return last, fmt.Errorf("invalid last scan file %s: %w", path, err)
+	}
+	return last, nil
+}
+
+// Within returns the snapshot's results of the categories opts scans, so
+// a scan of some categories is only compared with those
+func (l LastScan) Within(opts types.ScanOptions) []types.ScanResult {
+	var results []types.ScanResult
+	for _, result := range l.Results {
+		if included, _ := includesType(opts, result.Type); included {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Update returns the snapshot after a scan with opts found results: the
+// scanned categories are replaced, the others keep their previous results
+func (l LastScan) Update(results []types.ScanResult, opts types.ScanOptions) LastScan {
+	updated := LastScan{Saved: time.Now()}
+	for _, result := range l.Results {
+		if included, _ := includesType(opts, result.Type); !included {
+			updated.Results = append(updated.Results, result)
+		}
+	}
+	updated.Results = append(updated.Results, results...)
+	return updated
+}
+
+// SaveLastScan replaces the snapshot at path atomically, so an interrupted
+// write never leaves a truncated file
+func SaveLastScan(path string, last LastScan) error {
+	data, err := json.Marshal(last)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), LastScanFileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// diffOrder sorts changes: new results first, then grown, then gone
+var diffOrder = map[types.ChangeKind]int{
+	types.ChangeNew:   0,
+	types.ChangeGrown: 1,
+	types.ChangeGone:  2,
+}
+
+// DiffResults compares two scans by path and returns the results of latest
+// that are new or have grown, and those of old that have disappeared.
+// Unchanged and shrunk results are left out. Each kind is sorted by the
+// size of its change, largest first.
+func DiffResults(old, latest []types.ScanResult) []types.ResultChange {
+	previous := make(map[string]types.ScanResult, len(old))
+	for _, result := range old {
+		previous[result.Path] = result
+	}
+
+	var changes []types.ResultChange
+	current := make(map[string]bool, len(latest))
+	for _, result := range latest {
+		current[result.Path] = true
+		before, ok := previous[result.Path]
+		switch {
+		case !ok:
+			changes = append(changes, types.ResultChange{Kind: types.ChangeNew, Result: result, Delta: result.Size})
+		case result.Size > before.Size:
+			changes = append(changes, types.ResultChange{Kind: types.ChangeGrown, Result: result, Delta: result.Size - before.Size})
+		}
+	}
+	for _, result := range old {
+		if !current[result.Path] {
+			changes = append(changes, types.ResultChange{Kind: types.ChangeGone, Result: result, Delta: -result.Size})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return diffOrder[changes[i].Kind] < diffOrder[changes[j].Kind]
+		}
+		return abs(changes[i].Delta) > abs(changes[j].Delta)
+	})
+	return changes
+}
+
+// abs returns the magnitude of a size change
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
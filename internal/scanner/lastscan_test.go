@@ -0,0 +1,85 @@
+package scanner
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestDiffResults(t *testing.T) {
+	old := []types.ScanResult{
+		{Path: "/p/web/node_modules", Type: types.TypeNode, Size: 100},
+		{Path: "/p/api/node_modules", Type: types.TypeNode, Size: 500},
+		{Path: "/p/old/target", Type: types.TypeRust, Size: 300},
+		{Path: "/p/cli/target", Type: types.TypeRust, Size: 50},
+	}
+	latest := []types.ScanResult{
+		{Path: "/p/web/node_modules", Type: types.TypeNode, Size: 3100},
+		{Path: "/p/api/node_modules", Type: types.TypeNode, Size: 400}, // Shrunk: left out
+		{Path: "/p/cli/target", Type: types.TypeRust, Size: 50},        // Unchanged: left out
+		{Path: "/p/app/node_modules", Type: types.TypeNode, Size: 10},
+		{Path: "/p/game/target", Type: types.TypeRust, Size: 20},
+	}
+
+	var got []string
+	for _, change := range DiffResults(old, latest) {
+		got = append(got, string(change.Kind)+" "+change.Result.Path)
+	}
+	want := []string{
+		"new /p/game/target",
+		"new /p/app/node_modules",
+		"grown /p/web/node_modules",
+		"gone /p/old/target",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("DiffResults() = %q, want %q", got, want)
+	}
+
+	changes := DiffResults(old, latest)
+	if changes[2].Delta != 3000 || changes[3].Delta != -300 {
+		t.Errorf("deltas = %d, %d; want 3000, -300", changes[2].Delta, changes[3].Delta)
+	}
+}
+
+func TestLastScan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LastScanFileName)
+
+	last, err := LoadLastScan(path)
+	if err != nil || !last.Saved.IsZero() || len(last.Results) != 0 {
+		t.Fatalf("LoadLastScan() without a file = %+v, %v; want empty", last, err)
+	}
+
+	last.Results = []types.ScanResult{
+		{Path: "/p/web/node_modules", Type: types.TypeNode, Size: 100},
+		{Path: "/p/cli/target", Type: types.TypeRust, Size: 50},
+	}
+
+	// A node-only scan replaces the node results and keeps the rust ones
+	nodeOnly := types.ScanOptions{IncludeNode: true}
+	scanned := []types.ScanResult{{Path: "/p/api/node_modules", Type: types.TypeNode, Size: 70}}
+	if err := SaveLastScan(path, last.Update(scanned, nodeOnly)); err != nil {
+		t.Fatalf("SaveLastScan() error = %v", err)
+	}
+
+	loaded, err := LoadLastScan(path)
+	if err != nil {
+		t.Fatalf("LoadLastScan() error = %v", err)
+	}
+	if loaded.Saved.IsZero() {
+		t.Error("Saved not set")
+	}
+	var paths []string
+	for _, result := range loaded.Results {
+		paths = append(paths, result.Path)
+	}
+	if want := []string{"/p/cli/target", "/p/api/node_modules"}; !slices.Equal(paths, want) {
+		t.Errorf("saved paths = %v, want %v", paths, want)
+	}
+
+	within := loaded.Within(nodeOnly)
+	if len(within) != 1 || within[0].Path != "/p/api/node_modules" {
+		t.Errorf("Within(node) = %+v, want only api/node_modules", within)
+	}
+}
@@ -1,8 +1,10 @@
 package scanner
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
@@ -14,7 +16,6 @@ var NodeGlobalPaths = []struct {
 	Name string
 }{
 	{"~/.npm", "npm Cache"},
-	{"~/.pnpm-store", "pnpm Store"},
 	{"~/.yarn/cache", "Yarn Cache"},
 	{"~/.bun/install/cache", "Bun Cache"},
 }
@@ -28,7 +29,7 @@ var SkipDirs = []string{
 }
 
 // ScanNode scans for Node.js development artifacts
-func (s *Scanner) ScanNode(maxDepth int) []types.ScanResult {
+func (s *Scanner) ScanNode(ctx context.Context, maxDepth int) []types.ScanResult {
 	var results []types.ScanResult
 
 	// Scan global caches
@@ -38,89 +39,84 @@ func (s *Scanner) ScanNode(maxDepth int) []types.ScanResult {
 			continue
 		}
 
-		size, count, err := s.calculateSize(path)
-		if err != nil || size == 0 {
-			continue
-		}
-
-		results = append(results, types.ScanResult{
-			Path:      path,
-			Type:      types.TypeNode,
-			Size:      size,
-			FileCount: count,
-			Name:      target.Name,
-		})
+		results = append(results, s.scanCacheRoot(path, target.Name, types.TypeNode)...)
 	}
 
-	// Scan for project node_modules in common development directories
-	projectDirs := []string{
-		"~/Documents",
-		"~/Projects",
-		"~/Development",
-		"~/Developer",
-		"~/Code",
-		"~/repos",
-		"~/workspace",
+	// Bun honors BUN_INSTALL_CACHE_DIR for a relocated install cache
+	if bunCache := os.Getenv("BUN_INSTALL_CACHE_DIR"); bunCache != "" && s.PathExists(bunCache) {
+		results = append(results, s.scanCacheRoot(bunCache, "Bun Cache", types.TypeNode)...)
 	}
 
-	for _, dir := range projectDirs {
+	// Scan for project node_modules in common development directories
+	var nodeModules []types.ScanResult
+	ctx = s.withSymlinkVisits(ctx)
+	for _, dir := range s.projectRoots() {
 		expandedDir := s.ExpandPath(dir)
 		if !s.PathExists(expandedDir) {
 			continue
 		}
 
-		nodeModules := s.findNodeModules(expandedDir, maxDepth)
-		results = append(results, nodeModules...)
+		nodeModules = append(nodeModules, s.findNodeModules(ctx, expandedDir, maxDepth)...)
 	}
+	results = append(results, nodeModules...)
+
+	// pnpm stores are sized after the projects that may link into them
+	results = append(results, s.scanPnpmStores(nodeModules)...)
 
 	return results
 }
 
 // findNodeModules recursively finds node_modules directories
-func (s *Scanner) findNodeModules(root string, maxDepth int) []types.ScanResult {
+func (s *Scanner) findNodeModules(ctx context.Context, root string, maxDepth int) []types.ScanResult {
 	var results []types.ScanResult
 
-	if maxDepth <= 0 {
+	if maxDepth <= 0 || ctx.Err() != nil {
 		return results
 	}
 
-	entries, err := os.ReadDir(root)
+	entries, err := s.readDir(root)
 	if err != nil {
 		return results
 	}
 
 	for _, entry := range entries {
 		if !entry.IsDir() {
+			// Symlinked directories only with ScanOptions.FollowSymlinks
+			if linked := filepath.Join(root, entry.Name()); s.followSymlink(ctx, linked, entry) {
+				results = append(results, s.findNodeModules(ctx, linked, maxDepth-1)...)
+			}
 			continue
 		}
 
 		name := entry.Name()
-
-		// Skip hidden and known non-project directories
-		if shouldSkipDir(name) {
-			continue
-		}
-
 		fullPath := filepath.Join(root, name)
 
+		// Checked before shouldSkipDir, which skips node_modules when
+		// walking for every other ecosystem
 		if name == "node_modules" {
 			size, count, _ := s.calculateSize(fullPath)
 			if size > 0 {
 				// Get parent project name
 				projectName := filepath.Base(root)
 				results = append(results, types.ScanResult{
-					Path:      fullPath,
-					Type:      types.TypeNode,
-					Size:      size,
-					FileCount: count,
-					Name:      projectName + "/node_modules",
+					Path:       fullPath,
+					Type:       types.TypeNode,
+					Size:       size,
+					FileCount:  count,
+					Name:       projectName + "/node_modules",
+					SafetyTier: types.TierInactive,
 				})
 			}
 			continue // Don't recurse into node_modules
 		}
 
+		// Skip hidden and known non-project directories
+		if s.skipDir(root, name) {
+			continue
+		}
+
 		// Recurse into subdirectories
-		subResults := s.findNodeModules(fullPath, maxDepth-1)
+		subResults := s.findNodeModules(ctx, fullPath, maxDepth-1)
 		results = append(results, subResults...)
 	}
 
@@ -142,3 +138,14 @@ func shouldSkipDir(name string) bool {
 
 	return false
 }
+
+// skipDir is shouldSkipDir for the entry name of directory dir. With
+// ScanOptions.IncludeHiddenRoots, hidden directories directly below a
+// project root (e.g. ~/Projects/.archive) are searched too. SkipDirs such
+// as .git stay boundaries, and deeper hidden directories are still skipped.
+func (s *Scanner) skipDir(dir, name string) bool {
+	if s.hidden && strings.HasPrefix(name, ".") && !slices.Contains(SkipDirs, name) && s.isProjectRoot(dir) {
+		return false
+	}
+	return shouldSkipDir(name)
+}
@@ -0,0 +1,124 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// ComposerCachePaths contains the usual global Composer cache locations
+// (macOS default, XDG and legacy ~/.composer)
+var ComposerCachePaths = []string{
+	"~/Library/Caches/composer",
+	"~/.cache/composer",
+	"~/.composer/cache",
+}
+
+// getComposerCacheDirs returns COMPOSER_CACHE_DIR and COMPOSER_HOME/cache
+// (when set) followed by the default cache locations
+func (s *Scanner) getComposerCacheDirs() []string {
+	var dirs []string
+	if cacheDir := os.Getenv("COMPOSER_CACHE_DIR"); cacheDir != "" {
+		dirs = append(dirs, cacheDir)
+	}
+	if composerHome := os.Getenv("COMPOSER_HOME"); composerHome != "" {
+		dirs = append(dirs, filepath.Join(composerHome, "cache"))
+	}
+	for _, path := range ComposerCachePaths {
+		dirs = append(dirs, s.ExpandPath(path))
+	}
+	return dirs
+}
+
+// ScanPHP scans for PHP Composer artifacts (global cache and project
+// vendor directories, including Laravel apps)
+func (s *Scanner) ScanPHP(ctx context.Context, maxDepth int) []types.ScanResult {
+	var results []types.ScanResult
+
+	// Global Composer cache, each location reported once
+	seen := make(map[string]bool)
+	for _, path := range s.getComposerCacheDirs() {
+		path = filepath.Clean(path)
+		if seen[path] || !s.PathExists(path) {
+			continue
+		}
+		seen[path] = true
+
+		results = append(results, s.scanCacheRoot(path, "Composer Cache", types.TypePHP)...)
+	}
+
+	// Scan for project vendor directories in common development directories
+	ctx = s.withSymlinkVisits(ctx)
+	for _, dir := range s.projectRoots() {
+		expandedDir := s.ExpandPath(dir)
+		if !s.PathExists(expandedDir) {
+			continue
+		}
+
+		vendorDirs := s.findComposerVendor(ctx, expandedDir, maxDepth)
+		results = append(results, vendorDirs...)
+	}
+
+	return results
+}
+
+// findComposerVendor recursively finds vendor directories next to a
+// composer.json (a bare vendor/ may belong to Go or another tool)
+func (s *Scanner) findComposerVendor(ctx context.Context, root string, maxDepth int) []types.ScanResult {
+	var results []types.ScanResult
+
+	if maxDepth <= 0 || ctx.Err() != nil {
+		return results
+	}
+
+	entries, err := s.readDir(root)
+	if err != nil {
+		return results
+	}
+
+	isComposerProject := s.PathExists(filepath.Join(root, "composer.json"))
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			// Symlinked directories only with ScanOptions.FollowSymlinks
+			if linked := filepath.Join(root, entry.Name()); s.followSymlink(ctx, linked, entry) {
+				results = append(results, s.findComposerVendor(ctx, linked, maxDepth-1)...)
+			}
+			continue
+		}
+
+		name := entry.Name()
+
+		// Skip hidden and known non-project directories
+		if s.skipDir(root, name) {
+			continue
+		}
+
+		fullPath := filepath.Join(root, name)
+
+		if name == "vendor" && isComposerProject {
+			size, count, _ := s.calculateSize(fullPath)
+			if size > 0 {
+				// Get parent project name
+				projectName := filepath.Base(root)
+				results = append(results, types.ScanResult{
+					Path:       fullPath,
+					Type:       types.TypePHP,
+					Size:       size,
+					FileCount:  count,
+					Name:       projectName + "/vendor",
+					SafetyTier: types.TierInactive,
+				})
+			}
+			continue // Don't recurse into vendor
+		}
+
+		// Recurse into subdirectories
+		subResults := s.findComposerVendor(ctx, fullPath, maxDepth-1)
+		results = append(results, subResults...)
+	}
+
+	return results
+}
@@ -0,0 +1,49 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetComposerCacheDirs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("COMPOSER_HOME", home)
+	t.Setenv("COMPOSER_CACHE_DIR", "")
+
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	dirs := s.getComposerCacheDirs()
+	if want := filepath.Join(home, "cache"); len(dirs) == 0 || dirs[0] != want {
+		t.Errorf("getComposerCacheDirs() = %v, want %s first", dirs, want)
+	}
+}
+
+func TestFindComposerVendor(t *testing.T) {
+	root := t.TempDir()
+
+	// Laravel app with dependencies installed
+	writeTestFile(t, filepath.Join(root, "shop", "composer.json"))
+	writeTestFile(t, filepath.Join(root, "shop", "artisan"))
+	writeTestFile(t, filepath.Join(root, "shop", "vendor", "autoload.php"))
+
+	// vendor without composer.json (e.g. Go vendoring) is left alone
+	writeTestFile(t, filepath.Join(root, "tool", "go.mod"))
+	writeTestFile(t, filepath.Join(root, "tool", "vendor", "modules.txt"))
+
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	results := s.findComposerVendor(context.Background(), root, 3)
+	if len(results) != 1 {
+		t.Fatalf("findComposerVendor() returned %d results, want 1: %v", len(results), results)
+	}
+	if want := filepath.Join(root, "shop", "vendor"); results[0].Path != want || results[0].Name != "shop/vendor" {
+		t.Errorf("findComposerVendor() = %+v, want shop/vendor at %s", results[0], want)
+	}
+}
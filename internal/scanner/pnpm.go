@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// PnpmStorePaths contains pnpm content-addressable store locations (the
+// legacy ~/.pnpm-store and the pnpm 7+ defaults on macOS and Linux)
+var PnpmStorePaths = []string{
+	"~/.pnpm-store",
+	"~/Library/pnpm/store",
+	"~/.local/share/pnpm/store",
+}
+
+// scanPnpmStores reports each pnpm store by the bytes deleting it would
+// actually free. pnpm hard-links store files into every project's
+// node_modules, so linked files free nothing, and a store that scanned
+// projects link into is marked Risky: those projects must be reinstalled.
+func (s *Scanner) scanPnpmStores(nodeModules []types.ScanResult) []types.ScanResult {
+	var results []types.ScanResult
+
+	seen := make(map[string]bool)
+	for _, path := range PnpmStorePaths {
+		path = filepath.Clean(s.ExpandPath(path))
+		if seen[path] || !s.PathExists(path) {
+			continue
+		}
+		seen[path] = true
+
+		unique, shared, count, err := pnpmStoreSize(path)
+		if err != nil || unique == 0 {
+			continue
+		}
+
+		name := "pnpm Store"
+		linked := countPnpmProjects(path, nodeModules)
+		if linked > 0 {
+			name += fmt.Sprintf(" (used by %d projects; reinstall them after deleting)", linked)
+		} else if shared > 0 {
+			name += " (hard-linked into projects; reinstall them after deleting)"
+		}
+
+		results = append(results, types.ScanResult{
+			Path:       path,
+			Type:       types.TypeNode,
+			Size:       unique,
+			FileCount:  count,
+			Name:       name,
+			Risky:      linked > 0 || shared > 0,
+			SafetyTier: types.TierSafe,
+		})
+	}
+
+	return results
+}
+
+// pnpmStoreSize sums the store's files into bytes only the store holds
+// (unique) and bytes hard-linked elsewhere (shared)
+func pnpmStoreSize(path string) (unique, shared int64, count int, err error) {
+	err = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip inaccessible files
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if linkCount(info) > 1 {
+			shared += info.Size()
+		} else {
+			unique += info.Size()
+		}
+		count++
+		return nil
+	})
+	return unique, shared, count, err
+}
+
+// countPnpmProjects counts the node_modules directories installed from the
+// store at storePath, according to the storeDir in their .modules.yaml
+func countPnpmProjects(storePath string, nodeModules []types.ScanResult) int {
+	var count int
+	for _, result := range nodeModules {
+		storeDir := readPnpmStoreDir(filepath.Join(result.Path, ".modules.yaml"))
+		if storeDir == "" {
+			continue
+		}
+		rel, err := filepath.Rel(storePath, storeDir)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			count++
+		}
+	}
+	return count
+}
+
+// readPnpmStoreDir returns the storeDir recorded in a pnpm .modules.yaml,
+// or "" if the file is missing or has none
+func readPnpmStoreDir(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(scanner.Text(), "storeDir:")
+		if ok {
+			return filepath.Clean(strings.Trim(strings.TrimSpace(value), `"'`))
+		}
+	}
+	return ""
+}
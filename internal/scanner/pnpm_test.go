@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScanPnpmStore(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	store := filepath.Join(home, ".pnpm-store")
+	writeTestFile(t, filepath.Join(store, "v3", "files", "00", "unique"))
+	linkedFile := filepath.Join(store, "v3", "files", "01", "linked")
+	writeTestFile(t, linkedFile)
+
+	// pnpm project: node_modules hard-links into the store
+	nodeModules := filepath.Join(home, "Projects", "app", "node_modules")
+	if err := os.MkdirAll(filepath.Join(nodeModules, "left-pad"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	modulesYAML := "layoutVersion: 5\nstoreDir: " + filepath.Join(store, "v3") + "\n"
+	if err := os.WriteFile(filepath.Join(nodeModules, ".modules.yaml"), []byte(modulesYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(linkedFile, filepath.Join(nodeModules, "left-pad", "index.js")); err != nil {
+		t.Skipf("hard links not supported: %v", err)
+	}
+
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var found bool
+	for _, result := range s.ScanNode(context.Background(), 3) {
+		if result.Path != store {
+			continue
+		}
+		found = true
+		if result.Size != 1 || result.FileCount != 2 {
+			t.Errorf("store size = %d (%d files), want 1 (2 files): linked files free nothing", result.Size, result.FileCount)
+		}
+		if !result.Risky {
+			t.Error("store Risky = false, want true while a project links into it")
+		}
+		if !strings.Contains(result.Name, "used by 1 projects") {
+			t.Errorf("store name = %q, want linked project count", result.Name)
+		}
+	}
+	if !found {
+		t.Fatal("pnpm store not reported")
+	}
+}
+
+func TestReadPnpmStoreDir(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".modules.yaml")
+
+	if got := readPnpmStoreDir(path); got != "" {
+		t.Errorf("readPnpmStoreDir(missing) = %q, want empty", got)
+	}
+
+	if err := os.WriteFile(path, []byte("hoistPattern:\n  - '*'\nstoreDir: '/Users/me/Library/pnpm/store/v3'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := readPnpmStoreDir(path); got != "/Users/me/Library/pnpm/store/v3" {
+		t.Errorf("readPnpmStoreDir() = %q, want /Users/me/Library/pnpm/store/v3", got)
+	}
+}
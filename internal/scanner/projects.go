@@ -0,0 +1,137 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// platformDirs are the per-platform folders of multi-platform projects
+// (React Native, Flutter) whose build output belongs to the project above
+var platformDirs = map[string]bool{
+	"ios": true, "android": true, "app": true, "macos": true,
+	"linux": true, "windows": true, "web": true,
+}
+
+// projectManifests are file names that mark a project root
+var projectManifests = []string{
+	"package.json", "deno.json", "Cargo.toml", "go.mod", "pom.xml",
+	"build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts",
+	"pubspec.yaml", "Podfile", "Package.swift", "pyproject.toml", "setup.py",
+	"requirements.txt", "Pipfile", "composer.json", "mix.exs", "rebar.config",
+	"stack.yaml", "cabal.project",
+}
+
+// projectManifestSuffixes mark a project root by extension, e.g. app.cabal
+var projectManifestSuffixes = []string{".cabal", ".csproj", ".fsproj", ".sln", ".xcodeproj"}
+
+// projectLevels is how many folders above an artifact ProjectRoot looks
+// for a project manifest, e.g. api/app/__pycache__ belongs to api
+const projectLevels = 4
+
+// manifestDirs caches hasProjectManifest by directory; the grouped TUI
+// list asks for every item on each redraw
+var manifestDirs sync.Map
+
+// hasProjectManifest reports whether dir holds a project manifest
+func hasProjectManifest(dir string) bool {
+	if found, ok := manifestDirs.Load(dir); ok {
+		return found.(bool)
+	}
+	entries, _ := os.ReadDir(dir)
+	found := slices.ContainsFunc(entries, func(entry os.DirEntry) bool {
+		name := entry.Name()
+		return slices.Contains(projectManifests, name) || slices.ContainsFunc(projectManifestSuffixes, func(suffix string) bool {
+			return strings.HasSuffix(name, suffix)
+		})
+	})
+	manifestDirs.Store(dir, found)
+	return found
+}
+
+// ProjectGroup is the artifacts of one project, for --group-by-project
+type ProjectGroup struct {
+	Root  string // Project root, "" for results that belong to no project
+	Name  string
+	Size  int64
+	Items []int // Indices into the grouped results, in their order
+}
+
+// ProjectRoot returns the project a result's artifact belongs to: the
+// nearest folder above it with a project manifest (package.json,
+// Cargo.toml, ...), or the project above that for per-platform output such
+// as ios/build or android/app/build when that folder has a manifest too.
+// Results with no manifest above them (global caches and tools, which live
+// outside projects) return "". The home directory is never a project.
+func ProjectRoot(result types.ScanResult) string {
+	if !filepath.IsAbs(result.Path) {
+		return ""
+	}
+	home, _ := os.UserHomeDir()
+
+	root := ""
+	dir := filepath.Dir(result.Path)
+	for i := 0; i < projectLevels; i++ {
+		if dir == home || dir == filepath.Dir(dir) {
+			break
+		}
+		if hasProjectManifest(dir) {
+			root = dir
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	if root == "" {
+		return ""
+	}
+
+	// A project named app or web stays on its own unless the folder above
+	// is a project as well
+	for platformDirs[filepath.Base(root)] && hasProjectManifest(filepath.Dir(root)) {
+		root = filepath.Dir(root)
+	}
+	return root
+}
+
+// GroupByProject groups results by ProjectRoot, largest project first.
+// Results that belong to no project (global caches and tools) come last,
+// in a single group.
+func GroupByProject(results []types.ScanResult) []ProjectGroup {
+	var groups []ProjectGroup
+	var other *ProjectGroup
+	byRoot := make(map[string]int)
+
+	for i, result := range results {
+		root := ProjectRoot(result)
+		if root == "" {
+			if other == nil {
+				other = &ProjectGroup{Name: "Global caches & tools"}
+			}
+			other.Size += result.Size
+			other.Items = append(other.Items, i)
+			continue
+		}
+
+		g, ok := byRoot[root]
+		if !ok {
+			g = len(groups)
+			byRoot[root] = g
+			groups = append(groups, ProjectGroup{Root: root, Name: filepath.Base(root)})
+		}
+		groups[g].Size += result.Size
+		groups[g].Items = append(groups[g].Items, i)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Size > groups[j].Size
+	})
+	if other != nil {
+		groups = append(groups, *other)
+	}
+	return groups
+}
@@ -0,0 +1,78 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestGroupByProject(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	work := filepath.Join(home, "work")
+	for _, manifest := range []string{
+		"proj-a/package.json",
+		"proj-a/ios/Podfile",
+		"proj-a/android/settings.gradle",
+		"proj-a/android/app/build.gradle",
+		"proj-b/Cargo.toml",
+		"api/pyproject.toml",
+		// Projects named like platform folders stay on their own
+		"web/package.json",
+		"app/package.json",
+	} {
+		writeTestFile(t, filepath.Join(work, manifest))
+	}
+	writeTestFile(t, filepath.Join(home, ".npm", "_cacache", "index"))
+	// A manifest in the home directory does not make it a project
+	writeTestFile(t, filepath.Join(home, "package.json"))
+
+	path := func(rel string) string { return filepath.Join(work, rel) }
+	results := []types.ScanResult{
+		{Path: path("proj-a/node_modules"), Size: 100, SafetyTier: types.TierInactive},
+		{Path: filepath.Join(home, ".npm"), Size: 90, SafetyTier: types.TierSafe},
+		{Path: path("proj-b/target"), Size: 80, SafetyTier: types.TierInactive},
+		{Path: path("proj-a/ios/build"), Size: 30, SafetyTier: types.TierInactive},
+		{Path: path("proj-a/android/app/build"), Size: 20, SafetyTier: types.TierInactive},
+		{Path: "docker:images", Size: 10, SafetyTier: types.TierInactive},
+		{Path: path("web/node_modules"), Size: 70, SafetyTier: types.TierInactive},
+		{Path: path("app/node_modules"), Size: 60, SafetyTier: types.TierInactive},
+		// Grouped by path whatever the tier
+		{Path: path("api/src/app/__pycache__"), Size: 5, SafetyTier: types.TierSafe},
+	}
+
+	groups := GroupByProject(results)
+	want := []struct {
+		root  string
+		name  string
+		size  int64
+		items []int
+	}{
+		{path("proj-a"), "proj-a", 150, []int{0, 3, 4}},
+		{path("proj-b"), "proj-b", 80, []int{2}},
+		{path("web"), "web", 70, []int{6}},
+		{path("app"), "app", 60, []int{7}},
+		{path("api"), "api", 5, []int{8}},
+		{"", "Global caches & tools", 100, []int{1, 5}},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d: %+v", len(groups), len(want), groups)
+	}
+	for i, w := range want {
+		g := groups[i]
+		if g.Root != w.root || g.Name != w.name || g.Size != w.size {
+			t.Errorf("group %d = %s %q %d, want %s %q %d", i, g.Root, g.Name, g.Size, w.root, w.name, w.size)
+		}
+		if len(g.Items) != len(w.items) {
+			t.Errorf("group %d items = %v, want %v", i, g.Items, w.items)
+			continue
+		}
+		for j := range w.items {
+			if g.Items[j] != w.items[j] {
+				t.Errorf("group %d items = %v, want %v", i, g.Items, w.items)
+				break
+			}
+		}
+	}
+}
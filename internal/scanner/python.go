@@ -1,9 +1,12 @@
 package scanner
 
 import (
+	"bufio"
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
@@ -43,7 +46,7 @@ var PythonMarkerFiles = []string{
 }
 
 // ScanPython scans for Python development artifacts
-func (s *Scanner) ScanPython(maxDepth int) []types.ScanResult {
+func (s *Scanner) ScanPython(ctx context.Context, maxDepth int) []types.ScanResult {
 	var results []types.ScanResult
 
 	// Scan global caches
@@ -53,38 +56,21 @@ func (s *Scanner) ScanPython(maxDepth int) []types.ScanResult {
 			continue
 		}
 
-		size, count, err := s.calculateSize(path)
-		if err != nil || size == 0 {
-			continue
-		}
-
-		results = append(results, types.ScanResult{
-			Path:      path,
-			Type:      types.TypePython,
-			Size:      size,
-			FileCount: count,
-			Name:      target.Name,
-		})
+		results = append(results, s.scanCacheRoot(path, target.Name, types.TypePython)...)
 	}
 
-	// Scan for Python projects in common development directories
-	projectDirs := []string{
-		"~/Documents",
-		"~/Projects",
-		"~/Development",
-		"~/Developer",
-		"~/Code",
-		"~/repos",
-		"~/workspace",
-	}
+	// Scan conda environments and package caches
+	results = append(results, s.ScanConda()...)
 
-	for _, dir := range projectDirs {
+	// Scan for Python projects in common development directories
+	ctx = s.withSymlinkVisits(ctx)
+	for _, dir := range s.projectRoots() {
 		expandedDir := s.ExpandPath(dir)
 		if !s.PathExists(expandedDir) {
 			continue
 		}
 
-		pythonArtifacts := s.findPythonArtifacts(expandedDir, maxDepth)
+		pythonArtifacts := s.findPythonArtifacts(ctx, expandedDir, maxDepth)
 		results = append(results, pythonArtifacts...)
 	}
 
@@ -92,14 +78,14 @@ func (s *Scanner) ScanPython(maxDepth int) []types.ScanResult {
 }
 
 // findPythonArtifacts recursively finds Python project artifacts
-func (s *Scanner) findPythonArtifacts(root string, maxDepth int) []types.ScanResult {
+func (s *Scanner) findPythonArtifacts(ctx context.Context, root string, maxDepth int) []types.ScanResult {
 	var results []types.ScanResult
 
-	if maxDepth <= 0 {
+	if maxDepth <= 0 || ctx.Err() != nil {
 		return results
 	}
 
-	entries, err := os.ReadDir(root)
+	entries, err := s.readDir(root)
 	if err != nil {
 		return results
 	}
@@ -123,46 +109,62 @@ func (s *Scanner) findPythonArtifacts(root string, maxDepth int) []types.ScanRes
 	// Scan for artifacts in Python project
 	for _, entry := range entries {
 		if !entry.IsDir() {
+			// Symlinked directories only with ScanOptions.FollowSymlinks
+			if linked := filepath.Join(root, entry.Name()); s.followSymlink(ctx, linked, entry) {
+				results = append(results, s.findPythonArtifacts(ctx, linked, maxDepth-1)...)
+			}
 			continue
 		}
 
 		name := entry.Name()
 		fullPath := filepath.Join(root, name)
 
-		// Skip hidden dirs (except Python-specific ones)
-		if strings.HasPrefix(name, ".") && !isPythonArtifactDir(name) {
-			continue
-		}
-
-		// Skip common non-project dirs
-		if shouldSkipDir(name) {
-			continue
-		}
-
-		// Check if this is a Python artifact directory
+		// Check if this is a Python artifact directory (before the skip
+		// below, which would drop hidden ones like .venv and .tox)
 		if isPythonArtifactDir(name) {
 			size, count, _ := s.calculateSize(fullPath)
 			if size > 0 {
 				projectName := filepath.Base(root)
-				results = append(results, types.ScanResult{
-					Path:      fullPath,
-					Type:      types.TypePython,
-					Size:      size,
-					FileCount: count,
-					Name:      projectName + "/" + name,
-				})
+				result := types.ScanResult{
+					Path:       fullPath,
+					Type:       types.TypePython,
+					Size:       size,
+					FileCount:  count,
+					Name:       projectName + "/" + name,
+					SafetyTier: pythonArtifactTier(name),
+				}
+				if venv, ok := readVenvInfo(fullPath); ok {
+					result.Name += venv.label()
+					result.Risky = venv.isRecent()
+				}
+				results = append(results, result)
 			}
 			continue // Don't recurse into artifact dirs
 		}
 
+		// Skip hidden and common non-project dirs
+		if s.skipDir(root, name) {
+			continue
+		}
+
 		// Recurse into subdirectories
-		subResults := s.findPythonArtifacts(fullPath, maxDepth-1)
+		subResults := s.findPythonArtifacts(ctx, fullPath, maxDepth-1)
 		results = append(results, subResults...)
 	}
 
 	return results
 }
 
+// pythonArtifactTier returns TierInactive for virtualenvs (reinstalling
+// packages takes a while) and TierSafe for tool caches
+func pythonArtifactTier(name string) types.SafetyTier {
+	switch name {
+	case "venv", ".venv", "env", ".env":
+		return types.TierInactive
+	}
+	return types.TierSafe
+}
+
 // isPythonArtifactDir checks if directory is a Python artifact
 func isPythonArtifactDir(name string) bool {
 	for _, artifactDir := range PythonProjectDirs {
@@ -172,3 +174,107 @@ func isPythonArtifactDir(name string) bool {
 	}
 	return false
 }
+
+// VenvRecentDays is how recently a venv's interpreter or site-packages must
+// have been modified for the venv to be considered in active use (and
+// marked Risky)
+const VenvRecentDays = 14
+
+// venvInfo describes a virtualenv as recorded in its pyvenv.cfg
+type venvInfo struct {
+	Version string    // Interpreter version, e.g. "3.12.1"
+	Home    string    // Directory of the base interpreter
+	Broken  bool      // Base interpreter no longer exists
+	ModTime time.Time // Newest of bin/python and site-packages
+}
+
+// readVenvInfo parses dir/pyvenv.cfg; ok is false if dir is not a venv
+func readVenvInfo(dir string) (venvInfo, bool) {
+	cfgPath := filepath.Join(dir, "pyvenv.cfg")
+	f, err := os.Open(cfgPath)
+	if err != nil {
+		return venvInfo{}, false
+	}
+	defer f.Close()
+
+	// pyvenv.cfg is written once at creation, so its mtime says nothing
+	// about use; installs touch site-packages and rebuilds replace bin/python
+	info := venvInfo{ModTime: venvModTime(dir)}
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		key, value, found := strings.Cut(sc.Text(), "=")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "home":
+			info.Home = value
+		case "version", "version_info":
+			// venv writes "version", uv and virtualenv write "version_info"
+			if info.Version == "" {
+				info.Version = value
+			}
+		}
+	}
+
+	if info.Home != "" {
+		if _, err := os.Stat(info.Home); err != nil {
+			info.Broken = true
+		}
+	}
+
+	return info, true
+}
+
+// venvModTime returns the newest mtime of the venv's bin/python link and
+// its site-packages directories, or the zero time if none exist
+func venvModTime(dir string) time.Time {
+	paths := []string{filepath.Join(dir, "bin", "python")}
+	sitePackages, _ := filepath.Glob(filepath.Join(dir, "lib", "python*", "site-packages"))
+	paths = append(paths, sitePackages...)
+
+	var newest time.Time
+	for _, path := range paths {
+		// Lstat: bin/python links to the base interpreter, whose mtime
+		// says nothing about this venv
+		if stat, err := os.Lstat(path); err == nil && stat.ModTime().After(newest) {
+			newest = stat.ModTime()
+		}
+	}
+	return newest
+}
+
+// label returns the display suffix for a venv, e.g. " (py3.12)"
+func (v venvInfo) label() string {
+	var parts []string
+	if v.Version != "" {
+		parts = append(parts, "py"+shortPythonVersion(v.Version))
+	}
+	if v.Broken {
+		parts = append(parts, "broken")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// isRecent reports whether the venv looks actively used. Broken venvs are
+// never considered active since their interpreter is gone.
+func (v venvInfo) isRecent() bool {
+	if v.Broken || v.ModTime.IsZero() {
+		return false
+	}
+	return time.Since(v.ModTime) < VenvRecentDays*24*time.Hour
+}
+
+// shortPythonVersion trims a version to major.minor ("3.12.1" -> "3.12")
+func shortPythonVersion(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
@@ -0,0 +1,84 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadVenvInfo(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, ok := readVenvInfo(dir); ok {
+		t.Fatal("readVenvInfo() ok for dir without pyvenv.cfg")
+	}
+
+	home := t.TempDir()
+	cfg := "home = " + home + "\ninclude-system-site-packages = false\nversion = 3.12.1\n"
+	cfgPath := filepath.Join(dir, "pyvenv.cfg")
+	if err := os.WriteFile(cfgPath, []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, ok := readVenvInfo(dir)
+	if !ok {
+		t.Fatal("readVenvInfo() not ok for venv")
+	}
+	if info.Version != "3.12.1" || info.Broken {
+		t.Errorf("readVenvInfo() = %+v, want version 3.12.1, not broken", info)
+	}
+	if got := info.label(); got != " (py3.12)" {
+		t.Errorf("label() = %q, want %q", got, " (py3.12)")
+	}
+	if info.isRecent() {
+		t.Error("isRecent() = true for venv without site-packages")
+	}
+
+	// Only site-packages counts: a fresh pyvenv.cfg alone is not use
+	sitePackages := filepath.Join(dir, "lib", "python3.12", "site-packages")
+	if err := os.MkdirAll(sitePackages, 0755); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-(VenvRecentDays + 1) * 24 * time.Hour)
+	if err := os.Chtimes(sitePackages, old, old); err != nil {
+		t.Fatal(err)
+	}
+	info, _ = readVenvInfo(dir)
+	if info.isRecent() {
+		t.Error("isRecent() = true for stale site-packages with fresh pyvenv.cfg")
+	}
+
+	if err := os.Chtimes(sitePackages, time.Now(), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(cfgPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+	info, _ = readVenvInfo(dir)
+	if !info.isRecent() {
+		t.Error("isRecent() = false for freshly modified site-packages")
+	}
+}
+
+func TestReadVenvInfoBroken(t *testing.T) {
+	dir := t.TempDir()
+	cfg := "home = " + filepath.Join(dir, "missing") + "\nversion_info = 3.8.18\n"
+	if err := os.WriteFile(filepath.Join(dir, "pyvenv.cfg"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, ok := readVenvInfo(dir)
+	if !ok {
+		t.Fatal("readVenvInfo() not ok for venv")
+	}
+	if !info.Broken {
+		t.Error("Broken = false for venv with missing interpreter")
+	}
+	if info.isRecent() {
+		t.Error("isRecent() = true for broken venv")
+	}
+	if got := info.label(); got != " (py3.8, broken)" {
+		t.Errorf("label() = %q, want %q", got, " (py3.8, broken)")
+	}
+}
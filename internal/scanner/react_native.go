@@ -1,7 +1,7 @@
 package scanner
 
 import (
-	"encoding/json"
+	"context"
 	"os"
 	"path/filepath"
 
@@ -22,8 +22,20 @@ var ReactNativeCachePaths = []CachePattern{
 	{Pattern: "react-*", Name: "React Native Temp Files"},
 }
 
-// ScanReactNative scans for React Native caches in TMPDIR
-func (s *Scanner) ScanReactNative() []types.ScanResult {
+// ScanReactNative scans for React Native caches in TMPDIR and build
+// artifacts in React Native projects
+func (s *Scanner) ScanReactNative(ctx context.Context, maxDepth int) []types.ScanResult {
+	results := s.ScanReactNativeCaches()
+
+	// Also scan project-specific builds
+	projectResults := s.ScanReactNativeProjects(ctx, maxDepth)
+	results = append(results, projectResults...)
+
+	return results
+}
+
+// ScanReactNativeCaches scans for React Native caches in TMPDIR
+func (s *Scanner) ScanReactNativeCaches() []types.ScanResult {
 	results := make([]types.ScanResult, 0)
 	tmpDir := os.TempDir()
 
@@ -47,40 +59,26 @@ func (s *Scanner) ScanReactNative() []types.ScanResult {
 			}
 
 			results = append(results, types.ScanResult{
-				Path:      match,
-				Type:      types.TypeReactNative,
-				Size:      size,
-				FileCount: count,
-				Name:      cache.Name,
+				Path:       match,
+				Type:       types.TypeReactNative,
+				Size:       size,
+				FileCount:  count,
+				Name:       cache.Name,
+				SafetyTier: types.TierSafe,
 			})
 		}
 	}
 
-	// Also scan project-specific builds
-	projectResults := s.ScanReactNativeProjects()
-	results = append(results, projectResults...)
-
 	return results
 }
 
 // isReactNativeProject checks if a directory is a React Native project
 func (s *Scanner) isReactNativeProject(path string) bool {
-	packageJSONPath := filepath.Join(path, "package.json")
-
-	data, err := os.ReadFile(packageJSONPath)
+	pkg, err := readPackageJSON(path)
 	if err != nil {
 		return false
 	}
 
-	var pkg struct {
-		Dependencies    map[string]string `json:"dependencies"`
-		DevDependencies map[string]string `json:"devDependencies"`
-	}
-
-	if err := json.Unmarshal(data, &pkg); err != nil {
-		return false
-	}
-
 	// Check for react-native dependency
 	if _, ok := pkg.Dependencies["react-native"]; ok {
 		return true
@@ -93,27 +91,18 @@ func (s *Scanner) isReactNativeProject(path string) bool {
 }
 
 // ScanReactNativeProjects scans for React Native project-specific build artifacts
-func (s *Scanner) ScanReactNativeProjects() []types.ScanResult {
+func (s *Scanner) ScanReactNativeProjects(ctx context.Context, maxDepth int) []types.ScanResult {
 	results := make([]types.ScanResult, 0)
 
 	// Search for React Native projects in common directories
-	searchDirs := []string{
-		"~/Documents",
-		"~/Projects",
-		"~/Development",
-		"~/Developer",
-		"~/Code",
-		"~/repos",
-		"~/workspace",
-	}
-
-	for _, dir := range searchDirs {
+	ctx = s.withSymlinkVisits(ctx)
+	for _, dir := range s.projectRoots() {
 		expandedDir := s.ExpandPath(dir)
 		if !s.PathExists(expandedDir) {
 			continue
 		}
 
-		projects := s.findReactNativeProjects(expandedDir, 3)
+		projects := s.findReactNativeProjects(ctx, expandedDir, maxDepth)
 		for _, projectPath := range projects {
 			projectResults := s.scanReactNativeProjectBuilds(projectPath)
 			results = append(results, projectResults...)
@@ -124,10 +113,10 @@ func (s *Scanner) ScanReactNativeProjects() []types.ScanResult {
 }
 
 // findReactNativeProjects recursively finds React Native projects
-func (s *Scanner) findReactNativeProjects(root string, maxDepth int) []string {
+func (s *Scanner) findReactNativeProjects(ctx context.Context, root string, maxDepth int) []string {
 	var projects []string
 
-	if maxDepth <= 0 {
+	if maxDepth <= 0 || ctx.Err() != nil {
 		return projects
 	}
 
@@ -137,25 +126,29 @@ func (s *Scanner) findReactNativeProjects(root string, maxDepth int) []string {
 		return projects // Don't recurse into RN project subdirectories
 	}
 
-	entries, err := os.ReadDir(root)
+	entries, err := s.readDir(root)
 	if err != nil {
 		return projects
 	}
 
 	for _, entry := range entries {
 		if !entry.IsDir() {
+			// Symlinked directories only with ScanOptions.FollowSymlinks
+			if linked := filepath.Join(root, entry.Name()); s.followSymlink(ctx, linked, entry) {
+				projects = append(projects, s.findReactNativeProjects(ctx, linked, maxDepth-1)...)
+			}
 			continue
 		}
 
 		name := entry.Name()
 
 		// Skip hidden and known non-project directories
-		if shouldSkipDir(name) {
+		if s.skipDir(root, name) {
 			continue
 		}
 
 		fullPath := filepath.Join(root, name)
-		subProjects := s.findReactNativeProjects(fullPath, maxDepth-1)
+		subProjects := s.findReactNativeProjects(ctx, fullPath, maxDepth-1)
 		projects = append(projects, subProjects...)
 	}
 
@@ -189,11 +182,12 @@ func (s *Scanner) scanReactNativeProjectBuilds(projectPath string) []types.ScanR
 		}
 
 		results = append(results, types.ScanResult{
-			Path:      buildDir.Path,
-			Type:      types.TypeReactNative,
-			Size:      size,
-			FileCount: count,
-			Name:      projectName + " - " + buildDir.Name,
+			Path:       buildDir.Path,
+			Type:       types.TypeReactNative,
+			Size:       size,
+			FileCount:  count,
+			Name:       projectName + " - " + buildDir.Name,
+			SafetyTier: types.TierInactive,
 		})
 	}
 
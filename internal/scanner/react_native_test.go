@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -16,7 +17,7 @@ func TestScanReactNative(t *testing.T) {
 
 	// Note: This test will scan actual TMPDIR
 	// In a real environment, RN caches may or may not exist
-	results := s.ScanReactNative()
+	results := s.ScanReactNative(context.Background(), types.DefaultProjectSearchDepth)
 
 	// Just verify it returns a slice (may be empty)
 	if results == nil {
@@ -0,0 +1,89 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// versionedParents are the folders whose subfolders are versions (or
+// per-project copies) of the same thing, for --keep-recent
+var versionedParents = []string{
+	"DerivedData",
+	"iOS DeviceSupport",
+	"watchOS DeviceSupport",
+	"tvOS DeviceSupport",
+}
+
+// retentionGroup returns the group a result is compared within for
+// retention, or false when it is not a versioned folder. Results are
+// grouped by parent directory, except Android system images, which are
+// versioned by API level above their tag/abi (android-34/google_apis/x86_64
+// is grouped with android-33/google_apis/x86_64).
+func retentionGroup(path string) (string, bool) {
+	if types.IsPseudoPath(path) {
+		return "", false
+	}
+
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	if n := len(parts); n >= 4 && parts[n-4] == "system-images" {
+		return strings.Join(append(parts[:n-3:n-3], parts[n-2], parts[n-1]), "/"), true
+	}
+
+	parent := filepath.Dir(path)
+	for _, name := range versionedParents {
+		if filepath.Base(parent) == name {
+			return parent, true
+		}
+	}
+	return "", false
+}
+
+// ApplyRetention implements --keep-recent: within each group of versioned
+// folders (see versionedParents) it returns the paths of all but the keep
+// most recently modified, which are the ones to select for cleaning.
+// Groups with keep or fewer members are left alone.
+func ApplyRetention(results []types.ScanResult, keep int) map[string]bool {
+	selected := make(map[string]bool)
+	if keep < 0 {
+		return selected
+	}
+
+	groups := make(map[string][]string)
+	for _, result := range results {
+		if group, ok := retentionGroup(result.Path); ok {
+			groups[group] = append(groups[group], result.Path)
+		}
+	}
+
+	for _, paths := range groups {
+		if len(paths) <= keep {
+			continue
+		}
+
+		modTimes := make(map[string]time.Time, len(paths))
+		for _, path := range paths {
+			if info, err := os.Stat(path); err == nil {
+				modTimes[path] = info.ModTime()
+			}
+		}
+		// Newest first; paths break ties so the choice is stable
+		sort.Slice(paths, func(i, j int) bool {
+			ti, tj := modTimes[paths[i]], modTimes[paths[j]]
+			if !ti.Equal(tj) {
+				return ti.After(tj)
+			}
+			return paths[i] < paths[j]
+		})
+
+		for _, path := range paths[keep:] {
+			selected[path] = true
+		}
+	}
+
+	return selected
+}
@@ -0,0 +1,57 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestApplyRetention(t *testing.T) {
+	root := t.TempDir()
+	derived := filepath.Join(root, "DerivedData")
+	images := filepath.Join(root, "sdk", "system-images")
+
+	// Paths in order of age, newest first
+	aged := []string{
+		filepath.Join(derived, "App-c"),
+		filepath.Join(derived, "App-a"),
+		filepath.Join(derived, "App-b"),
+		filepath.Join(images, "android-34", "google_apis", "x86_64"),
+		filepath.Join(images, "android-33", "google_apis", "x86_64"),
+		filepath.Join(images, "android-34", "default", "x86_64"),
+		filepath.Join(images, "android-30", "default", "x86_64"),
+	}
+	for i, path := range aged {
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		mtime := time.Now().Add(-time.Duration(i) * time.Hour)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	unversioned := filepath.Join(root, "src", "app", "node_modules")
+
+	var results []types.ScanResult
+	for _, path := range append(aged, unversioned, "docker:images") {
+		results = append(results, types.ScanResult{Path: path})
+	}
+
+	selected := ApplyRetention(results, 1)
+	want := []string{aged[1], aged[2], aged[4], aged[6]}
+	if len(selected) != len(want) {
+		t.Errorf("selected %v, want %v", selected, want)
+	}
+	for _, path := range want {
+		if !selected[path] {
+			t.Errorf("%s not selected", path)
+		}
+	}
+
+	if selected := ApplyRetention(results, 3); len(selected) != 0 {
+		t.Errorf("keep 3 selected %v, want none", selected)
+	}
+}
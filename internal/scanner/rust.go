@@ -1,9 +1,9 @@
 package scanner
 
 import (
+	"context"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
@@ -27,7 +27,7 @@ func getCargoHome() string {
 }
 
 // ScanRust scans for Rust/Cargo development artifacts
-func (s *Scanner) ScanRust(maxDepth int) []types.ScanResult {
+func (s *Scanner) ScanRust(ctx context.Context, maxDepth int) []types.ScanResult {
 	var results []types.ScanResult
 
 	cargoHome := getCargoHome()
@@ -46,38 +46,18 @@ func (s *Scanner) ScanRust(maxDepth int) []types.ScanResult {
 			continue
 		}
 
-		size, count, err := s.calculateSize(target.Path)
-		if err != nil || size == 0 {
-			continue
-		}
-
-		results = append(results, types.ScanResult{
-			Path:      target.Path,
-			Type:      types.TypeRust,
-			Size:      size,
-			FileCount: count,
-			Name:      target.Name,
-		})
+		results = append(results, s.scanCacheRoot(target.Path, target.Name, types.TypeRust)...)
 	}
 
 	// Scan for Rust projects' target directories
-	projectDirs := []string{
-		"~/Documents",
-		"~/Projects",
-		"~/Development",
-		"~/Developer",
-		"~/Code",
-		"~/repos",
-		"~/workspace",
-	}
-
-	for _, dir := range projectDirs {
+	ctx = s.withSymlinkVisits(ctx)
+	for _, dir := range s.projectRoots() {
 		expandedDir := s.ExpandPath(dir)
 		if !s.PathExists(expandedDir) {
 			continue
 		}
 
-		rustTargets := s.findRustTargets(expandedDir, maxDepth)
+		rustTargets := s.findRustTargets(ctx, expandedDir, maxDepth)
 		results = append(results, rustTargets...)
 	}
 
@@ -85,14 +65,14 @@ func (s *Scanner) ScanRust(maxDepth int) []types.ScanResult {
 }
 
 // findRustTargets recursively finds Rust target directories
-func (s *Scanner) findRustTargets(root string, maxDepth int) []types.ScanResult {
+func (s *Scanner) findRustTargets(ctx context.Context, root string, maxDepth int) []types.ScanResult {
 	var results []types.ScanResult
 
-	if maxDepth <= 0 {
+	if maxDepth <= 0 || ctx.Err() != nil {
 		return results
 	}
 
-	entries, err := os.ReadDir(root)
+	entries, err := s.readDir(root)
 	if err != nil {
 		return results
 	}
@@ -116,11 +96,12 @@ func (s *Scanner) findRustTargets(root string, maxDepth int) []types.ScanResult
 		if size > 0 {
 			projectName := filepath.Base(root)
 			results = append(results, types.ScanResult{
-				Path:      targetPath,
-				Type:      types.TypeRust,
-				Size:      size,
-				FileCount: count,
-				Name:      projectName + "/target",
+				Path:       targetPath,
+				Type:       types.TypeRust,
+				Size:       size,
+				FileCount:  count,
+				Name:       projectName + "/target",
+				SafetyTier: types.TierInactive,
 			})
 		}
 		// Don't recurse into Rust projects
@@ -130,18 +111,17 @@ func (s *Scanner) findRustTargets(root string, maxDepth int) []types.ScanResult
 	// Recurse into subdirectories
 	for _, entry := range entries {
 		if !entry.IsDir() {
+			// Symlinked directories only with ScanOptions.FollowSymlinks
+			if linked := filepath.Join(root, entry.Name()); s.followSymlink(ctx, linked, entry) {
+				results = append(results, s.findRustTargets(ctx, linked, maxDepth-1)...)
+			}
 			continue
 		}
 
 		name := entry.Name()
 
-		// Skip hidden directories
-		if strings.HasPrefix(name, ".") {
-			continue
-		}
-
-		// Skip common non-project dirs
-		if shouldSkipDir(name) {
+		// Skip hidden and common non-project dirs
+		if s.skipDir(root, name) {
 			continue
 		}
 
@@ -151,7 +131,7 @@ func (s *Scanner) findRustTargets(root string, maxDepth int) []types.ScanResult
 		}
 
 		fullPath := filepath.Join(root, name)
-		subResults := s.findRustTargets(fullPath, maxDepth-1)
+		subResults := s.findRustTargets(ctx, fullPath, maxDepth-1)
 		results = append(results, subResults...)
 	}
 
@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"path/filepath"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// SafeCacheTargets returns the fixed list of global caches that
+// `clean-cache` deletes: download caches that are refetched on demand and
+// never hold project state. The pnpm store is added by ScanSafeCaches only
+// while no project links into it.
+func (s *Scanner) SafeCacheTargets() []CustomTarget {
+	var targets []CustomTarget
+
+	for _, target := range NodeGlobalPaths {
+		targets = append(targets, CustomTarget{s.ExpandPath(target.Path), target.Name, types.TypeNode})
+	}
+
+	targets = append(targets,
+		CustomTarget{s.ExpandPath("~/Library/Caches/pip"), "pip Cache", types.TypePython},
+		CustomTarget{s.ExpandPath("~/.cache/pip"), "pip Cache", types.TypePython},
+		CustomTarget{filepath.Join(getCargoHome(), "registry"), "Cargo Registry", types.TypeRust},
+		CustomTarget{getGOCACHE(), "Go Build Cache", types.TypeGo},
+	)
+
+	for _, target := range HomebrewPaths {
+		targets = append(targets, CustomTarget{s.ExpandPath(target.Path), target.Name, types.TypeHomebrew})
+	}
+
+	return append(targets,
+		CustomTarget{filepath.Join(getGradleUserHome(), "wrapper", "dists"), "Gradle Wrapper Distributions", types.TypeJava},
+	)
+}
+
+// ScanSafeCaches scans the SafeCacheTargets, each location reported once,
+// plus any pnpm store that is not in use
+func (s *Scanner) ScanSafeCaches() []types.ScanResult {
+	var results []types.ScanResult
+
+	seen := make(map[string]bool)
+	for _, target := range s.SafeCacheTargets() {
+		path := filepath.Clean(target.Path)
+		if seen[path] || !s.PathExists(path) {
+			continue
+		}
+		seen[path] = true
+
+		results = append(results, s.scanCacheRoot(path, target.Name, target.Type)...)
+	}
+
+	for _, store := range s.scanPnpmStores(nil) {
+		if !store.Risky {
+			results = append(results, store)
+		}
+	}
+
+	return results
+}
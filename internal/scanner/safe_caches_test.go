@@ -0,0 +1,52 @@
+package scanner
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestScanSafeCaches(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GOCACHE", filepath.Join(home, "gocache"))
+	t.Setenv("CARGO_HOME", filepath.Join(home, ".cargo"))
+	t.Setenv("GRADLE_USER_HOME", filepath.Join(home, ".gradle"))
+
+	writeTestFile(t, filepath.Join(home, ".npm", "_cacache", "index"))
+	writeTestFile(t, filepath.Join(home, ".cache", "pip", "http", "blob"))
+	writeTestFile(t, filepath.Join(home, ".cargo", "registry", "cache", "crate"))
+	writeTestFile(t, filepath.Join(home, "gocache", "00", "entry"))
+	writeTestFile(t, filepath.Join(home, ".gradle", "wrapper", "dists", "gradle-8.5-bin", "gradle.zip"))
+
+	// Not on the safe list: dependency caches with project state
+	writeTestFile(t, filepath.Join(home, ".gradle", "caches", "modules-2", "dep.jar"))
+	writeTestFile(t, filepath.Join(home, ".cargo", "git", "checkouts", "repo"))
+	writeTestFile(t, filepath.Join(home, ".local", "share", "virtualenvs", "app", "pyvenv.cfg"))
+
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, result := range s.ScanSafeCaches() {
+		rel, _ := filepath.Rel(home, result.Path)
+		got[rel] = result.Name
+	}
+
+	want := map[string]string{
+		".npm":                              "npm Cache",
+		filepath.Join(".cache", "pip"):      "pip Cache",
+		filepath.Join(".cargo", "registry"): "Cargo Registry",
+		"gocache":                           "Go Build Cache",
+		filepath.Join(".gradle", "wrapper", "dists"): "Gradle Wrapper Distributions",
+	}
+	if len(got) != len(want) {
+		t.Errorf("ScanSafeCaches() = %v, want %v", got, want)
+	}
+	for path, name := range want {
+		if got[path] != name {
+			t.Errorf("ScanSafeCaches()[%s] = %q, want %q", path, got[path], name)
+		}
+	}
+}
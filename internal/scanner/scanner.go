@@ -2,191 +2,813 @@
 package scanner
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
 
 // Scanner handles scanning for development artifacts
 type Scanner struct {
-	homeDir  string
-	maxDepth int
+	homeDir string
+	deep    bool     // Expand global cache roots one level deeper
+	hidden  bool     // Also search hidden dirs directly below project roots
+	extra   []string // Additional project roots (ScanOptions.ExtraRoots)
+	roots   []string // Replaces ProjectRoots when set (SetProjectRoots)
+
+	followSymlinks bool // Project finders descend into symlinked directories
+	useDu          bool // Size directories with du -sk (ScanOptions.UseDu)
+	estimateOnly   bool // Extrapolate sizes from shallow walks (ScanOptions.EstimateOnly)
+	dockerStopped  bool // Don't ask the Docker daemon (ScanOptions.AssumeDockerStopped)
+
+	archivesOlderThan time.Duration // Only list Xcode Archives date folders older than this
+
+	customTargets []CustomTarget // From ~/.dev-cleaner.json
+	sizeProgress  SizeProgress   // Called during size walks, may be nil
+	categoryEvent CategoryEvent  // Called as categories start and finish, may be nil
+	sizeCache     *SizeCache     // Skips walks of unchanged dirs, may be nil
+
+	notesMu    sync.Mutex
+	notes      []string          // Collected during a scan for ScanReport.Notes
+	scanErrors []types.ScanError // Collected during a scan for ScanReport.Errors
+
+	dirsWalked atomic.Int64 // Directories read during a scan, for ScanReport.DirsWalked
+}
+
+// SizeProgress receives the bytes and files counted so far while a
+// directory's size is being calculated
+type SizeProgress func(bytes int64, files int)
+
+// CategoryEvent receives a category's name (e.g. "node", "gradle") when its
+// scan starts, with done false and no results, and again with done true and
+// the category's results once it finishes
+type CategoryEvent func(category string, done bool, results []types.ScanResult)
+
+// Size walks report progress every sizeProgressFiles files or
+// sizeProgressInterval, whichever comes first
+const (
+	sizeProgressFiles    = 1000
+	sizeProgressInterval = 500 * time.Millisecond
+)
+
+// ProjectRoots are the common development directories every project finder
+// searches for node_modules, target/, build/ and other project artifacts
+var ProjectRoots = []string{
+	"~/Documents",
+	"~/Projects",
+	"~/Development",
+	"~/Developer",
+	"~/Code",
+	"~/repos",
+	"~/workspace",
 }
 
-// New creates a new Scanner instance
+// New creates a new Scanner instance, loading custom targets from
+// ~/.dev-cleaner.json when it exists
 func New() (*Scanner, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return nil, err
 	}
+	config, err := loadConfig(filepath.Join(home, ConfigFileName))
+	if err != nil {
+		return nil, err
+	}
 	return &Scanner{
-		homeDir:  home,
-		maxDepth: 3,
+		homeDir:       home,
+		customTargets: config.CustomTargets,
 	}, nil
 }
 
-// SetMaxDepth sets the maximum directory depth for scanning
-func (s *Scanner) SetMaxDepth(depth int) {
-	s.maxDepth = depth
+// SetDeep enables or disables expanding global cache roots into subfolders
+func (s *Scanner) SetDeep(deep bool) {
+	s.deep = deep
+}
+
+// SetIncludeHiddenRoots enables or disables searching hidden directories
+// directly below each project root (see skipDir)
+func (s *Scanner) SetIncludeHiddenRoots(include bool) {
+	s.hidden = include
+}
+
+// SetSizeProgress sets a callback fired periodically during size walks, so
+// callers can show progress inside one huge folder (nil disables it).
+// ScanDirectory reports running totals for the whole directory; other scans
+// report per walk and may call it from several goroutines.
+func (s *Scanner) SetSizeProgress(progress SizeProgress) {
+	s.sizeProgress = progress
+}
+
+// SetCategoryEvent sets a callback fired as each category scan starts and
+// finishes, from the category's goroutine (nil disables it). Skipped
+// categories (cancelled before starting) fire neither event.
+func (s *Scanner) SetCategoryEvent(event CategoryEvent) {
+	s.categoryEvent = event
+}
+
+// EnableSizeCache loads ~/.dev-cleaner-sizecache.json, so directories whose
+// mtime is unchanged since the last scan are not walked again. Call
+// SaveSizeCache after scanning to persist new sizes.
+func (s *Scanner) EnableSizeCache() {
+	s.sizeCache = LoadSizeCache(filepath.Join(s.homeDir, SizeCacheFileName))
+}
+
+// SaveSizeCache writes the size cache back, if one is enabled
+func (s *Scanner) SaveSizeCache() error {
+	if s.sizeCache == nil {
+		return nil
+	}
+	return s.sizeCache.Save()
+}
+
+// SetExtraRoots sets additional project roots searched by every project
+// finder, e.g. a work volume outside the home directory
+func (s *Scanner) SetExtraRoots(roots []string) {
+	s.extra = roots
+}
+
+// SetProjectRoots replaces ProjectRoots (and ecosystem-specific defaults
+// like ~/IdeaProjects) for every project finder, e.g. with a t.TempDir()
+// fixture in tests. Extra roots are still appended.
+func (s *Scanner) SetProjectRoots(roots []string) {
+	s.roots = roots
+}
+
+// projectRoots returns the roots a project finder searches: ProjectRoots
+// plus the finder's own defaults, or the SetProjectRoots list, followed by
+// the extra roots
+func (s *Scanner) projectRoots(defaults ...string) []string {
+	if s.roots != nil {
+		return s.withExtraRoots(s.roots)
+	}
+	return s.withExtraRoots(append(slices.Clone(ProjectRoots), defaults...))
+}
+
+// withExtraRoots appends the extra roots to a project root list
+func (s *Scanner) withExtraRoots(dirs []string) []string {
+	if len(s.extra) == 0 {
+		return dirs
+	}
+	roots := make([]string, 0, len(dirs)+len(s.extra))
+	roots = append(roots, dirs...)
+	return append(roots, s.extra...)
+}
+
+// isProjectRoot reports whether dir is one of the roots project finders
+// start from, including ecosystem defaults like ~/IdeaProjects
+func (s *Scanner) isProjectRoot(dir string) bool {
+	for _, root := range s.projectRoots("~/IdeaProjects") {
+		if s.ExpandPath(root) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanAll scans all categories based on options. Categories that failed
+// (see types.ScanError) are returned alongside the results of the others.
+func (s *Scanner) ScanAll(opts types.ScanOptions) ([]types.ScanResult, []types.ScanError, error) {
+	return s.ScanAllContext(context.Background(), opts)
+}
+
+// ScanAllContext scans like ScanAll but stops walking project directories
+// once ctx is cancelled. On cancellation it returns the results found so far
+// together with ctx.Err().
+func (s *Scanner) ScanAllContext(ctx context.Context, opts types.ScanOptions) ([]types.ScanResult, []types.ScanError, error) {
+	report, err := s.scanAllReport(ctx, opts)
+	return report.Results, report.Errors, err
+}
+
+// ScanAllReport scans all categories like ScanAll and also records how long
+// each category took
+func (s *Scanner) ScanAllReport(opts types.ScanOptions) (types.ScanReport, error) {
+	return s.scanAllReport(context.Background(), opts)
+}
+
+// ScanAllReportContext scans like ScanAllReport but gives up once ctx is
+// done, e.g. at a --timeout deadline. It does not wait for categories stuck
+// in a size walk (a stalled network mount): it returns the results of the
+// categories that finished, lists the others in ScanReport.Unfinished and
+// returns ctx.Err().
+func (s *Scanner) ScanAllReportContext(ctx context.Context, opts types.ScanOptions) (types.ScanReport, error) {
+	return s.scanAllReport(ctx, opts)
 }
 
-// ScanAll scans all categories based on options
-func (s *Scanner) ScanAll(opts types.ScanOptions) ([]types.ScanResult, error) {
+func (s *Scanner) scanAllReport(ctx context.Context, opts types.ScanOptions) (types.ScanReport, error) {
 	var results []types.ScanResult
+	timings := make(map[string]time.Duration)
 	var mu sync.Mutex
-	var wg sync.WaitGroup
 
-	if opts.IncludeXcode {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			xcodeResults := s.ScanXcode()
+	scan := s.forScan(opts)
+	unfinished := scan.scanCategories(ctx, opts, func(category string, categoryResults []types.ScanResult, elapsed time.Duration) {
+		mu.Lock()
+		results = append(results, categoryResults...)
+		timings[category] = elapsed
+		mu.Unlock()
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	return types.ScanReport{
+		Results:    DedupeResults(results),
+		Timings:    timings,
+		Notes:      scan.takeNotes(),
+		Errors:     scan.takeErrors(),
+		DirsWalked: scan.dirsWalked.Load(),
+		Unfinished: unfinished,
+	}, ctx.Err()
+}
+
+// addNote records a note for the current scan's report; category scans
+// call it concurrently
+func (s *Scanner) addNote(note string) {
+	s.notesMu.Lock()
+	defer s.notesMu.Unlock()
+	s.notes = append(s.notes, note)
+}
+
+// takeNotes returns and clears the notes recorded so far
+func (s *Scanner) takeNotes() []string {
+	s.notesMu.Lock()
+	defer s.notesMu.Unlock()
+	notes := s.notes
+	s.notes = nil
+	return notes
+}
+
+// addError records a failed category for the current scan's report;
+// category scans call it concurrently
+func (s *Scanner) addError(category string, err error) {
+	s.notesMu.Lock()
+	defer s.notesMu.Unlock()
+	s.scanErrors = append(s.scanErrors, types.ScanError{Category: category, Message: err.Error()})
+}
+
+// takeErrors returns and clears the errors recorded so far, sorted by
+// category
+func (s *Scanner) takeErrors() []types.ScanError {
+	s.notesMu.Lock()
+	defer s.notesMu.Unlock()
+	scanErrors := s.scanErrors
+	s.scanErrors = nil
+	sort.SliceStable(scanErrors, func(i, j int) bool {
+		return scanErrors[i].Category < scanErrors[j].Category
+	})
+	return scanErrors
+}
+
+// checkProjectRoots records a ScanError for each project root that exists
+// but cannot be read, e.g. ~/Documents without Full Disk Access, which
+// every project finder would otherwise skip silently
+func (s *Scanner) checkProjectRoots() {
+	for _, dir := range s.projectRoots() {
+		root := s.ExpandPath(dir)
+		if _, err := os.ReadDir(root); err != nil && !os.IsNotExist(err) {
+			s.addError("project roots", err)
+		}
+	}
+}
+
+// ScanAllStream scans all categories like ScanAll but emits results as soon
+// as each category completes, so callers can show them progressively. The
+// channel is closed once every category is done. Streamed results are not
+// deduplicated; run DedupeResults on the collected slice.
+func (s *Scanner) ScanAllStream(opts types.ScanOptions) <-chan types.ScanResult {
+	return s.ScanAllStreamContext(context.Background(), opts)
+}
+
+// ScanAllStreamContext streams like ScanAllStream and closes the channel
+// early once ctx is cancelled
+func (s *Scanner) ScanAllStreamContext(ctx context.Context, opts types.ScanOptions) <-chan types.ScanResult {
+	stream, _ := s.ScanAllStreamReport(ctx, opts)
+	return stream
+}
+
+// ScanAllStreamReport streams like ScanAllStreamContext and also returns
+// a func giving the scan's report once the channel is closed: its errors,
+// notes and unfinished categories, but no Results (they went to the
+// channel). Until then the func returns an empty report.
+func (s *Scanner) ScanAllStreamReport(ctx context.Context, opts types.ScanOptions) (<-chan types.ScanResult, func() types.ScanReport) {
+	stream := make(chan types.ScanResult, 64)
+	var report atomic.Pointer[types.ScanReport]
+
+	go func() {
+		defer close(stream)
+		timings := make(map[string]time.Duration)
+		var mu sync.Mutex
+
+		scan := s.forScan(opts)
+		unfinished := scan.scanCategories(ctx, opts, func(category string, categoryResults []types.ScanResult, elapsed time.Duration) {
 			mu.Lock()
-			results = append(results, xcodeResults...)
+			timings[category] = elapsed
 			mu.Unlock()
-		}()
+			for _, result := range categoryResults {
+				select {
+				case stream <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+
+		mu.Lock()
+		defer mu.Unlock()
+		report.Store(&types.ScanReport{
+			Timings:    timings,
+			Notes:      scan.takeNotes(),
+			Errors:     scan.takeErrors(),
+			DirsWalked: scan.dirsWalked.Load(),
+			Unfinished: unfinished,
+		})
+	}()
+
+	return stream, func() types.ScanReport {
+		if r := report.Load(); r != nil {
+			return *r
+		}
+		return types.ScanReport{}
 	}
+}
 
-	if opts.IncludeAndroid {
+// normalizeResults clamps negative sizes and file counts, which tools
+// like docker system df can report in odd states, to 0
+func normalizeResults(results []types.ScanResult) []types.ScanResult {
+	for i := range results {
+		results[i].Size = max(results[i].Size, 0)
+		results[i].FileCount = max(results[i].FileCount, 0)
+	}
+	return results
+}
+
+// forScan returns the Scanner one scan runs on: a copy with the settings
+// of opts and its own notes, errors and directory count. Categories still
+// running after a timed-out scan returns only read the copy, so the next
+// scan or a setter like SetCategoryEvent never races with them.
+func (s *Scanner) forScan(opts types.ScanOptions) *Scanner {
+	return &Scanner{
+		homeDir:           s.homeDir,
+		deep:              opts.Deep,
+		hidden:            opts.IncludeHiddenRoots,
+		extra:             opts.ExtraRoots,
+		roots:             s.roots,
+		followSymlinks:    opts.FollowSymlinks,
+		useDu:             opts.UseDu,
+		estimateOnly:      opts.EstimateOnly,
+		dockerStopped:     opts.AssumeDockerStopped,
+		archivesOlderThan: opts.ArchivesOlderThan,
+		customTargets:     s.customTargets,
+		sizeProgress:      s.sizeProgress,
+		categoryEvent:     s.categoryEvent,
+		sizeCache:         s.sizeCache,
+	}
+}
+
+// scanCategories runs each enabled category in its own goroutine and calls
+// emit (concurrently) as each one finishes. It returns when all are done.
+// With opts.Concurrency set, at most that many categories scan at once.
+// Categories not yet started when ctx is cancelled are skipped, and the
+// recursive project finders stop at the next directory boundary.
+//
+// Once ctx is done it returns without waiting for categories that are
+// still running, never calling emit afterwards, and returns the sorted
+// names of the categories that did not finish before ctx was done.
+// Neither emit nor the category event fires after it has returned.
+//
+// s must come from forScan: late categories keep using it.
+func (s *Scanner) scanCategories(ctx context.Context, opts types.ScanOptions, emit func(category string, results []types.ScanResult, elapsed time.Duration)) []string {
+	if opts.GlobalsOnly {
+		// Depth 0 stops every find* helper before it reads a directory
+		opts.ProjectSearchDepth = 0
+	} else {
+		s.checkProjectRoots()
+	}
+	var wg sync.WaitGroup
+
+	// pending holds the categories that have not finished; after returned
+	// is set, late categories are dropped instead of emitted
+	var pendingMu sync.Mutex
+	pending := make(map[string]bool)
+	returned := false
+
+	// Limit how many categories walk the disk at once (slow or network disks)
+	var sem chan struct{}
+	if opts.Concurrency > 0 {
+		sem = make(chan struct{}, opts.Concurrency)
+	}
+
+	// hasReturned reports whether scanCategories has returned; categories
+	// finishing later fire no more events
+	hasReturned := func() bool {
+		pendingMu.Lock()
+		defer pendingMu.Unlock()
+		return returned
+	}
+
+	// run scans one category in its own goroutine and records its duration
+	run := func(category string, scan func() []types.ScanResult) {
+		pendingMu.Lock()
+		pending[category] = true
+		pendingMu.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			androidResults := s.ScanAndroid()
-			mu.Lock()
-			results = append(results, androidResults...)
-			mu.Unlock()
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					return
+				}
+			}
+			start := time.Now() // After acquiring, so waiting isn't timed
+			if hasReturned() {
+				return
+			}
+			if s.categoryEvent != nil {
+				s.categoryEvent(category, false, nil)
+			}
+			categoryResults := normalizeResults(s.excludeGlobs(scan(), opts.ExcludeGlobs))
+			protectActiveProjects(categoryResults, opts.ProtectActiveDays)
+			if opts.EstimateOnly {
+				markEstimated(categoryResults)
+			}
+			if s.categoryEvent != nil && !hasReturned() {
+				s.categoryEvent(category, true, categoryResults)
+			}
+
+			pendingMu.Lock()
+			defer pendingMu.Unlock()
+			if returned {
+				return
+			}
+			// A walk cut short by ctx still emits what it found, but
+			// the category stays unfinished
+			if ctx.Err() == nil {
+				delete(pending, category)
+			}
+			emit(category, categoryResults, time.Since(start))
 		}()
 	}
 
+	if opts.IncludeXcode {
+		run("xcode", s.ScanXcode)
+	}
+
+	if opts.IncludeAndroid {
+		run("android", s.ScanAndroid)
+	}
+
+	// Gradle is shared by Android and Java projects, scan it once for either
+	if opts.IncludeAndroid || opts.IncludeJava {
+		run("gradle", s.ScanGradle)
+	}
+
 	if opts.IncludeNode {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			nodeResults := s.ScanNode(opts.MaxDepth)
-			mu.Lock()
-			results = append(results, nodeResults...)
-			mu.Unlock()
-		}()
+		run("node", func() []types.ScanResult { return s.ScanNode(ctx, opts.ProjectSearchDepth) })
 	}
 
 	if opts.IncludeFlutter {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			flutterResults := s.ScanFlutter(opts.MaxDepth)
-			mu.Lock()
-			results = append(results, flutterResults...)
-			mu.Unlock()
-		}()
+		run("flutter", func() []types.ScanResult { return s.ScanFlutter(ctx, opts.ProjectSearchDepth) })
 	}
 
 	if opts.IncludePython {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			pythonResults := s.ScanPython(opts.MaxDepth)
-			mu.Lock()
-			results = append(results, pythonResults...)
-			mu.Unlock()
-		}()
+		run("python", func() []types.ScanResult { return s.ScanPython(ctx, opts.ProjectSearchDepth) })
 	}
 
 	if opts.IncludeRust {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			rustResults := s.ScanRust(opts.MaxDepth)
-			mu.Lock()
-			results = append(results, rustResults...)
-			mu.Unlock()
-		}()
+		run("rust", func() []types.ScanResult { return s.ScanRust(ctx, opts.ProjectSearchDepth) })
 	}
 
 	if opts.IncludeGo {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			goResults := s.ScanGo(opts.MaxDepth)
-			mu.Lock()
-			results = append(results, goResults...)
-			mu.Unlock()
-		}()
+		run("go", func() []types.ScanResult { return s.ScanGo() })
 	}
 
 	if opts.IncludeHomebrew {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			homebrewResults := s.ScanHomebrew()
-			mu.Lock()
-			results = append(results, homebrewResults...)
-			mu.Unlock()
-		}()
+		run("homebrew", s.ScanHomebrew)
 	}
 
 	if opts.IncludeDocker {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			dockerResults := s.ScanDocker()
-			mu.Lock()
-			results = append(results, dockerResults...)
-			mu.Unlock()
-		}()
+		run("docker", s.ScanDocker)
 	}
 
 	if opts.IncludeJava {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			javaResults := s.ScanJava(opts.MaxDepth)
-			mu.Lock()
-			results = append(results, javaResults...)
-			mu.Unlock()
-		}()
+		run("java", func() []types.ScanResult { return s.ScanJava(ctx, opts.ProjectSearchDepth) })
 	}
 
 	if opts.IncludeReactNative {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			rnResults := s.ScanReactNative()
-			mu.Lock()
-			results = append(results, rnResults...)
-			mu.Unlock()
-		}()
+		run("react-native", func() []types.ScanResult {
+			if opts.GlobalsOnly {
+				return s.ScanReactNativeCaches()
+			}
+			return s.ScanReactNative(ctx, opts.ProjectSearchDepth)
+		})
 	}
 
-	wg.Wait()
-	return results, nil
+	if opts.IncludeDeno {
+		run("deno", s.ScanDeno)
+	}
+
+	if opts.IncludeDotNet {
+		run("dotnet", func() []types.ScanResult { return s.ScanDotNet(ctx, opts.ProjectSearchDepth) })
+	}
+
+	if opts.IncludePHP {
+		run("php", func() []types.ScanResult { return s.ScanPHP(ctx, opts.ProjectSearchDepth) })
+	}
+
+	if opts.IncludeElixir {
+		run("elixir", func() []types.ScanResult { return s.ScanElixir(ctx, opts.ProjectSearchDepth) })
+	}
+
+	if opts.IncludeHaskell {
+		run("haskell", func() []types.ScanResult { return s.ScanHaskell(ctx, opts.ProjectSearchDepth) })
+	}
+
+	if opts.IncludeJetBrains {
+		run("jetbrains", s.ScanJetBrains)
+	}
+
+	if len(s.customTargets) > 0 {
+		run("custom", func() []types.ScanResult { return s.ScanCustom(opts) })
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	returned = true
+	var unfinished []string
+	for category := range pending {
+		unfinished = append(unfinished, category)
+	}
+	sort.Strings(unfinished)
+	return unfinished
+}
+
+// DedupeResults removes exact duplicate paths and results nested inside
+// another result (e.g. a project's .gradle under a scanned parent), so
+// totals don't double-count and deleting a parent leaves no phantom
+// children behind. The outermost path wins; original order is preserved.
+func DedupeResults(results []types.ScanResult) []types.ScanResult {
+	order := make([]int, len(results))
+	for i := range results {
+		order[i] = i
+	}
+	// Sorting by path (with the separator ordered first, so "proj/x"
+	// sorts before "proj-other") puts every parent directly before its
+	// descendants
+	sortKey := func(path string) string {
+		return strings.ReplaceAll(path, string(filepath.Separator), "\x00")
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return sortKey(results[order[a]].Path) < sortKey(results[order[b]].Path)
+	})
+
+	drop := make(map[int]bool)
+	var parents []string
+	for _, idx := range order {
+		path := filepath.Clean(results[idx].Path)
+
+		// Pseudo-paths (docker:..., simctl:...) can only be exact duplicates
+		if types.IsPseudoPath(results[idx].Path) {
+			path = results[idx].Path
+		}
+
+		for len(parents) > 0 {
+			parent := parents[len(parents)-1]
+			if path == parent || strings.HasPrefix(path, parent+string(filepath.Separator)) {
+				break
+			}
+			parents = parents[:len(parents)-1]
+		}
+		if len(parents) > 0 {
+			drop[idx] = true
+			continue
+		}
+		parents = append(parents, path)
+	}
+
+	if len(drop) == 0 {
+		return results
+	}
+
+	deduped := make([]types.ScanResult, 0, len(results)-len(drop))
+	for i, result := range results {
+		if !drop[i] {
+			deduped = append(deduped, result)
+		}
+	}
+	return deduped
 }
 
-// calculateSize calculates the total size of a directory
+// calculateSize calculates the total size of a directory, reporting to the
+// scanner's SizeProgress callback if one is set (or with du, see
+// measureSize). With a size cache enabled, a directory whose mtime matches
+// its cache entry is not walked. Estimates (ScanOptions.EstimateOnly) and
+// du sizes, which are disk usage with no file count, are never cached so
+// they can't stand in for a walk on a later scan.
 func (s *Scanner) calculateSize(path string) (int64, int, error) {
+	if s.sizeCache == nil || s.estimateOnly || s.useDu {
+		return s.measureSize(path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return s.measureSize(path)
+	}
+	if size, count, ok := s.sizeCache.lookup(path, info.ModTime()); ok {
+		return size, count, nil
+	}
+
+	size, count, err := s.measureSize(path)
+	if err == nil {
+		s.sizeCache.store(path, info.ModTime(), size, count)
+	}
+	return size, count, err
+}
+
+// calculateSizeProgress calculates the total size of a directory, calling
+// progress (if non-nil) every sizeProgressFiles files or sizeProgressInterval
+func (s *Scanner) calculateSizeProgress(path string, progress SizeProgress) (int64, int, error) {
 	var size int64
 	var count int
+	var dirs int64
+	lastReport := time.Now()
 
 	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return nil // Skip errors, continue
 		}
-		if !d.IsDir() {
+		if d.IsDir() {
+			dirs++
+		} else {
 			info, err := d.Info()
 			if err == nil {
 				size += info.Size()
 				count++
 			}
+			if progress != nil && (count%sizeProgressFiles == 0 || time.Since(lastReport) >= sizeProgressInterval) {
+				progress(size, count)
+				lastReport = time.Now()
+			}
 		}
 		return nil
 	})
+	s.dirsWalked.Add(dirs)
 
 	return size, count, err
 }
 
+// dirTotal is the size and file count of everything below a directory
+type dirTotal struct {
+	size  int64
+	files int
+}
+
+// childTotals walks root once, summing the files below each of its direct
+// children (keyed by name) instead of walking every child separately.
+// progress, if non-nil, gets running totals for all of root.
+func (s *Scanner) childTotals(root string, progress SizeProgress) map[string]dirTotal {
+	totals := make(map[string]dirTotal)
+	var size int64
+	var count int
+	var dirs int64
+	lastReport := time.Now()
+
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == root {
+			return nil // Skip errors, continue
+		}
+		if d.IsDir() {
+			dirs++
+			return nil
+		}
+
+		// The first element of path below root is the child it belongs to
+		child := strings.TrimPrefix(path[len(root):], string(filepath.Separator))
+		if i := strings.IndexByte(child, filepath.Separator); i >= 0 {
+			child = child[:i]
+		}
+		info, err := d.Info()
+		if err == nil {
+			total := totals[child]
+			total.size += info.Size()
+			total.files++
+			totals[child] = total
+			size += info.Size()
+			count++
+		}
+		if progress != nil && (count%sizeProgressFiles == 0 || time.Since(lastReport) >= sizeProgressInterval) {
+			progress(size, count)
+			lastReport = time.Now()
+		}
+		return nil
+	})
+	s.dirsWalked.Add(dirs)
+
+	return totals
+}
+
+// readDir lists a directory for a project finder, counting it towards
+// ScanReport.DirsWalked
+func (s *Scanner) readDir(dir string) ([]os.DirEntry, error) {
+	s.dirsWalked.Add(1)
+	return os.ReadDir(dir)
+}
+
+// LargestFile returns the largest regular file under root, looking at no
+// more than limit files so a huge folder cannot stall the caller. complete
+// is false when the limit cut the walk short.
+func LargestFile(root string, limit int) (path string, size int64, complete bool) {
+	var seen int
+	complete = true
+	filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil // Skip inaccessible entries
+		}
+		if seen >= limit {
+			complete = false
+			return filepath.SkipAll
+		}
+		seen++
+		if info, err := d.Info(); err == nil && info.Mode().IsRegular() && info.Size() > size {
+			path, size = p, info.Size()
+		}
+		return nil
+	})
+	return path, size, complete
+}
+
+// scanCacheRoot returns the result(s) for a global cache directory.
+// In deep mode each direct subfolder becomes its own result (like the
+// per-project DerivedData folders in ScanXcode), so e.g. ~/.npm/_cacache
+// can be removed while ~/.npm/_logs is kept. Loose files at the root are
+// never included in deep mode. Falls back to the whole root when it has
+// no sized subfolders.
+func (s *Scanner) scanCacheRoot(path, name string, cacheType types.CleanTargetType) []types.ScanResult {
+	var results []types.ScanResult
+
+	if s.deep {
+		entries, err := os.ReadDir(path)
+		if err == nil {
+			for _, entry := range entries {
+				if !entry.IsDir() {
+					continue
+				}
+				subPath := filepath.Join(path, entry.Name())
+				size, count, _ := s.calculateSize(subPath)
+				if size > 0 {
+					results = append(results, types.ScanResult{
+						Path:       subPath,
+						Type:       cacheType,
+						Size:       size,
+						FileCount:  count,
+						Name:       name + "/" + entry.Name(),
+						SafetyTier: types.TierSafe,
+					})
+				}
+			}
+		}
+		if len(results) > 0 {
+			return results
+		}
+	}
+
+	size, count, err := s.calculateSize(path)
+	if err != nil || size == 0 {
+		return results
+	}
+
+	return append(results, types.ScanResult{
+		Path:       path,
+		Type:       cacheType,
+		Size:       size,
+		FileCount:  count,
+		Name:       name,
+		SafetyTier: types.TierSafe,
+	})
+}
+
 // ExpandPath expands ~ to home directory
 func (s *Scanner) ExpandPath(path string) string {
 	if len(path) > 0 && path[0] == '~' {
@@ -203,6 +825,12 @@ func (s *Scanner) PathExists(path string) bool {
 
 // ScanDirectory scans a single directory lazily and returns TreeNode with children
 func (s *Scanner) ScanDirectory(path string, currentDepth int, maxDepth int) (*types.TreeNode, error) {
+	return s.ScanDirectoryTyped(path, currentDepth, maxDepth, "")
+}
+
+// ScanDirectoryTyped scans like ScanDirectory and tags the node and its
+// children with cleanType, inherited from the scan result being browsed
+func (s *Scanner) ScanDirectoryTyped(path string, currentDepth int, maxDepth int, cleanType types.CleanTargetType) (*types.TreeNode, error) {
 	// Depth limit check
 	if currentDepth >= maxDepth {
 		return nil, fmt.Errorf("max depth %d reached", maxDepth)
@@ -214,21 +842,20 @@ func (s *Scanner) ScanDirectory(path string, currentDepth int, maxDepth int) (*t
 		return nil, fmt.Errorf("failed to read directory %s: %w", path, err)
 	}
 
-	// Calculate total size
-	totalSize, fileCount, _ := s.calculateSize(path)
-
-	// Build TreeNode
+	// Build TreeNode; its size is the sum of its children, which are all
+	// sized by one walk of the folder
 	node := &types.TreeNode{
-		Path:      path,
-		Name:      types.GetBasename(path),
-		Size:      totalSize,
-		IsDir:     true,
-		Children:  make([]*types.TreeNode, 0),
-		Scanned:   true,
-		Depth:     currentDepth,
-		FileCount: fileCount,
+		Path:     path,
+		Name:     types.GetBasename(path),
+		IsDir:    true,
+		Type:     cleanType,
+		Children: make([]*types.TreeNode, 0),
+		Scanned:  true,
+		Depth:    currentDepth,
 	}
 
+	totals := s.childTotals(path, s.sizeProgress)
+
 	// Process children
 	for _, entry := range entries {
 		childPath := filepath.Join(path, entry.Name())
@@ -247,8 +874,8 @@ func (s *Scanner) ScanDirectory(path string, currentDepth int, maxDepth int) (*t
 		var childFileCount int
 
 		if isDir {
-			// For directories, calculate size
-			childSize, childFileCount, _ = s.calculateSize(childPath)
+			// For directories, the subtotal from the walk above
+			childSize, childFileCount = totals[entry.Name()].size, totals[entry.Name()].files
 		} else {
 			// For files, use file size
 			childSize = info.Size()
@@ -260,17 +887,87 @@ func (s *Scanner) ScanDirectory(path string, currentDepth int, maxDepth int) (*t
 			Name:      entry.Name(),
 			Size:      childSize,
 			IsDir:     isDir,
+			Type:      cleanType,
 			Scanned:   false, // Lazy - not scanned yet
 			Depth:     currentDepth + 1,
 			FileCount: childFileCount,
 		}
 
 		node.AddChild(child)
+		node.Size += childSize
+		node.FileCount += childFileCount
 	}
 
 	return node, nil
 }
 
+// ScanTree scans path like ScanDirectory and then every subfolder, down to
+// maxDepth levels below path, for exporting the whole hierarchy
+func (s *Scanner) ScanTree(path string, maxDepth int) (*types.TreeNode, error) {
+	node, err := s.ScanDirectory(path, 0, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	s.expandTree(node, maxDepth)
+	return node, nil
+}
+
+// expandTree scans node's folders recursively until maxDepth
+func (s *Scanner) expandTree(node *types.TreeNode, maxDepth int) {
+	for _, child := range node.Children {
+		if !child.IsDir || child.Depth >= maxDepth {
+			continue
+		}
+		scanned, err := s.ScanDirectory(child.Path, child.Depth, maxDepth)
+		if err != nil {
+			continue // Unreadable folder: keep its size, without children
+		}
+		child.Children = scanned.Children
+		child.Scanned = true
+		s.expandTree(child, maxDepth)
+	}
+}
+
+// Analyze sizes every folder down to depth levels below root, whatever it
+// holds, and returns them largest first as TypeGeneric results named by
+// their path below root. Folders nest, so with depth > 1 a parent's size
+// includes its listed children.
+func (s *Scanner) Analyze(root string, depth int) ([]types.ScanResult, error) {
+	tree, err := s.ScanTree(root, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []types.ScanResult
+	var collect func(node *types.TreeNode)
+	collect = func(node *types.TreeNode) {
+		for _, child := range node.Children {
+			if !child.IsDir || child.Size == 0 {
+				continue
+			}
+			name, err := filepath.Rel(root, child.Path)
+			if err != nil {
+				name = child.Name
+			}
+			results = append(results, types.ScanResult{
+				Path:       child.Path,
+				Type:       types.TypeGeneric,
+				Size:       child.Size,
+				FileCount:  child.FileCount,
+				Name:       name,
+				SafetyTier: types.TierReview,
+			})
+			collect(child)
+		}
+	}
+	collect(tree)
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Size > results[j].Size
+	})
+	return results, nil
+}
+
 // ScanResultToTreeNode converts ScanResult to initial TreeNode
 func (s *Scanner) ScanResultToTreeNode(result types.ScanResult) (*types.TreeNode, error) {
 	node := types.ScanResultToTreeNode(result)
@@ -1,9 +1,19 @@
 package scanner
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
 
 func TestNew(t *testing.T) {
@@ -126,3 +136,649 @@ func TestCalculateSize(t *testing.T) {
 		})
 	}
 }
+
+func TestScanCacheRootDeep(t *testing.T) {
+	s, _ := New()
+
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "_cacache"), 0755)
+	os.MkdirAll(filepath.Join(root, "_logs"), 0755)
+	os.MkdirAll(filepath.Join(root, "empty"), 0755)
+	os.WriteFile(filepath.Join(root, "_cacache", "blob"), make([]byte, 300), 0644)
+	os.WriteFile(filepath.Join(root, "_logs", "debug.log"), make([]byte, 20), 0644)
+	os.WriteFile(filepath.Join(root, "config.json"), make([]byte, 10), 0644)
+
+	// Default: single result for the whole root
+	results := s.scanCacheRoot(root, "npm Cache", types.TypeNode)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Size != 330 {
+		t.Errorf("size = %d, want 330", results[0].Size)
+	}
+
+	// Deep: one result per non-empty subfolder, loose files excluded
+	s.SetDeep(true)
+	results = s.scanCacheRoot(root, "npm Cache", types.TypeNode)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	names := map[string]int64{}
+	for _, r := range results {
+		names[r.Name] = r.Size
+	}
+	if names["npm Cache/_cacache"] != 300 {
+		t.Errorf("_cacache size = %d, want 300", names["npm Cache/_cacache"])
+	}
+	if names["npm Cache/_logs"] != 20 {
+		t.Errorf("_logs size = %d, want 20", names["npm Cache/_logs"])
+	}
+}
+
+func TestDedupeResults(t *testing.T) {
+	results := []types.ScanResult{
+		{Path: "/home/u/proj/android/.gradle", Type: types.TypeReactNative, Size: 10},
+		{Path: "/home/u/proj", Type: types.TypeJava, Size: 100},
+		{Path: "/home/u/proj-other/target", Type: types.TypeRust, Size: 50},
+		{Path: "/home/u/proj", Type: types.TypeNode, Size: 100},
+		{Path: "docker:images", Type: types.TypeDocker, Size: 5},
+		{Path: "docker:images", Type: types.TypeDocker, Size: 5},
+	}
+
+	got := DedupeResults(results)
+
+	want := []string{"/home/u/proj", "/home/u/proj-other/target", "docker:images"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d results, want %d: %v", len(got), len(want), got)
+	}
+	for i, path := range want {
+		if got[i].Path != path {
+			t.Errorf("result %d = %s, want %s", i, got[i].Path, path)
+		}
+	}
+	// First occurrence of an exact duplicate is kept
+	if got[0].Type != types.TypeJava {
+		t.Errorf("expected first occurrence to be kept, got type %s", got[0].Type)
+	}
+}
+
+func TestWithExtraRoots(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	dirs := []string{"~/Projects"}
+	if got := s.withExtraRoots(dirs); len(got) != 1 {
+		t.Errorf("withExtraRoots() = %v, want only default roots", got)
+	}
+
+	s.SetExtraRoots([]string{"/Volumes/Work"})
+	got := s.withExtraRoots(dirs)
+	if len(got) != 2 || got[1] != "/Volumes/Work" {
+		t.Errorf("withExtraRoots() = %v, want default and extra roots", got)
+	}
+	if len(dirs) != 1 {
+		t.Error("withExtraRoots() modified its input")
+	}
+}
+
+func TestScanAllReportTimings(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	report, err := s.ScanAllReport(types.ScanOptions{IncludeHomebrew: true, IncludeJava: true, ProjectSearchDepth: 1})
+	if err != nil {
+		t.Fatalf("ScanAllReport() error = %v", err)
+	}
+
+	for _, category := range []string{"homebrew", "java", "gradle"} {
+		if _, ok := report.Timings[category]; !ok {
+			t.Errorf("Timings missing %q: %v", category, report.Timings)
+		}
+	}
+	if _, ok := report.Timings["node"]; ok {
+		t.Error("Timings has entry for disabled category node")
+	}
+}
+
+func TestScanAllReportDirsWalked(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "web", "package.json"))
+	writeTestFile(t, filepath.Join(root, "web", "node_modules", "react", "cjs", "index.js"))
+
+	opts := types.ScanOptions{IncludeNode: true, ProjectSearchDepth: 3}
+	first, err := s.ScanAllReport(opts)
+	if err != nil {
+		t.Fatalf("ScanAllReport() error = %v", err)
+	}
+	// At least the project root, web/ and node_modules/react/cjs
+	if first.DirsWalked < 5 {
+		t.Errorf("DirsWalked = %d, want >= 5", first.DirsWalked)
+	}
+
+	// The counter restarts with every scan
+	second, err := s.ScanAllReport(opts)
+	if err != nil {
+		t.Fatalf("ScanAllReport() error = %v", err)
+	}
+	if second.DirsWalked != first.DirsWalked {
+		t.Errorf("second scan DirsWalked = %d, want %d", second.DirsWalked, first.DirsWalked)
+	}
+}
+
+func TestScanAllCategoryEvents(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "web", "package.json"))
+	writeTestFile(t, filepath.Join(root, "web", "node_modules", "react", "index.js"))
+
+	var mu sync.Mutex
+	var started []string
+	completed := make(map[string]int)
+	s.SetCategoryEvent(func(category string, done bool, results []types.ScanResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !done {
+			started = append(started, category)
+			return
+		}
+		if !slices.Contains(started, category) {
+			t.Errorf("%s completed before it started", category)
+		}
+		completed[category] = len(results)
+	})
+
+	if _, _, err := s.ScanAll(types.ScanOptions{IncludeNode: true, IncludeRust: true, ProjectSearchDepth: 3}); err != nil {
+		t.Fatalf("ScanAll() error = %v", err)
+	}
+
+	slices.Sort(started)
+	if want := []string{"node", "rust"}; !slices.Equal(started, want) {
+		t.Errorf("started = %v, want %v", started, want)
+	}
+	if _, ok := completed["rust"]; !ok || completed["node"] != 1 {
+		t.Errorf("completed = %v, want node with 1 result and rust", completed)
+	}
+}
+
+func TestScanAllReportContextTimeout(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "web", "package.json"))
+	writeTestFile(t, filepath.Join(root, "web", "node_modules", "react", "index.js"))
+
+	// Rust stands in for a category stuck on a stalled mount
+	stalled := make(chan struct{})
+	defer close(stalled)
+	s.SetCategoryEvent(func(category string, done bool, _ []types.ScanResult) {
+		if category == "rust" && !done {
+			<-stalled
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	report, err := s.ScanAllReportContext(ctx, types.ScanOptions{IncludeNode: true, IncludeRust: true, ProjectSearchDepth: 3})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ScanAllReportContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if !slices.Equal(report.Unfinished, []string{"rust"}) {
+		t.Errorf("Unfinished = %v, want [rust]", report.Unfinished)
+	}
+	assertPaths(t, resultPaths(t, root, report.Results), filepath.Join("web", "node_modules"))
+}
+
+func TestScanAllStreamReportTimeout(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "web", "package.json"))
+	writeTestFile(t, filepath.Join(root, "web", "node_modules", "react", "index.js"))
+
+	stalled := make(chan struct{})
+	defer close(stalled)
+	s.SetCategoryEvent(func(category string, done bool, _ []types.ScanResult) {
+		if category == "rust" && !done {
+			<-stalled
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	stream, report := s.ScanAllStreamReport(ctx, types.ScanOptions{IncludeNode: true, IncludeRust: true, ProjectSearchDepth: 3})
+	for range stream {
+	}
+	if unfinished := report().Unfinished; !slices.Equal(unfinished, []string{"rust"}) {
+		t.Errorf("Unfinished = %v, want [rust]", unfinished)
+	}
+}
+
+// TestScanAllTimeoutThenRescan changes the scanner while a timed-out
+// category is still running; go test -race flags any shared state
+func TestScanAllTimeoutThenRescan(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "web", "package.json"))
+	writeTestFile(t, filepath.Join(root, "web", "node_modules", "react", "index.js"))
+
+	stalled := make(chan struct{})
+	var afterReturn atomic.Bool
+	var lateEvents atomic.Int32
+	s.SetCategoryEvent(func(category string, done bool, _ []types.ScanResult) {
+		if category == "rust" && !done {
+			<-stalled
+			return
+		}
+		if afterReturn.Load() {
+			lateEvents.Add(1)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	opts := types.ScanOptions{IncludeNode: true, IncludeRust: true, ProjectSearchDepth: 3}
+	if _, err := s.ScanAllReportContext(ctx, opts); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ScanAllReportContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	afterReturn.Store(true)
+
+	// Rust is still stuck: reconfigure and rescan with other settings
+	s.SetCategoryEvent(nil)
+	report, err := s.ScanAllReport(types.ScanOptions{IncludeNode: true, UseDu: true, Deep: true, ProjectSearchDepth: 3})
+	if err != nil {
+		t.Fatalf("ScanAllReport() error = %v", err)
+	}
+	assertPaths(t, resultPaths(t, root, report.Results), filepath.Join("web", "node_modules"))
+
+	// Once unblocked, the stale category finishes without an event
+	close(stalled)
+	time.Sleep(100 * time.Millisecond)
+	if n := lateEvents.Load(); n != 0 {
+		t.Errorf("%d category events fired after the timed-out scan returned", n)
+	}
+}
+
+func TestScanAllStreamMatchesScanAll(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// No categories enabled: the stream must close without results
+	var count int
+	for range s.ScanAllStream(types.ScanOptions{}) {
+		count++
+	}
+	if count != 0 {
+		t.Errorf("ScanAllStream() with no categories emitted %d results", count)
+	}
+
+	opts := types.ScanOptions{IncludeHomebrew: true, IncludeGo: true, ProjectSearchDepth: 1}
+	var streamed []types.ScanResult
+	for result := range s.ScanAllStream(opts) {
+		streamed = append(streamed, result)
+	}
+	all, _, err := s.ScanAll(opts)
+	if err != nil {
+		t.Fatalf("ScanAll() error = %v", err)
+	}
+	if got := DedupeResults(streamed); len(got) != len(all) {
+		t.Errorf("deduped stream has %d results, ScanAll has %d", len(got), len(all))
+	}
+}
+
+func TestScanDirectoryTyped(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	node, err := s.ScanDirectoryTyped(dir, 0, 5, types.TypePython)
+	if err != nil {
+		t.Fatalf("ScanDirectoryTyped() error = %v", err)
+	}
+	if node.Type != types.TypePython {
+		t.Errorf("node.Type = %q, want %q", node.Type, types.TypePython)
+	}
+	if len(node.Children) != 2 {
+		t.Fatalf("len(Children) = %d, want 2", len(node.Children))
+	}
+	for _, child := range node.Children {
+		if child.Type != types.TypePython {
+			t.Errorf("child %s Type = %q, want %q", child.Name, child.Type, types.TypePython)
+		}
+	}
+}
+
+func TestScanDirectoryChildSizes(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	for path, size := range map[string]int{
+		"a/x":          10,
+		"a/deep/b/c/y": 20,
+		"ab/z":         5,
+		"top":          7,
+	} {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	node, err := s.ScanDirectory(dir, 0, 5)
+	if err != nil {
+		t.Fatalf("ScanDirectory() error = %v", err)
+	}
+	want := map[string][2]int64{"a": {30, 2}, "ab": {5, 1}, "top": {7, 1}}
+	for _, child := range node.Children {
+		if got := [2]int64{child.Size, int64(child.FileCount)}; got != want[child.Name] {
+			t.Errorf("child %s = %v (size, files), want %v", child.Name, got, want[child.Name])
+		}
+	}
+	if node.Size != 42 || node.FileCount != 4 {
+		t.Errorf("node = %d bytes, %d files; want 42, 4", node.Size, node.FileCount)
+	}
+}
+
+func TestScanTree(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "a", "b", "c", "f"))
+	writeTestFile(t, filepath.Join(dir, "a", "g"))
+
+	node, err := s.ScanTree(dir, 2)
+	if err != nil {
+		t.Fatalf("ScanTree() error = %v", err)
+	}
+	if len(node.Children) != 1 || node.Size != 2 {
+		t.Fatalf("root: %d children, %d bytes; want 1, 2", len(node.Children), node.Size)
+	}
+	a := node.Children[0]
+	if !a.Scanned || len(a.Children) != 2 {
+		t.Fatalf("a: scanned %v, %d children; want expanded with 2", a.Scanned, len(a.Children))
+	}
+	for _, child := range a.Children {
+		// Depth 2 is the limit: b keeps its size but is not expanded
+		if child.Name == "b" && (child.Scanned || child.Children != nil || child.Size != 1) {
+			t.Errorf("b = %+v; want size 1, not expanded", child)
+		}
+	}
+}
+
+func TestScanCancelled(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	root := t.TempDir()
+	cache := filepath.Join(root, "app", "__pycache__")
+	if err := os.MkdirAll(cache, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cache, "main.pyc"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := s.findPythonArtifacts(context.Background(), root, 3); len(got) != 1 {
+		t.Fatalf("findPythonArtifacts() = %d results, want 1", len(got))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if got := s.findPythonArtifacts(ctx, root, 3); len(got) != 0 {
+		t.Errorf("findPythonArtifacts() with cancelled ctx = %d results, want 0", len(got))
+	}
+
+	results, _, err := s.ScanAllContext(ctx, types.ScanOptions{IncludeHomebrew: true, ProjectSearchDepth: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ScanAllContext() error = %v, want context.Canceled", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("ScanAllContext() with cancelled ctx = %d results, want 0", len(results))
+	}
+
+	for range s.ScanAllStreamContext(ctx, types.ScanOptions{IncludeHomebrew: true}) {
+		t.Error("ScanAllStreamContext() with cancelled ctx emitted a result")
+	}
+}
+
+func TestScanGlobalsOnly(t *testing.T) {
+	home := t.TempDir()
+	cargoHome := filepath.Join(home, ".cargo")
+	t.Setenv("CARGO_HOME", cargoHome)
+
+	if err := os.MkdirAll(filepath.Join(cargoHome, "registry"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cargoHome, "registry", "index"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	project := filepath.Join(home, "Projects", "app")
+	if err := os.MkdirAll(filepath.Join(project, "target"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "Cargo.toml"), []byte("[package]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "target", "app"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Scanner{homeDir: home}
+	opts := types.ScanOptions{IncludeRust: true, ProjectSearchDepth: 3}
+
+	all, _, err := s.ScanAll(opts)
+	if err != nil {
+		t.Fatalf("ScanAll() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("ScanAll() = %d results, want registry and target", len(all))
+	}
+
+	opts.GlobalsOnly = true
+	globals, _, err := s.ScanAll(opts)
+	if err != nil {
+		t.Fatalf("ScanAll() error = %v", err)
+	}
+	if len(globals) != 1 || globals[0].Name != "Cargo Registry" {
+		t.Errorf("ScanAll() with GlobalsOnly = %+v, want only Cargo Registry", globals)
+	}
+}
+
+func TestScanConcurrencyLimit(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	opts := types.ScanOptions{IncludeHomebrew: true, IncludeGo: true, IncludeJava: true, ProjectSearchDepth: 1}
+	all, err := s.ScanAllReport(opts)
+	if err != nil {
+		t.Fatalf("ScanAllReport() error = %v", err)
+	}
+
+	opts.Concurrency = 1
+	serial, err := s.ScanAllReport(opts)
+	if err != nil {
+		t.Fatalf("ScanAllReport() serial error = %v", err)
+	}
+	if len(serial.Results) != len(all.Results) || len(serial.Timings) != len(all.Timings) {
+		t.Errorf("serial scan = %d results/%d timings, want %d/%d",
+			len(serial.Results), len(serial.Timings), len(all.Results), len(all.Timings))
+	}
+
+	// Waiting categories must give up on cancellation instead of blocking
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := s.ScanAllContext(ctx, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("ScanAllContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestScanDirectorySizeProgress(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	for _, sub := range []string{"a", "b"} {
+		for i := 0; i < sizeProgressFiles; i++ {
+			writeTestFile(t, filepath.Join(dir, sub, fmt.Sprintf("f%d", i)))
+		}
+	}
+
+	var calls int
+	var lastBytes int64
+	var lastFiles int
+	s.SetSizeProgress(func(bytes int64, files int) {
+		if bytes < lastBytes || files < lastFiles {
+			t.Errorf("progress went backwards: %d/%d after %d/%d", bytes, files, lastBytes, lastFiles)
+		}
+		calls++
+		lastBytes, lastFiles = bytes, files
+	})
+
+	node, err := s.ScanDirectory(dir, 0, 5)
+	if err != nil {
+		t.Fatalf("ScanDirectory() error = %v", err)
+	}
+	if calls < 2 {
+		t.Errorf("progress called %d times, want at least 2", calls)
+	}
+	// Totals across both children, not per child walk
+	if lastFiles != 2*sizeProgressFiles || node.FileCount != 2*sizeProgressFiles {
+		t.Errorf("last progress = %d files, node = %d files; want %d", lastFiles, node.FileCount, 2*sizeProgressFiles)
+	}
+	if node.Size != lastBytes {
+		t.Errorf("node.Size = %d, want %d", node.Size, lastBytes)
+	}
+}
+
+func TestLargestFile(t *testing.T) {
+	root := t.TempDir()
+	for name, size := range map[string]int{"small.txt": 10, "runtime/sim.dmg": 500, "runtime/other": 20} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path, size, complete := LargestFile(root, 100)
+	if path != filepath.Join(root, "runtime", "sim.dmg") || size != 500 || !complete {
+		t.Errorf("LargestFile() = %s, %d, %v; want sim.dmg, 500, true", path, size, complete)
+	}
+
+	if _, _, complete := LargestFile(root, 2); complete {
+		t.Error("LargestFile() with limit 2 of 3 files reported complete")
+	}
+}
+
+func TestNormalizeResults(t *testing.T) {
+	results := normalizeResults([]types.ScanResult{
+		{Path: "docker:images", Size: 100, FileCount: -2},
+		{Path: "/a", Size: -1, FileCount: 3},
+	})
+	if results[0].FileCount != 0 || results[0].Size != 100 {
+		t.Errorf("normalizeResults() = %+v, want file count clamped to 0", results[0])
+	}
+	if results[1].Size != 0 || results[1].FileCount != 3 {
+		t.Errorf("normalizeResults() = %+v, want size clamped to 0", results[1])
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	writeTestFile(t, filepath.Join(root, "small", "a"))
+	writeTestFile(t, filepath.Join(root, "big", "a"))
+	writeTestFile(t, filepath.Join(root, "big", "inner", "b"))
+	writeTestFile(t, filepath.Join(root, "big", "inner", "c"))
+	writeTestFile(t, filepath.Join(root, "loose-file"))
+	if err := os.Mkdir(filepath.Join(root, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := s.Analyze(root, 1)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	var names []string
+	for _, r := range results {
+		if r.Type != types.TypeGeneric {
+			t.Errorf("result %s has type %s, want generic", r.Name, r.Type)
+		}
+		names = append(names, fmt.Sprintf("%s=%d", r.Name, r.Size))
+	}
+	if want := []string{"big=3", "small=1"}; !slices.Equal(names, want) {
+		t.Errorf("Analyze(depth 1) = %v, want %v", names, want)
+	}
+
+	results, err = s.Analyze(root, 2)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	names = nil
+	for _, r := range results {
+		names = append(names, fmt.Sprintf("%s=%d", r.Name, r.Size))
+	}
+	if want := []string{"big=3", "big/inner=2", "small=1"}; !slices.Equal(names, want) {
+		t.Errorf("Analyze(depth 2) = %v, want %v", names, want)
+	}
+}
+
+func TestScanAllReportsUnreadableRoots(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	notDir := filepath.Join(root, "not-a-dir")
+	writeTestFile(t, notDir)
+	s.SetProjectRoots([]string{notDir, filepath.Join(root, "missing")})
+
+	_, scanErrors, err := s.ScanAll(types.ScanOptions{IncludeNode: true, ProjectSearchDepth: 2})
+	if err != nil {
+		t.Fatalf("ScanAll() error = %v", err)
+	}
+	if len(scanErrors) != 1 || scanErrors[0].Category != "project roots" || !strings.Contains(scanErrors[0].Message, notDir) {
+		t.Errorf("ScanAll() errors = %+v, want one for %s (missing roots are fine)", scanErrors, notDir)
+	}
+
+	// Reported once per scan, and not with --globals-only
+	_, scanErrors, _ = s.ScanAll(types.ScanOptions{IncludeNode: true, GlobalsOnly: true})
+	if len(scanErrors) != 0 {
+		t.Errorf("ScanAll(GlobalsOnly) errors = %+v, want none", scanErrors)
+	}
+}
+
+func TestScanAllStreamReportErrors(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	notDir := filepath.Join(root, "not-a-dir")
+	writeTestFile(t, notDir)
+	s.SetProjectRoots([]string{notDir})
+
+	stream, report := s.ScanAllStreamReport(context.Background(), types.ScanOptions{IncludeNode: true, ProjectSearchDepth: 2})
+	for range stream {
+	}
+	if scanErrors := report().Errors; len(scanErrors) != 1 || scanErrors[0].Category != "project roots" {
+		t.Errorf("streamed report errors = %+v, want one for project roots", scanErrors)
+	}
+}
@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SizeCacheFileName is the size cache file, kept in the home directory
+const SizeCacheFileName = ".dev-cleaner-sizecache.json"
+
+// SizeCacheTTL is how long a cached size is trusted. A directory's mtime
+// only changes when its direct children change, so edits deeper down go
+// unnoticed until the entry expires (or --no-cache is used).
+const SizeCacheTTL = 24 * time.Hour
+
+// sizeCacheEntry is the cached size of one path
+type sizeCacheEntry struct {
+	ModTime   time.Time `json:"modTime"` // mtime of the path when it was sized
+	Size      int64     `json:"size"`
+	FileCount int       `json:"fileCount"`
+	CachedAt  time.Time `json:"cachedAt"`
+}
+
+// SizeCache remembers directory sizes between scans, keyed by path. It is
+// safe for concurrent use by the category scans.
+type SizeCache struct {
+	mu      sync.Mutex
+	path    string
+	ttl     time.Duration
+	entries map[string]sizeCacheEntry
+}
+
+// LoadSizeCache reads the size cache at path. A missing or unreadable
+// file gives an empty cache: it is only an optimization.
+func LoadSizeCache(path string) *SizeCache {
+	c := &SizeCache{
+		path:    path,
+		ttl:     SizeCacheTTL,
+		entries: make(map[string]sizeCacheEntry),
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &c.entries); err != nil || c.entries == nil {
+			c.entries = make(map[string]sizeCacheEntry)
+		}
+	}
+	return c
+}
+
+// lookup returns the cached size of path if its mtime is unchanged and the
+// entry has not expired
+func (c *SizeCache) lookup(path string, modTime time.Time) (int64, int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[path]
+	if !ok || !entry.ModTime.Equal(modTime) || time.Since(entry.CachedAt) > c.ttl {
+		return 0, 0, false
+	}
+	return entry.Size, entry.FileCount, true
+}
+
+// store records the size of path as of modTime
+func (c *SizeCache) store(path string, modTime time.Time, size int64, count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[path] = sizeCacheEntry{ModTime: modTime, Size: size, FileCount: count, CachedAt: time.Now()}
+}
+
+// Save writes the cache back to its file, dropping expired entries. The
+// file is replaced atomically so concurrent runs never read half of it.
+func (c *SizeCache) Save() error {
+	c.mu.Lock()
+	for path, entry := range c.entries {
+		if time.Since(entry.CachedAt) > c.ttl {
+			delete(c.entries, path)
+		}
+	}
+	data, err := json.Marshal(c.entries)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), SizeCacheFileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -0,0 +1,102 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSizeCacheLookup(t *testing.T) {
+	cache := LoadSizeCache(filepath.Join(t.TempDir(), SizeCacheFileName))
+	modTime := time.Now().Truncate(time.Second)
+
+	cache.store("/p", modTime, 100, 3)
+	if size, count, ok := cache.lookup("/p", modTime); !ok || size != 100 || count != 3 {
+		t.Errorf("lookup = %d, %d, %v; want 100, 3, true", size, count, ok)
+	}
+	if _, _, ok := cache.lookup("/p", modTime.Add(time.Second)); ok {
+		t.Error("lookup hit after mtime changed")
+	}
+
+	cache.ttl = 0
+	if _, _, ok := cache.lookup("/p", modTime); ok {
+		t.Error("lookup hit after entry expired")
+	}
+}
+
+func TestSizeCacheSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SizeCacheFileName)
+	modTime := time.Now().Truncate(time.Second)
+
+	cache := LoadSizeCache(path)
+	cache.store("/p", modTime, 42, 1)
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if size, _, ok := LoadSizeCache(path).lookup("/p", modTime); !ok || size != 42 {
+		t.Errorf("reloaded lookup = %d, %v; want 42, true", size, ok)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := LoadSizeCache(path).lookup("/p", modTime); ok {
+		t.Error("corrupt cache file should load as empty")
+	}
+}
+
+func TestCalculateSizeUsesCache(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	dir := filepath.Join(root, "node_modules")
+	writeTestFile(t, filepath.Join(dir, "a.js"))
+	s.EnableSizeCache()
+
+	size, _, err := s.calculateSize(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Growing a file keeps the directory mtime, so the cached size is used
+	if err := os.WriteFile(filepath.Join(dir, "a.js"), make([]byte, 4096), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cached, _, _ := s.calculateSize(dir); cached != size {
+		t.Errorf("cached size = %d, want %d", cached, size)
+	}
+
+	// Adding an entry changes the mtime and forces a new walk
+	writeTestFile(t, filepath.Join(dir, "b.js"))
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(dir, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if fresh, _, _ := s.calculateSize(dir); fresh <= size {
+		t.Errorf("size after change = %d, want more than %d", fresh, size)
+	}
+}
+
+func TestCalculateSizeDuNotCached(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	dir := filepath.Join(root, "node_modules")
+	writeTestFile(t, filepath.Join(dir, "a.js"))
+	s.EnableSizeCache()
+
+	origDu := duCommand
+	defer func() { duCommand = origDu }()
+	duCommand = func(path string) ([]byte, error) {
+		return []byte("8\t" + path + "\n"), nil
+	}
+
+	s.useDu = true
+	if size, count, _ := s.calculateSize(dir); size != 8*1024 || count != 0 {
+		t.Fatalf("with du: %d bytes, %d files; want %d, 0", size, count, 8*1024)
+	}
+
+	// A later walk-mode scan must not reuse du's size and missing count
+	s.useDu = false
+	if size, count, _ := s.calculateSize(dir); size != 1 || count != 1 {
+		t.Errorf("without du: %d bytes, %d files; want 1, 1", size, count)
+	}
+}
@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// symlinkVisitsKey is the context key of a walk's symlinkVisits
+type symlinkVisitsKey struct{}
+
+// symlinkVisits holds the real paths of directories a project walk already
+// covers: its roots and every symlink target it entered. A link into one of
+// them is a cycle or a duplicate and is not followed.
+type symlinkVisits struct {
+	mu   sync.Mutex
+	seen []string
+}
+
+// withSymlinkVisits starts a visited set for one category's project walk
+// when following symlinks is enabled, seeded with the project roots
+func (s *Scanner) withSymlinkVisits(ctx context.Context) context.Context {
+	if !s.followSymlinks {
+		return ctx
+	}
+	visits := &symlinkVisits{}
+	for _, dir := range s.projectRoots() {
+		if real, err := filepath.EvalSymlinks(s.ExpandPath(dir)); err == nil {
+			visits.seen = append(visits.seen, real)
+		}
+	}
+	return context.WithValue(ctx, symlinkVisitsKey{}, visits)
+}
+
+// followSymlink reports whether entry (at path) is a symlink to a directory
+// the walk in ctx should descend into: following is enabled, the link is
+// not a skipped name, and its target is outside everything already visited
+func (s *Scanner) followSymlink(ctx context.Context, path string, entry os.DirEntry) bool {
+	if entry.Type()&os.ModeSymlink == 0 || shouldSkipDir(entry.Name()) {
+		return false
+	}
+	visits, ok := ctx.Value(symlinkVisitsKey{}).(*symlinkVisits)
+	if !ok {
+		return false
+	}
+
+	real, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false // Dangling link
+	}
+	if info, err := os.Stat(real); err != nil || !info.IsDir() {
+		return false
+	}
+	return visits.visit(real)
+}
+
+// visit records real and reports whether it was new, i.e. not equal to or
+// below a directory visited before
+func (v *symlinkVisits) visit(real string) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, seen := range v.seen {
+		if real == seen || strings.HasPrefix(real, seen+string(filepath.Separator)) {
+			return false
+		}
+	}
+	v.seen = append(v.seen, real)
+	return true
+}
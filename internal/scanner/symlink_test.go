@@ -0,0 +1,37 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanNodeFollowSymlinks(t *testing.T) {
+	s, root := newFixtureScanner(t)
+	external := filepath.Join(t.TempDir(), "Work")
+	writeTestFile(t, filepath.Join(external, "app", "package.json"))
+	writeTestFile(t, filepath.Join(external, "app", "node_modules", "react", "index.js"))
+	if err := os.MkdirAll(root, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for link, target := range map[string]string{
+		filepath.Join(root, "work"):      external, // Stitched-in project tree
+		filepath.Join(root, "work-copy"): external, // Same tree again
+		filepath.Join(root, "self"):      root,     // Loop back to the root
+		filepath.Join(external, "loop"):  external, // Loop inside the target
+	} {
+		if err := os.Symlink(target, link); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := resultPaths(t, root, s.ScanNode(context.Background(), 6)); len(got) != 0 {
+		t.Errorf("symlinks followed while disabled: %v", got)
+	}
+
+	s.followSymlinks = true
+	got := resultPaths(t, root, s.ScanNode(context.Background(), 6))
+	assertPaths(t, got, "work/app/node_modules")
+}
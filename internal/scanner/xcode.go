@@ -1,8 +1,12 @@
 package scanner
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"time"
 
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
@@ -11,12 +15,12 @@ import (
 var XcodePaths = []struct {
 	Path string
 	Name string
+	Tier types.SafetyTier
 }{
-	{"~/Library/Developer/Xcode/DerivedData", "Xcode DerivedData"},
-	{"~/Library/Developer/Xcode/Archives", "Xcode Archives"},
-	{"~/Library/Caches/com.apple.dt.Xcode", "Xcode Caches"},
-	{"~/Library/Developer/CoreSimulator/Caches", "Simulator Caches"},
-	{"~/Library/Caches/CocoaPods", "CocoaPods Cache"},
+	{"~/Library/Developer/Xcode/DerivedData", "Xcode DerivedData", types.TierSafe},
+	{"~/Library/Caches/com.apple.dt.Xcode", "Xcode Caches", types.TierSafe},
+	{"~/Library/Developer/CoreSimulator/Caches", "Simulator Caches", types.TierSafe},
+	{"~/Library/Caches/CocoaPods", "CocoaPods Cache", types.TierSafe},
 }
 
 // ScanXcode scans for Xcode/iOS development artifacts
@@ -35,11 +39,12 @@ func (s *Scanner) ScanXcode() []types.ScanResult {
 		}
 
 		results = append(results, types.ScanResult{
-			Path:      path,
-			Type:      types.TypeXcode,
-			Size:      size,
-			FileCount: count,
-			Name:      target.Name,
+			Path:       path,
+			Type:       types.TypeXcode,
+			Size:       size,
+			FileCount:  count,
+			Name:       target.Name,
+			SafetyTier: target.Tier,
 		})
 	}
 
@@ -54,11 +59,12 @@ func (s *Scanner) ScanXcode() []types.ScanResult {
 					size, count, _ := s.calculateSize(subPath)
 					if size > 0 {
 						results = append(results, types.ScanResult{
-							Path:      subPath,
-							Type:      types.TypeXcode,
-							Size:      size,
-							FileCount: count,
-							Name:      "DerivedData/" + entry.Name(),
+							Path:       subPath,
+							Type:       types.TypeXcode,
+							Size:       size,
+							FileCount:  count,
+							Name:       "DerivedData/" + entry.Name(),
+							SafetyTier: types.TierSafe,
 						})
 					}
 				}
@@ -66,5 +72,147 @@ func (s *Scanner) ScanXcode() []types.ScanResult {
 		}
 	}
 
+	// Device support files, one folder per OS version of a connected device;
+	// Xcode copies them again the next time that device is attached
+	for _, platform := range []string{"iOS", "watchOS", "tvOS"} {
+		supportPath := s.ExpandPath("~/Library/Developer/Xcode/" + platform + " DeviceSupport")
+		entries, err := os.ReadDir(supportPath)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			subPath := filepath.Join(supportPath, entry.Name())
+			size, count, _ := s.calculateSize(subPath)
+			if size > 0 {
+				results = append(results, types.ScanResult{
+					Path:       subPath,
+					Type:       types.TypeXcode,
+					Size:       size,
+					FileCount:  count,
+					Name:       platform + " DeviceSupport/" + entry.Name(),
+					SafetyTier: types.TierSafe,
+				})
+			}
+		}
+	}
+
+	results = append(results, s.scanArchives()...)
+	results = append(results, s.scanUnavailableSimulators()...)
+
+	return results
+}
+
+// xcodeArchivesPath holds one folder per day (e.g. 2024-05-31) with the
+// .xcarchive bundles built that day
+const xcodeArchivesPath = "~/Library/Developer/Xcode/Archives"
+
+// archiveDateLayout is the name format of an Archives date folder
+const archiveDateLayout = "2006-01-02"
+
+// scanArchives lists each Archives date folder separately, so old builds
+// can go while recent ones stay for symbolicating crash reports. With
+// ScanOptions.ArchivesOlderThan set, only folders dated before that age are
+// listed; folders without a date in their name never are.
+func (s *Scanner) scanArchives() []types.ScanResult {
+	archivesPath := s.ExpandPath(xcodeArchivesPath)
+	entries, err := os.ReadDir(archivesPath)
+	if err != nil {
+		return nil
+	}
+	cutoff := time.Now().Add(-s.archivesOlderThan)
+
+	var results []types.ScanResult
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if s.archivesOlderThan > 0 {
+			date, err := time.ParseInLocation(archiveDateLayout, entry.Name(), time.Local)
+			if err != nil || !date.Before(cutoff) {
+				continue
+			}
+		}
+
+		subPath := filepath.Join(archivesPath, entry.Name())
+		size, count, _ := s.calculateSize(subPath)
+		if size > 0 {
+			results = append(results, types.ScanResult{
+				Path:       subPath,
+				Type:       types.TypeXcode,
+				Size:       size,
+				FileCount:  count,
+				Name:       "Xcode Archives/" + entry.Name(),
+				SafetyTier: types.TierReview, // Signed builds and dSYMs
+			})
+		}
+	}
 	return results
 }
+
+// UnavailableSimulatorsPath is the pseudo-path of the unavailable simulator
+// devices result; the cleaner runs `xcrun simctl delete unavailable` for it
+const UnavailableSimulatorsPath = types.SimctlPathPrefix + "unavailable"
+
+// simctlDevices is the part of `xcrun simctl list devices -j` we read
+type simctlDevices struct {
+	Devices map[string][]struct {
+		UDID     string `json:"udid"`
+		Name     string `json:"name"`
+		DataPath string `json:"dataPath"`
+	} `json:"devices"`
+}
+
+// simctlListUnavailable lists simulator devices whose runtime is no longer
+// installed (replaced in tests)
+var simctlListUnavailable = func() ([]byte, error) {
+	return exec.Command("xcrun", "simctl", "list", "devices", "unavailable", "-j").Output()
+}
+
+// scanUnavailableSimulators reports the devices simctl marks unavailable
+// (their iOS runtime was removed) as one result. They are left to simctl
+// to delete so its device set stays consistent.
+func (s *Scanner) scanUnavailableSimulators() []types.ScanResult {
+	if _, err := lookPath("xcrun"); err != nil {
+		return nil
+	}
+	output, err := simctlListUnavailable()
+	if err != nil {
+		return nil
+	}
+
+	var list simctlDevices
+	if err := json.Unmarshal(output, &list); err != nil {
+		return nil
+	}
+
+	devicesDir := s.ExpandPath("~/Library/Developer/CoreSimulator/Devices")
+	var size int64
+	var count, devices int
+	for _, runtimeDevices := range list.Devices {
+		for _, device := range runtimeDevices {
+			dir := filepath.Join(devicesDir, device.UDID)
+			if device.DataPath != "" {
+				dir = filepath.Dir(device.DataPath)
+			}
+			deviceSize, deviceCount, _ := s.calculateSize(dir)
+			size += deviceSize
+			count += deviceCount
+			devices++
+		}
+	}
+	if devices == 0 || size == 0 {
+		return nil
+	}
+
+	return []types.ScanResult{{
+		Path:       UnavailableSimulatorsPath,
+		Type:       types.TypeXcode,
+		Size:       size,
+		FileCount:  count,
+		Name:       fmt.Sprintf("Unavailable Simulators (%d devices)", devices),
+		SafetyTier: types.TierSafe,
+	}}
+}
@@ -0,0 +1,72 @@
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScanUnavailableSimulators(t *testing.T) {
+	origLookPath, origList := lookPath, simctlListUnavailable
+	defer func() { lookPath, simctlListUnavailable = origLookPath, origList }()
+
+	s, _ := newFixtureScanner(t)
+	devices := filepath.Join(s.homeDir, "Library", "Developer", "CoreSimulator", "Devices")
+	writeTestFile(t, filepath.Join(devices, "AAAA", "data", "Library", "app.db"))
+	writeTestFile(t, filepath.Join(devices, "BBBB", "device.plist"))
+
+	lookPath = func(string) (string, error) { return "/usr/bin/xcrun", nil }
+	simctlListUnavailable = func() ([]byte, error) {
+		return []byte(fmt.Sprintf(`{"devices": {
+			"com.apple.CoreSimulator.SimRuntime.iOS-15-0": [
+				{"udid": "AAAA", "name": "iPhone 13", "dataPath": %q},
+				{"udid": "BBBB", "name": "iPad Air"}
+			],
+			"com.apple.CoreSimulator.SimRuntime.iOS-17-0": []
+		}}`, filepath.Join(devices, "AAAA", "data"))), nil
+	}
+
+	results := s.scanUnavailableSimulators()
+	if len(results) != 1 {
+		t.Fatalf("scanUnavailableSimulators() returned %d results, want 1", len(results))
+	}
+	got := results[0]
+	if got.Path != UnavailableSimulatorsPath || got.Name != "Unavailable Simulators (2 devices)" || got.FileCount != 2 {
+		t.Errorf("scanUnavailableSimulators() = %+v", got)
+	}
+
+	// Nothing unavailable, nothing reported
+	simctlListUnavailable = func() ([]byte, error) { return []byte(`{"devices": {}}`), nil }
+	if results := s.scanUnavailableSimulators(); len(results) != 0 {
+		t.Errorf("scanUnavailableSimulators() = %v, want none", results)
+	}
+}
+
+func TestScanArchives(t *testing.T) {
+	s, _ := newFixtureScanner(t)
+	archives := filepath.Join(s.homeDir, "Library", "Developer", "Xcode", "Archives")
+	old := time.Now().AddDate(-1, 0, 0).Format(archiveDateLayout)
+	recent := time.Now().AddDate(0, 0, -3).Format(archiveDateLayout)
+	for _, day := range []string{old, recent, "Imported"} {
+		writeTestFile(t, filepath.Join(archives, day, "App.xcarchive", "Info.plist"))
+	}
+
+	names := func() map[string]bool {
+		got := make(map[string]bool)
+		for _, result := range s.scanArchives() {
+			got[result.Name] = true
+		}
+		return got
+	}
+
+	if got := names(); len(got) != 3 || !got["Xcode Archives/"+old] || !got["Xcode Archives/Imported"] {
+		t.Errorf("all archives = %v, want the 3 folders", got)
+	}
+
+	// Only dated folders older than 180 days
+	s.archivesOlderThan = 180 * 24 * time.Hour
+	if got := names(); len(got) != 1 || !got["Xcode Archives/"+old] {
+		t.Errorf("archives older than 180d = %v, want only %s", got, old)
+	}
+}
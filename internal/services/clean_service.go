@@ -65,20 +65,14 @@ func (c *CleanService) Clean(items []types.ScanResult) ([]cleaner.CleanResult, e
 		return results, err
 	}
 
-	// Calculate freed space
-	var freedSpace int64
-	successCount := 0
-	for _, r := range results {
-		if r.Success {
-			freedSpace += r.Size
-			successCount++
-		}
-	}
+	// Calculate freed space (includes space freed by partially failed items)
+	successCount, freedSpace, notFreed := cleaner.SummarizeResults(results)
 
 	if c.ctx != nil {
 		runtime.EventsEmit(c.ctx, "clean:complete", map[string]interface{}{
 			"results":      results,
 			"freedSpace":   freedSpace,
+			"notFreed":     notFreed,
 			"successCount": successCount,
 		})
 	}
@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"sync"
 
@@ -16,6 +18,8 @@ type ScanService struct {
 	scanner  *scanner.Scanner
 	results  []types.ScanResult
 	scanning bool
+	cancel   context.CancelFunc // Cancels the in-progress scan, nil when idle
+	settings *SettingsService   // Source of the saved ExcludePaths, nil for none
 	mu       sync.RWMutex
 }
 
@@ -35,20 +39,46 @@ func (s *ScanService) SetContext(ctx context.Context) {
 	s.ctx = ctx
 }
 
+// SetSettings makes scans skip the ExcludePaths saved in settings, read
+// again at the start of every scan so edits apply to the next one
+func (s *ScanService) SetSettings(settings *SettingsService) {
+	s.settings = settings
+}
+
 // Scan performs full scan with events
 func (s *ScanService) Scan(opts types.ScanOptions) error {
+	return s.scan(opts, false)
+}
+
+// ScanStream scans like Scan and also emits scan:category:started and
+// scan:category:complete as each category begins and finishes, so the GUI
+// can show a live per-ecosystem checklist. Category results are not
+// deduplicated; scan:complete still carries the final list.
+func (s *ScanService) ScanStream(opts types.ScanOptions) error {
+	return s.scan(opts, true)
+}
+
+func (s *ScanService) scan(opts types.ScanOptions, perCategory bool) error {
 	s.mu.Lock()
 	if s.scanning {
 		s.mu.Unlock()
 		return fmt.Errorf("scan already in progress")
 	}
+	parent := s.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	scanCtx, cancel := context.WithCancel(parent)
 	s.scanning = true
+	s.cancel = cancel
 	s.mu.Unlock()
 
 	defer func() {
 		s.mu.Lock()
 		s.scanning = false
+		s.cancel = nil
 		s.mu.Unlock()
+		cancel()
 	}()
 
 	// Emit start event
@@ -56,8 +86,24 @@ func (s *ScanService) Scan(opts types.ScanOptions) error {
 		runtime.EventsEmit(s.ctx, "scan:started")
 	}
 
+	if perCategory && s.ctx != nil {
+		s.scanner.SetCategoryEvent(s.emitCategoryEvent)
+		defer s.scanner.SetCategoryEvent(nil)
+	}
+
+	if s.settings != nil {
+		opts.ExcludeGlobs = slices.Concat(opts.ExcludeGlobs, s.settings.ExcludeGlobs())
+	}
+
 	// Perform scan
-	results, err := s.scanner.ScanAll(opts)
+	results, scanErrors, err := s.scanner.ScanAllContext(scanCtx, opts)
+	if errors.Is(err, context.Canceled) {
+		fmt.Printf("🛑 Scan cancelled after %d results\n", len(results))
+		if s.ctx != nil {
+			runtime.EventsEmit(s.ctx, "scan:cancelled")
+		}
+		return err
+	}
 	if err != nil {
 		fmt.Printf("❌ Scan error: %v\n", err)
 		if s.ctx != nil {
@@ -91,6 +137,14 @@ func (s *ScanService) Scan(opts types.ScanOptions) error {
 	s.results = results
 	s.mu.Unlock()
 
+	// Categories that failed, so the GUI can tell them from empty ones
+	for _, scanErr := range scanErrors {
+		fmt.Printf("⚠️  Scan error: %v\n", scanErr)
+	}
+	if len(scanErrors) > 0 && s.ctx != nil {
+		runtime.EventsEmit(s.ctx, "scan:errors", scanErrors)
+	}
+
 	// Emit complete event
 	fmt.Printf("📡 Emitting scan:complete event with %d results\n", len(results))
 	if s.ctx != nil {
@@ -99,6 +153,30 @@ func (s *ScanService) Scan(opts types.ScanOptions) error {
 	return nil
 }
 
+// emitCategoryEvent forwards a scanner category event to the frontend
+func (s *ScanService) emitCategoryEvent(category string, done bool, results []types.ScanResult) {
+	if !done {
+		runtime.EventsEmit(s.ctx, "scan:category:started", map[string]interface{}{
+			"category": category,
+		})
+		return
+	}
+	runtime.EventsEmit(s.ctx, "scan:category:complete", map[string]interface{}{
+		"category": category,
+		"results":  results,
+	})
+}
+
+// Cancel stops the in-progress scan, if any. The cached results from the
+// previous scan are kept.
+func (s *ScanService) Cancel() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
 // GetResults returns cached results
 func (s *ScanService) GetResults() []types.ScanResult {
 	s.mu.RLock()
@@ -106,6 +184,22 @@ func (s *ScanService) GetResults() []types.ScanResult {
 	return s.results
 }
 
+// GetSummary returns totals and per-type sizes for the cached results
+func (s *ScanService) GetSummary() types.ScanSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	summary := types.ScanSummary{
+		Count:  len(s.results),
+		ByType: make(map[types.CleanTargetType]int64),
+	}
+	for _, result := range s.results {
+		summary.TotalSize += result.Size
+		summary.ByType[result.Type] += result.Size
+	}
+	return summary
+}
+
 // IsScanning returns scan status
 func (s *ScanService) IsScanning() bool {
 	s.mu.RLock()
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -194,3 +196,57 @@ func TestEmptyResults(t *testing.T) {
 
 	assert.Empty(t, dedupedResults, "Empty results should remain empty after deduplication")
 }
+
+// TestGetSummary tests totals and per-type aggregation of cached results
+func TestGetSummary(t *testing.T) {
+	service, err := NewScanService()
+	require.NoError(t, err)
+
+	service.results = []types.ScanResult{
+		{Path: "/a", Size: 1000, Type: types.TypeNode},
+		{Path: "/b", Size: 2000, Type: types.TypeXcode},
+		{Path: "/c", Size: 500, Type: types.TypeNode},
+	}
+
+	summary := service.GetSummary()
+	assert.Equal(t, 3, summary.Count, "Count should match number of results")
+	assert.Equal(t, int64(3500), summary.TotalSize, "TotalSize should sum all results")
+	assert.Equal(t, int64(1500), summary.ByType[types.TypeNode], "Node sizes should be summed")
+	assert.Equal(t, int64(2000), summary.ByType[types.TypeXcode], "Xcode size should match")
+	assert.Len(t, summary.ByType, 2, "Only present types should appear")
+}
+
+// TestCancelIdle tests that cancelling with no scan in progress is a no-op
+func TestCancelIdle(t *testing.T) {
+	service, err := NewScanService()
+	require.NoError(t, err)
+
+	assert.NotPanics(t, service.Cancel, "Cancel should be safe when idle")
+	assert.False(t, service.IsScanning(), "Should not be scanning after Cancel")
+}
+
+// TestScanSkipsSettingsExcludePaths tests that saved ExcludePaths are
+// applied to GUI scans, not only the CLI
+func TestScanSkipsSettingsExcludePaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := filepath.Join(home, "src")
+	for _, project := range []string{"keep", "skip"} {
+		file := filepath.Join(root, project, "node_modules", "react", "index.js")
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, os.WriteFile(file, []byte("x"), 0644))
+	}
+
+	service, err := NewScanService()
+	require.NoError(t, err)
+	service.scanner.SetProjectRoots([]string{root})
+	service.SetSettings(&SettingsService{
+		path:     filepath.Join(home, "settings.json"),
+		settings: Settings{ExcludePaths: []string{filepath.Join(root, "skip")}},
+	})
+
+	require.NoError(t, service.Scan(types.ScanOptions{IncludeNode: true, ProjectSearchDepth: 4}))
+	results := service.GetResults()
+	require.Len(t, results, 1, "Excluded project should not be listed")
+	assert.Equal(t, filepath.Join(root, "keep", "node_modules"), results[0].Path)
+}
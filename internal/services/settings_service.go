@@ -4,22 +4,29 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
+
+	"github.com/thanhdevapp/dev-cleaner/internal/scanner"
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
 
 type Settings struct {
-	Theme          string   `json:"theme"`          // "light" | "dark" | "auto"
-	DefaultView    string   `json:"defaultView"`    // "list" | "treemap" | "split"
-	AutoScan       bool     `json:"autoScan"`       // Scan on launch
-	ConfirmDelete  bool     `json:"confirmDelete"`  // Show confirm dialog
-	ScanCategories []string `json:"scanCategories"` // ["xcode", "android", "node"]
-	MaxDepth       int      `json:"maxDepth"`       // Tree depth limit
-	CheckAutoUpdate bool    `json:"checkAutoUpdate"` // Check for updates on startup
+	Theme              string   `json:"theme"`              // "light" | "dark" | "auto"
+	DefaultView        string   `json:"defaultView"`        // "list" | "treemap" | "split"
+	AutoScan           bool     `json:"autoScan"`           // Scan on launch
+	ConfirmDelete      bool     `json:"confirmDelete"`      // Show confirm dialog
+	ScanCategories     []string `json:"scanCategories"`     // ["xcode", "android", "node"]
+	MaxDepth           int      `json:"maxDepth"`           // Tree depth limit
+	ProjectSearchDepth int      `json:"projectSearchDepth"` // Levels searched below each project root
+	CheckAutoUpdate    bool     `json:"checkAutoUpdate"`    // Check for updates on startup
+	ExcludePaths       []string `json:"excludePaths"`       // Never listed by scans, nor anything below
 }
 
 type SettingsService struct {
 	settings Settings
 	path     string
+	loaded   bool // Settings came from the file rather than defaults
 	mu       sync.RWMutex
 }
 
@@ -42,18 +49,31 @@ func (s *SettingsService) Load() error {
 	if err != nil {
 		// Set defaults
 		s.settings = Settings{
-			Theme:           "auto",
-			DefaultView:     "split",
-			AutoScan:        true,
-			ConfirmDelete:   true,
-			ScanCategories:  []string{"xcode", "android", "node"},
-			MaxDepth:        5,
-			CheckAutoUpdate: true,
+			Theme:              "auto",
+			DefaultView:        "split",
+			AutoScan:           true,
+			ConfirmDelete:      true,
+			ScanCategories:     []string{"xcode", "android", "node"},
+			MaxDepth:           5,
+			ProjectSearchDepth: types.DefaultProjectSearchDepth,
+			CheckAutoUpdate:    true,
 		}
 		return nil
 	}
 
-	return json.Unmarshal(data, &s.settings)
+	if err := json.Unmarshal(data, &s.settings); err != nil {
+		return err
+	}
+	s.loaded = true
+	return nil
+}
+
+// Loaded reports whether settings were read from the settings file. The CLI
+// only honors ScanCategories when they were explicitly saved.
+func (s *SettingsService) Loaded() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.loaded
 }
 
 func (s *SettingsService) Save() error {
@@ -70,9 +90,40 @@ func (s *SettingsService) Get() Settings {
 	return s.settings
 }
 
+// ExcludeGlobs returns ExcludePaths as patterns matching just those paths
+// (and anything below), for ScanOptions.ExcludeGlobs
+func (s *SettingsService) ExcludeGlobs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var patterns []string
+	for _, path := range s.settings.ExcludePaths {
+		patterns = append(patterns, scanner.LiteralGlob(path))
+	}
+	return patterns
+}
+
+// AddExcludePaths appends paths not yet in ExcludePaths and saves the
+// settings file. When there was no file yet, the default ScanCategories
+// aren't written, so the CLI keeps scanning all categories.
+func (s *SettingsService) AddExcludePaths(paths ...string) error {
+	s.mu.Lock()
+	if !s.loaded {
+		s.settings.ScanCategories = nil
+	}
+	for _, path := range paths {
+		if !slices.Contains(s.settings.ExcludePaths, path) {
+			s.settings.ExcludePaths = append(s.settings.ExcludePaths, path)
+		}
+	}
+	s.loaded = true
+	s.mu.Unlock()
+	return s.Save()
+}
+
 func (s *SettingsService) Update(settings Settings) error {
 	s.mu.Lock()
 	s.settings = settings
+	s.loaded = true
 	s.mu.Unlock()
 	return s.Save()
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
 
 // TestNewSettingsService tests SettingsService initialization
@@ -136,6 +137,7 @@ func TestSettingsLoadNonExistentFile(t *testing.T) {
 	assert.True(t, settings.AutoScan, "Should use default AutoScan")
 	assert.True(t, settings.ConfirmDelete, "Should use default ConfirmDelete")
 	assert.True(t, settings.CheckAutoUpdate, "Should use default CheckAutoUpdate")
+	assert.Equal(t, types.DefaultProjectSearchDepth, settings.ProjectSearchDepth, "Should use default ProjectSearchDepth")
 }
 
 // TestSettingsJSONMarshaling tests JSON marshaling/unmarshaling
@@ -189,3 +191,39 @@ func TestSettingsConcurrentAccess(t *testing.T) {
 
 	assert.True(t, true, "Concurrent reads should complete without panic")
 }
+
+// TestSettingsLoaded tests that Loaded distinguishes saved settings from defaults
+func TestSettingsLoaded(t *testing.T) {
+	tmpDir := t.TempDir()
+	service := &SettingsService{
+		path: filepath.Join(tmpDir, "test-settings.json"),
+	}
+	service.Load()
+	assert.False(t, service.Loaded(), "Defaults should not count as loaded")
+
+	settings := service.Get()
+	settings.ScanCategories = []string{"node"}
+	require.NoError(t, service.Update(settings))
+
+	reloaded := &SettingsService{path: service.path}
+	require.NoError(t, reloaded.Load())
+	assert.True(t, reloaded.Loaded(), "Settings read from file should count as loaded")
+	assert.Equal(t, []string{"node"}, reloaded.Get().ScanCategories)
+}
+
+// TestSettingsAddExcludePaths tests that exclude paths are appended once and saved
+func TestSettingsAddExcludePaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	service := &SettingsService{
+		path: filepath.Join(tmpDir, "test-settings.json"),
+	}
+	service.Load()
+
+	require.NoError(t, service.AddExcludePaths("/work/a/node_modules"))
+	require.NoError(t, service.AddExcludePaths("/work/a/node_modules", "/work/b/target"))
+
+	reloaded := &SettingsService{path: service.path}
+	require.NoError(t, reloaded.Load())
+	assert.Equal(t, []string{"/work/a/node_modules", "/work/b/target"}, reloaded.Get().ExcludePaths)
+	assert.Empty(t, reloaded.Get().ScanCategories, "Default categories should not be saved")
+}
@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
 	"sync"
 
+	"github.com/thanhdevapp/dev-cleaner/internal/cleaner"
 	"github.com/thanhdevapp/dev-cleaner/internal/scanner"
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
@@ -70,3 +73,41 @@ func (t *TreeService) ClearCache() {
 		runtime.EventsEmit(t.ctx, "tree:cleared")
 	}
 }
+
+// InvalidatePath drops the cached nodes of path, of everything below it and
+// of its ancestors, whose sizes and children include path, so the next
+// GetTreeNode rescans them while the rest of the cache is kept
+func (t *TreeService) InvalidatePath(path string) {
+	path = filepath.Clean(path)
+	prefix := path + string(filepath.Separator)
+
+	t.mu.Lock()
+	for cached := range t.cache {
+		if strings.HasPrefix(cached, prefix) {
+			delete(t.cache, cached)
+		}
+	}
+	for dir := path; ; dir = filepath.Dir(dir) {
+		delete(t.cache, dir)
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	t.mu.Unlock()
+
+	if t.ctx != nil {
+		runtime.EventsEmit(t.ctx, "tree:invalidated", path)
+	}
+}
+
+// InvalidateCleaned invalidates the paths a clean removed or partly freed
+func (t *TreeService) InvalidateCleaned(results []cleaner.CleanResult) {
+	for _, result := range results {
+		if result.WasDryRun {
+			continue
+		}
+		if result.Success || result.FreedSize > 0 {
+			t.InvalidatePath(result.Path)
+		}
+	}
+}
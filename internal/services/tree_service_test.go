@@ -0,0 +1,61 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanhdevapp/dev-cleaner/internal/cleaner"
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// cachedTree returns a TreeService whose cache holds the given paths
+func cachedTree(t *testing.T, paths ...string) *TreeService {
+	service, err := NewTreeService()
+	require.NoError(t, err)
+	for _, path := range paths {
+		service.cache[path] = &types.TreeNode{Path: path, Scanned: true}
+	}
+	return service
+}
+
+// TestInvalidatePath tests that the path, its descendants and its ancestors
+// leave the cache while siblings stay
+func TestInvalidatePath(t *testing.T) {
+	service := cachedTree(t,
+		"/work",
+		"/work/app",
+		"/work/app/node_modules",
+		"/work/app/node_modules/react",
+		"/work/app/src",
+		"/work/other",
+		"/work/app-old",
+	)
+
+	service.InvalidatePath("/work/app/node_modules/")
+
+	assert.NotContains(t, service.cache, "/work")
+	assert.NotContains(t, service.cache, "/work/app")
+	assert.NotContains(t, service.cache, "/work/app/node_modules")
+	assert.NotContains(t, service.cache, "/work/app/node_modules/react")
+	assert.Contains(t, service.cache, "/work/app/src")
+	assert.Contains(t, service.cache, "/work/other")
+	assert.Contains(t, service.cache, "/work/app-old")
+}
+
+// TestInvalidateCleaned tests that only paths a real clean freed are invalidated
+func TestInvalidateCleaned(t *testing.T) {
+	service := cachedTree(t, "/a", "/b", "/c", "/d")
+
+	service.InvalidateCleaned([]cleaner.CleanResult{
+		{Path: "/a", Success: true},
+		{Path: "/b", Success: true, WasDryRun: true},
+		{Path: "/c", FreedSize: 10},
+		{Path: "/d"},
+	})
+
+	assert.NotContains(t, service.cache, "/a")
+	assert.Contains(t, service.cache, "/b")
+	assert.NotContains(t, service.cache, "/c")
+	assert.Contains(t, service.cache, "/d")
+}
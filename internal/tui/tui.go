@@ -2,9 +2,15 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -26,12 +32,130 @@ const (
 	StateScanning   State = iota // Initial scanning animation
 	StateSelecting               // Viewing and selecting items
 	StateConfirming              // Showing confirmation dialog
+	StateCountdown               // Short abortable delay before permanent deletion
 	StateDeleting                // Actively deleting items
 	StateDone                    // Operation complete
 	StateTree                    // Tree navigation view
 	StateHelp                    // Help screen
+	StateTreemap                 // Proportional size chart view
+	StateCategories              // Choosing the categories to scan
 )
 
+// Options configures optional TUI behavior
+type Options struct {
+	DefaultView string             // "list" (default) or "treemap", from settings
+	LogPath     string             // Cleaner log file; empty uses the default location
+	ScanOptions *types.ScanOptions // Options for rescans; nil uses DefaultScanOptions
+	KeepRecent  int                // > 0 pre-selects all but the N newest versioned folders
+	SelectAll   bool               // Start with every item selected (clean --resume)
+
+	// Above DangerSize bytes or DangerCount items (0 = no limit), a real
+	// deletion is confirmed by typing DELETE instead of pressing y
+	DangerSize  int64
+	DangerCount int
+
+	// PreviewLog lists the entries the deletion will append to the log
+	// on the confirmation screen (clean --preview-log)
+	PreviewLog bool
+
+	// GroupByProject lists items under collapsible project headers
+	// (scanner.GroupByProject) instead of one flat list
+	GroupByProject bool
+
+	// PageSize caps the rows the list shows at once; PgUp/PgDn move
+	// between pages (0 = DefaultPageSize)
+	PageSize int
+
+	// StartScan runs the scan chosen on the category screen (see
+	// NewCategoriesModel); nil scans with a new scanner.Scanner. Like
+	// scanner.ScanAllStreamReport, it also returns the scan's report.
+	StartScan func(opts types.ScanOptions) (<-chan types.ScanResult, func() types.ScanReport)
+
+	// Rescan, when set, replaces the full rescan (r after a clean), e.g.
+	// to re-run analyze; rescanning one category (R) is then unavailable
+	Rescan func() ([]types.ScanResult, error)
+
+	// StreamReport returns the report of the stream given to
+	// NewStreamModel once it is closed, so failed categories show in the
+	// notice (nil = none)
+	StreamReport func() types.ScanReport
+}
+
+// itemsTableHeight sizes the main table to show all items, within limits
+func itemsTableHeight(count int) int {
+	if count < 5 {
+		return 5 // Minimum height
+	}
+	if count > 30 {
+		return 30 // Cap at 30 to prevent huge tables
+	}
+	return count
+}
+
+// DefaultPageSize is how many rows a page of the list holds, so the table
+// stays responsive with hundreds of results
+const DefaultPageSize = 50
+
+// tableHeight sizes the main table to the rows of one page
+func (m Model) tableHeight() int {
+	return itemsTableHeight(min(len(m.listRows()), m.pageSize))
+}
+
+// pageBounds returns the [start, end) rows of the page the cursor is on
+// among count rows
+func (m Model) pageBounds(count int) (int, int) {
+	start := m.cursor / m.pageSize * m.pageSize
+	return start, min(start+m.pageSize, count)
+}
+
+// pageInfo returns the cursor's page and the number of pages, from 1
+func (m Model) pageInfo() (int, int) {
+	count := len(m.listRows())
+	pages := max((count+m.pageSize-1)/m.pageSize, 1)
+	return m.cursor/m.pageSize + 1, pages
+}
+
+// clampCursor keeps a cursor moved by paging keys within [0, count)
+func clampCursor(cursor, count int) int {
+	if cursor >= count {
+		cursor = count - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	return cursor
+}
+
+// pageCursor returns where a paging key (PgUp/PgDn/Home/End) moves cursor
+// in a table of count rows that shows height rows at once
+func pageCursor(msg tea.KeyMsg, cursor, count, height int) int {
+	switch {
+	case key.Matches(msg, keys.PageUp):
+		return clampCursor(cursor-height, count)
+	case key.Matches(msg, keys.PageDown):
+		return clampCursor(cursor+height, count)
+	case key.Matches(msg, keys.Home):
+		return 0
+	case key.Matches(msg, keys.End):
+		return clampCursor(count-1, count)
+	}
+	return cursor
+}
+
+// slowDeleteFiles is the selected file count above which the confirmation
+// warns that deletion will take a while
+const slowDeleteFiles = 100000
+
+// largestFileScanLimit bounds the walk for the confirmation's largest-file
+// hint, so a folder with millions of files cannot stall it
+const largestFileScanLimit = 10000
+
+// deleteCountdownSeconds is the abort window before a permanent delete starts
+const deleteCountdownSeconds = 3
+
+// treemapMaxItems caps how many items the treemap view shows
+const treemapMaxItems = 20
+
 // treeState saves tree navigation state for restoration
 type treeState struct {
 	parentNode *types.TreeNode
@@ -52,6 +176,7 @@ var tips = []string{
 	"💡 Tip: In tree mode, 'c' lets you delete folders at any level",
 	"💡 Tip: All deletion operations are logged to ~/.dev-cleaner.log",
 	"💡 Tip: Press 'Esc' in tree mode to return to main list",
+	"💡 Tip: Press 't' to see the biggest items as a size chart",
 }
 
 // Styles
@@ -116,11 +241,18 @@ var (
 type KeyMap struct {
 	Up         key.Binding
 	Down       key.Binding
+	PageUp     key.Binding // Jump up by the visible table height
+	PageDown   key.Binding
+	Home       key.Binding // Jump to the first row
+	End        key.Binding // Jump to the last row
 	Toggle     key.Binding
 	All        key.Binding
 	None       key.Binding
+	AllOfType  key.Binding // Select every item of the current item's type
 	Confirm    key.Binding
 	QuickClean key.Binding // Quick select current + confirm
+	Open       key.Binding // Reveal current item in Finder
+	Yank       key.Binding // Copy current item's path to the clipboard
 	Help       key.Binding // Show help screen
 	Quit       key.Binding
 	// Tree navigation keys
@@ -128,6 +260,12 @@ type KeyMap struct {
 	GoBack    key.Binding
 	Refresh   key.Binding
 	ExitTree  key.Binding
+	SortTree  key.Binding // Toggle tree sort between size and name
+	// View toggles
+	Treemap    key.Binding
+	Visual     key.Binding // Range selection (vim-style visual mode)
+	GroupBy    key.Binding // Toggle grouping the list by project
+	RescanType key.Binding // Rescan only the current item's category
 }
 
 var keys = KeyMap{
@@ -139,6 +277,22 @@ var keys = KeyMap{
 		key.WithKeys("down", "j"),
 		key.WithHelp("↓/j", "down"),
 	),
+	PageUp: key.NewBinding(
+		key.WithKeys("pgup"),
+		key.WithHelp("pgup", "page up"),
+	),
+	PageDown: key.NewBinding(
+		key.WithKeys("pgdown"),
+		key.WithHelp("pgdn", "page down"),
+	),
+	Home: key.NewBinding(
+		key.WithKeys("home"),
+		key.WithHelp("home", "first"),
+	),
+	End: key.NewBinding(
+		key.WithKeys("end"),
+		key.WithHelp("end", "last"),
+	),
 	Toggle: key.NewBinding(
 		key.WithKeys(" "),
 		key.WithHelp("space", "toggle"),
@@ -151,6 +305,10 @@ var keys = KeyMap{
 		key.WithKeys("n"),
 		key.WithHelp("n", "select none"),
 	),
+	AllOfType: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "select all of type"),
+	),
 	Confirm: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "clean selected"),
@@ -159,6 +317,14 @@ var keys = KeyMap{
 		key.WithKeys("c"),
 		key.WithHelp("c", "select & clean"),
 	),
+	Open: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "open in Finder"),
+	),
+	Yank: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy path"),
+	),
 	Help: key.NewBinding(
 		key.WithKeys("?"),
 		key.WithHelp("?", "help"),
@@ -184,6 +350,26 @@ var keys = KeyMap{
 		key.WithKeys("esc"),
 		key.WithHelp("esc", "exit tree"),
 	),
+	SortTree: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "sort by size/name"),
+	),
+	Treemap: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "treemap"),
+	),
+	Visual: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "visual select"),
+	),
+	GroupBy: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "group by project"),
+	),
+	RescanType: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "rescan category"),
+	),
 }
 
 // Model represents the TUI state
@@ -215,11 +401,15 @@ type Model struct {
 	scanning       bool              // True while scanning
 	returnToTree   bool              // True if should return to tree after deletion
 	savedTreeState *treeState        // Saved tree state for restoration
+	treeSortByName bool              // Sort children by name instead of largest-first
+	treeProgress   *sizeProgress     // Running totals of the folder being scanned
 
 	// Time tracking
-	startTime      time.Time     // Session start time
-	deleteStart    time.Time     // Delete operation start time
-	deleteDuration time.Duration // Frozen duration when deletion completes
+	startTime      time.Time       // Session start time
+	deleteStart    time.Time       // Delete operation start time
+	deletedFiles   *atomic.Int64   // Files removed so far, for the files/s rate
+	largestFile    *largestFileMsg // Largest file in the top item to confirm
+	deleteDuration time.Duration   // Frozen duration when deletion completes
 
 	// Scanning progress
 	scanningCategories []string        // Categories being scanned
@@ -227,11 +417,19 @@ type Model struct {
 	currentScanning    int             // Index of currently scanning category
 
 	// Deletion progress
-	deletingItems   []types.ScanResult // Items being deleted
-	deleteComplete  map[int]bool       // Which items are complete
-	deleteStatus    map[int]string     // Status for each item (success/error)
-	currentDeleting int                // Index of currently deleting item
-	fakeProgress    float64            // Fake progress for smooth animation
+	deletingItems   []types.ScanResult    // Items being deleted
+	deleteComplete  map[int]bool          // Which items are complete
+	deleteStatus    map[int]string        // Status for each item (success/error)
+	deleteFreed     map[int]int64         // Bytes freed by items whose removal failed
+	deleteTimes     map[int]time.Duration // How long each item's removal took
+	currentDeleting int                   // Index of currently deleting item
+	stopRequested   bool                  // Esc/x: stop after the current item
+	stoppedOf       int                   // Items a stopped deletion had queued, 0 = ran to the end
+	fakeProgress    float64               // Fake progress for smooth animation
+	spaceBefore     int64                 // Free space on target volumes before deleting
+	spaceMeasured   bool                  // spaceBefore is valid (real runs only)
+	diskFree        int64                 // Free space on the home volume for the status bar, 0 = unknown
+	spaceCheck      *cleaner.SpaceCheck   // Measured vs estimated freed space
 
 	// Help and tips
 	currentTip string // Current random tip to display
@@ -240,30 +438,380 @@ type Model struct {
 	// Table view
 	itemsTable table.Model // Table for rendering items list
 	treeTable  table.Model // Table for rendering tree view
+
+	// Views
+	defaultView string // "list" or "treemap"
+
+	// Cleaner log file (empty = default location)
+	logPath string
+
+	// Scan options reused by rescans (nil = DefaultScanOptions)
+	scanOptions *types.ScanOptions
+
+	// Streaming scan: items arrive on stream while streaming is true
+	stream       <-chan types.ScanResult
+	streamReport func() types.ScanReport // Report of stream once closed (nil = none)
+	streaming    bool
+
+	// True while a rescanItems run is in flight; further rescans are ignored
+	rescanning bool
+
+	// Transient status bar message (e.g. result of opening in Finder)
+	notice string
+
+	// --keep-recent: versioned folders to keep per parent, 0 = off
+	keepRecent int
+
+	// Category screen (StateCategories): toggles by types.Categories index
+	categoryOn     []bool
+	categoryCursor int
+	startScan      func(opts types.ScanOptions) (<-chan types.ScanResult, func() types.ScanReport)
+
+	// Replaces the full rescan when set (Options.Rescan)
+	rescan func() ([]types.ScanResult, error)
+
+	// Risky items need a second [y] on the confirmation screen
+	riskyConfirmed bool
+
+	// Deletions above these thresholds need DELETE typed out (Options)
+	dangerSize  int64
+	dangerCount int
+	dangerTyped string // Typed so far on a dangerous confirmation
+
+	previewLog bool // Show the log entries on the confirmation screen
+
+	// Countdown before permanent deletion
+	countdown   int // Seconds left
+	countdownID int // Identifies the active countdown so stale ticks are ignored
+
+	// Visual (range) selection
+	visualMode   bool // True while a range is being marked
+	visualAnchor int  // Row where the range started
+
+	// Grouped list (--group-by-project, g): collapsed groups by root
+	groupByProject bool
+	collapsed      map[string]bool
+
+	// Rows per page of the list (--page-size); only one page is built
+	pageSize int
+
+	// Detail pane metadata, stat'ed once per path (shared between copies)
+	itemStats map[string]itemStat
+}
+
+// itemStat is the on-disk metadata shown in the detail pane
+type itemStat struct {
+	modTime time.Time
+	subdirs int
+	ok      bool // False when the path could not be stat'ed (or is not a path)
+}
+
+// listRow is one row of the items table: an item, or in the grouped list
+// a project header (item -1)
+type listRow struct {
+	item  int // Index into items, -1 for a group header
+	group int // Index into the groups of projectGroups, -1 when not grouped
+}
+
+// projectGroups returns the groups of the grouped list
+func (m Model) projectGroups() []scanner.ProjectGroup {
+	return scanner.GroupByProject(m.items)
+}
+
+// listRows returns the rows of the items table. The flat list has one row
+// per item; the grouped list has a header per project, followed by its
+// items unless the group is collapsed.
+func (m Model) listRows() []listRow {
+	if !m.groupByProject {
+		rows := make([]listRow, len(m.items))
+		for i := range m.items {
+			rows[i] = listRow{item: i, group: -1}
+		}
+		return rows
+	}
+
+	var rows []listRow
+	for g, group := range m.projectGroups() {
+		rows = append(rows, listRow{item: -1, group: g})
+		if m.collapsed[group.Root] {
+			continue
+		}
+		for _, i := range group.Items {
+			rows = append(rows, listRow{item: i, group: g})
+		}
+	}
+	return rows
+}
+
+// rowItems returns the items a row stands for: its item, or every item of
+// a header's group
+func (m Model) rowItems(rows []listRow, row int) []int {
+	if row < 0 || row >= len(rows) {
+		return nil
+	}
+	if rows[row].item >= 0 {
+		return []int{rows[row].item}
+	}
+	return m.projectGroups()[rows[row].group].Items
+}
+
+// cursorItem returns the item under the cursor, false on a group header
+func (m Model) cursorItem() (int, bool) {
+	rows := m.listRows()
+	if m.cursor >= len(rows) || rows[m.cursor].item < 0 {
+		return 0, false
+	}
+	return rows[m.cursor].item, true
+}
+
+// cursorGroup returns the project group of the row under the cursor, false
+// in the flat list
+func (m Model) cursorGroup() (scanner.ProjectGroup, bool) {
+	rows := m.listRows()
+	if m.cursor >= len(rows) || rows[m.cursor].group < 0 {
+		return scanner.ProjectGroup{}, false
+	}
+	return m.projectGroups()[rows[m.cursor].group], true
+}
+
+// cursorPath returns the path of the item under the cursor, or the
+// project root on a group header
+func (m Model) cursorPath() string {
+	if i, ok := m.cursorItem(); ok {
+		return m.items[i].Path
+	}
+	if group, ok := m.cursorGroup(); ok {
+		return group.Root
+	}
+	return ""
+}
+
+// rowOfItem returns the row showing item i, or its group's header when
+// the group is collapsed
+func (m Model) rowOfItem(i int) int {
+	for row, r := range m.listRows() {
+		if r.item == i {
+			return row
+		}
+	}
+	for g, group := range m.projectGroups() {
+		if slices.Contains(group.Items, i) {
+			return m.headerRow(g)
+		}
+	}
+	return 0
+}
+
+// headerRow returns the row of group g's header
+func (m Model) headerRow(g int) int {
+	for row, r := range m.listRows() {
+		if r.item < 0 && r.group == g {
+			return row
+		}
+	}
+	return 0
+}
+
+// toggleItems selects every one of items, or deselects them all if they
+// are all selected already
+func (m *Model) toggleItems(items []int) {
+	allSelected := true
+	for _, i := range items {
+		if !m.selected[i] {
+			allSelected = false
+			break
+		}
+	}
+	for _, i := range items {
+		if allSelected {
+			delete(m.selected, i)
+		} else {
+			m.selected[i] = true
+		}
+	}
+}
+
+// toggleGrouping switches between the flat and the grouped list, keeping
+// the cursor on the same item
+func (m *Model) toggleGrouping() {
+	item, ok := m.cursorItem()
+	m.groupByProject = !m.groupByProject
+	m.visualMode = false
+	m.cursor = 0
+	if ok {
+		m.cursor = m.rowOfItem(item)
+	}
+	m.itemsTable.SetHeight(m.tableHeight())
+}
+
+// setCollapsed collapses or expands group g, moving the cursor to its
+// header when the cursor's row is hidden
+func (m *Model) setCollapsed(g int, collapsed bool) {
+	groups := m.projectGroups()
+	if g < 0 || g >= len(groups) {
+		return
+	}
+	if m.collapsed == nil {
+		m.collapsed = make(map[string]bool)
+	}
+	m.collapsed[groups[g].Root] = collapsed
+	m.cursor = m.headerRow(g)
+	m.itemsTable.SetHeight(m.tableHeight())
+}
+
+// visualRange returns the inclusive [start, end] row range of visual mode
+func (m Model) visualRange() (int, int) {
+	start, end := m.visualAnchor, m.cursor
+	if start > end {
+		start, end = end, start
+	}
+	return start, end
+}
+
+// inVisualRange reports whether row i is inside the active visual range
+func (m Model) inVisualRange(i int) bool {
+	if !m.visualMode {
+		return false
+	}
+	start, end := m.visualRange()
+	return i >= start && i <= end
+}
+
+// toggleVisualRange selects every item in the range, or deselects them all
+// if the whole range is already selected, then leaves visual mode
+func (m *Model) toggleVisualRange() {
+	start, end := m.visualRange()
+	rows := m.listRows()
+	var items []int
+	for row := start; row <= end && row < len(rows); row++ {
+		for _, i := range m.rowItems(rows, row) {
+			if !slices.Contains(items, i) {
+				items = append(items, i)
+			}
+		}
+	}
+	m.toggleItems(items)
+	m.visualMode = false
+}
+
+// selectAllOfType selects every item of the given type (leaving other
+// selections alone) and returns how many items have that type
+func (m *Model) selectAllOfType(t types.CleanTargetType) int {
+	count := 0
+	for i, item := range m.items {
+		if item.Type == t {
+			m.selected[i] = true
+			count++
+		}
+	}
+	return count
+}
+
+// selectRetained applies --keep-recent, selecting all but the keepRecent
+// newest folders of each versioned parent (see scanner.ApplyRetention)
+func (m *Model) selectRetained() {
+	if m.keepRecent <= 0 {
+		return
+	}
+	older := scanner.ApplyRetention(m.items, m.keepRecent)
+	for i, item := range m.items {
+		if older[item.Path] {
+			m.selected[i] = true
+		}
+	}
+	if len(older) > 0 {
+		m.notice = fmt.Sprintf("Selected %d older version(s) (--keep-recent %d)", len(older), m.keepRecent)
+	}
+	m.updateTableRows()
 }
 
-// updateTableRows updates the table rows to reflect current selections
+// updateTableRows updates the table rows to reflect current selections.
+// Only the cursor's page is built, so long lists stay responsive.
 func (m *Model) updateTableRows() {
 	rows := []table.Row{}
-	for i, item := range m.items {
+	var groups []scanner.ProjectGroup
+	if m.groupByProject {
+		groups = m.projectGroups()
+	}
+	listRows := m.listRows()
+	start, end := m.pageBounds(len(listRows))
+	for row := start; row < end; row++ {
+		r := listRows[row]
+		if r.item < 0 {
+			rows = append(rows, m.groupHeaderRow(groups[r.group], row))
+			continue
+		}
+		i, item := r.item, m.items[r.item]
+
 		checkbox := "[ ]"
 		if m.selected[i] {
 			checkbox = "[✓]"
 		}
+		// Braces mark rows inside the visual range
+		if m.inVisualRange(row) {
+			checkbox = "{" + checkbox[1:len(checkbox)-1] + "}"
+		}
 
-		typeBadge := string(item.Type)
-		sizeStr := ui.FormatSize(item.Size)
+		typeBadge := item.CategoryLabel()
+		sizeStr := ui.SizeLabel(item)
+		name := item.Name
+		if label := ui.ReclaimableLabel(item); label != "" {
+			name += " (" + label + ")"
+		}
+		if item.Risky {
+			name = "⚠ " + name
+		}
+		if m.groupByProject {
+			name = "  " + name // Indent below the group header
+		}
 
 		rows = append(rows, table.Row{
 			checkbox,
 			typeBadge,
 			sizeStr,
-			item.Name,
+			name,
 			item.Path, // Full path
 		})
 	}
 	m.itemsTable.SetRows(rows)
-	m.itemsTable.SetCursor(m.cursor)
+	m.itemsTable.SetCursor(m.cursor - start)
+}
+
+// groupHeaderRow renders a project group's header: [✓] when all of its
+// items are selected, [-] when some are, and ▸ when it is collapsed
+func (m Model) groupHeaderRow(group scanner.ProjectGroup, row int) table.Row {
+	selected := 0
+	for _, i := range group.Items {
+		if m.selected[i] {
+			selected++
+		}
+	}
+	checkbox := "[ ]"
+	switch {
+	case selected == len(group.Items):
+		checkbox = "[✓]"
+	case selected > 0:
+		checkbox = "[-]"
+	}
+	if m.inVisualRange(row) {
+		checkbox = "{" + checkbox[1:len(checkbox)-1] + "}"
+	}
+
+	arrow := "▾"
+	if m.collapsed[group.Root] {
+		arrow = "▸"
+	}
+	count := fmt.Sprintf("%d items", len(group.Items))
+	if len(group.Items) == 1 {
+		count = "1 item"
+	}
+	return table.Row{
+		checkbox,
+		arrow + " " + count,
+		ui.FormatSize(group.Size),
+		fmt.Sprintf("%s (total %s)", group.Name, ui.FormatSize(group.Size)),
+		group.Root,
+	}
 }
 
 // updateTreeTableRows updates the tree table rows to reflect current selections
@@ -273,6 +821,8 @@ func (m *Model) updateTreeTableRows() {
 		return
 	}
 
+	m.sortTreeChildren()
+
 	rows := []table.Row{}
 	for _, child := range m.currentNode.Children {
 		checkbox := "[ ]"
@@ -304,14 +854,49 @@ func (m *Model) updateTreeTableRows() {
 	m.treeTable.SetCursor(m.cursor)
 }
 
+// sortTreeChildren orders the current node's children largest-first (or by
+// name when treeSortByName is set). Children are sorted in place because the
+// tree cursor indexes into them.
+func (m *Model) sortTreeChildren() {
+	children := m.currentNode.Children
+	sort.SliceStable(children, func(i, j int) bool {
+		if !m.treeSortByName && children[i].Size != children[j].Size {
+			return children[i].Size > children[j].Size
+		}
+		return strings.ToLower(children[i].Name) < strings.ToLower(children[j].Name)
+	})
+}
+
+// toggleTreeSort switches the tree sort order, keeping the cursor on the
+// same child
+func (m *Model) toggleTreeSort() {
+	m.treeSortByName = !m.treeSortByName
+	if m.currentNode == nil || !m.currentNode.HasChildren() {
+		return
+	}
+
+	var cursorPath string
+	if m.cursor < len(m.currentNode.Children) {
+		cursorPath = m.currentNode.Children[m.cursor].Path
+	}
+	m.sortTreeChildren()
+	for i, child := range m.currentNode.Children {
+		if child.Path == cursorPath {
+			m.cursor = i
+			break
+		}
+	}
+	m.updateTreeTableRows()
+}
+
 // updateTableColumns updates table column widths based on terminal width
 func (m *Model) updateTableColumns() {
 	if m.width == 0 {
 		return // No width info yet
 	}
 
-	// Fixed column widths: checkbox(3) + category/type(12 or 4) + size(10) + name(30) + borders/padding(~10)
-	fixedWidth := 3 + 12 + 10 + 30 + 10
+	// Fixed column widths: checkbox(3) + category/type(16 or 4) + size(10) + name(30) + borders/padding(~10)
+	fixedWidth := 3 + 16 + 10 + 30 + 10
 	pathWidth := m.width - fixedWidth
 	if pathWidth < 30 {
 		pathWidth = 30 // Minimum path width
@@ -320,7 +905,7 @@ func (m *Model) updateTableColumns() {
 	// Update main table columns
 	mainCols := []table.Column{
 		{Title: "", Width: 3},             // Checkbox
-		{Title: "Category", Width: 12},    // Type badge
+		{Title: "Category", Width: 16},    // Type badge
 		{Title: "Size", Width: 10},        // Formatted size
 		{Title: "Name", Width: 30},        // Item name
 		{Title: "Path", Width: pathWidth}, // Dynamic path width
@@ -346,6 +931,11 @@ func (m *Model) updateTableColumns() {
 
 // NewModel creates a new TUI model
 func NewModel(items []types.ScanResult, dryRun bool, version string) Model {
+	return NewModelWithOptions(items, dryRun, version, Options{})
+}
+
+// NewModelWithOptions creates a new TUI model with optional behavior
+func NewModelWithOptions(items []types.ScanResult, dryRun bool, version string, opts Options) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED"))
@@ -360,40 +950,18 @@ func NewModel(items []types.ScanResult, dryRun bool, version string) Model {
 		for _, item := range items {
 			typesSeen[item.Type] = true
 		}
-		if typesSeen[types.TypeXcode] {
-			categories = append(categories, "Xcode")
-		}
-		if typesSeen[types.TypeAndroid] {
-			categories = append(categories, "Android")
-		}
-		if typesSeen[types.TypeNode] {
-			categories = append(categories, "Node.js")
-		}
-		if typesSeen[types.TypeFlutter] {
-			categories = append(categories, "Flutter")
-		}
-		if typesSeen[types.TypePython] {
-			categories = append(categories, "Python")
-		}
-		if typesSeen[types.TypeRust] {
-			categories = append(categories, "Rust")
-		}
-		if typesSeen[types.TypeGo] {
-			categories = append(categories, "Go")
-		}
-		if typesSeen[types.TypeHomebrew] {
-			categories = append(categories, "Homebrew")
-		}
-		if typesSeen[types.TypeDocker] {
-			categories = append(categories, "Docker")
-		}
-		if typesSeen[types.TypeJava] {
-			categories = append(categories, "Java")
+		for _, display := range types.TypeDisplays {
+			if typesSeen[display.Type] {
+				categories = append(categories, display.Label)
+			}
 		}
 	}
 
 	// Start in scanning state if we have items
 	initialState := StateSelecting
+	if opts.DefaultView == "treemap" && len(items) > 0 {
+		initialState = StateTreemap
+	}
 	if len(items) > 0 && len(categories) > 0 {
 		initialState = StateScanning
 	}
@@ -404,41 +972,17 @@ func NewModel(items []types.ScanResult, dryRun bool, version string) Model {
 	// Create table columns
 	columns := []table.Column{
 		{Title: "", Width: 3},          // Checkbox
-		{Title: "Category", Width: 12}, // Type badge
+		{Title: "Category", Width: 16}, // Type badge
 		{Title: "Size", Width: 10},     // Formatted size
 		{Title: "Name", Width: 30},     // Item name (shorter to make room for path)
 		{Title: "Path", Width: 50},     // Full path
 	}
 
-	// Create table rows from items
-	rows := []table.Row{}
-	for _, item := range items {
-		checkbox := "[ ]"
-		typeBadge := string(item.Type)
-		sizeStr := ui.FormatSize(item.Size)
-
-		rows = append(rows, table.Row{
-			checkbox,
-			typeBadge,
-			sizeStr,
-			item.Name,
-			item.Path, // Full path
-		})
-	}
-
-	// Initialize table with dynamic height to show all items
-	tableHeight := len(items)
-	if tableHeight < 5 {
-		tableHeight = 5 // Minimum height
-	}
-	if tableHeight > 30 {
-		tableHeight = 30 // Cap at 30 to prevent huge tables
-	}
+	// Rows (one page of them) and height are set once the model exists
 	t := table.New(
 		table.WithColumns(columns),
-		table.WithRows(rows),
 		table.WithFocused(true),
-		table.WithHeight(tableHeight),
+		table.WithHeight(itemsTableHeight(len(items))),
 	)
 
 	// Apply table styles
@@ -482,9 +1026,11 @@ func NewModel(items []types.ScanResult, dryRun bool, version string) Model {
 		// Tree navigation
 		treeMode:     false,
 		nodeStack:    make([]*types.TreeNode, 0),
-		maxDepth:     5,
+		maxDepth:     types.DefaultTreeDepth,
 		treeSelected: make(map[string]bool),
 		scanning:     false,
+		treeProgress: &sizeProgress{},
+		deletedFiles: &atomic.Int64{},
 		// Time tracking
 		startTime: time.Now(),
 		// Scanning animation
@@ -495,6 +1041,8 @@ func NewModel(items []types.ScanResult, dryRun bool, version string) Model {
 		deletingItems:   []types.ScanResult{},
 		deleteComplete:  make(map[int]bool),
 		deleteStatus:    make(map[int]string),
+		deleteFreed:     make(map[int]int64),
+		deleteTimes:     make(map[int]time.Duration),
 		currentDeleting: 0,
 		// Help and tips
 		currentTip: randomTip,
@@ -502,20 +1050,83 @@ func NewModel(items []types.ScanResult, dryRun bool, version string) Model {
 		// Table view
 		itemsTable: t,
 		treeTable:  treeT,
+		// Views
+		defaultView: opts.DefaultView,
+		logPath:     opts.LogPath,
+		scanOptions: opts.ScanOptions,
+		itemStats:   make(map[string]itemStat),
+		keepRecent:  opts.KeepRecent,
+		dangerSize:  opts.DangerSize,
+		dangerCount: opts.DangerCount,
+		previewLog:  opts.PreviewLog,
+
+		groupByProject: opts.GroupByProject,
+		rescan:         opts.Rescan,
+		pageSize:       opts.PageSize,
+	}
+	if m.pageSize <= 0 {
+		m.pageSize = DefaultPageSize
+	}
+	if opts.ScanOptions != nil && opts.ScanOptions.MaxDepth > 0 {
+		m.maxDepth = opts.ScanOptions.MaxDepth
+	}
+
+	if opts.SelectAll {
+		for i := range items {
+			m.selected[i] = true
+		}
 	}
 
 	// Initialize table rows
+	m.itemsTable.SetHeight(m.tableHeight())
 	m.updateTableRows()
+	m.selectRetained()
+
+	return m
+}
+
+// NewStreamModel creates a TUI model that starts empty and fills its list
+// from stream as results arrive (see scanner.ScanAllStream)
+func NewStreamModel(stream <-chan types.ScanResult, dryRun bool, version string, opts Options) Model {
+	m := NewModelWithOptions(nil, dryRun, version, opts)
+	m.stream = stream
+	m.streamReport = opts.StreamReport
+	m.streaming = true
+	m.state = StateSelecting
+	if opts.DefaultView == "treemap" {
+		m.state = StateTreemap
+	}
+	return m
+}
+
+// NewCategoriesModel creates a TUI model that starts on a screen for
+// choosing which categories to scan, then runs that scan itself. The
+// categories enabled in opts.ScanOptions (all when nil) start toggled on.
+func NewCategoriesModel(dryRun bool, version string, opts Options) Model {
+	m := NewModelWithOptions(nil, dryRun, version, opts)
+	m.state = StateCategories
+	m.startScan = opts.StartScan
 
+	base := types.DefaultScanOptions()
+	if opts.ScanOptions != nil {
+		base = *opts.ScanOptions
+	}
+	m.categoryOn = make([]bool, len(types.Categories))
+	for i, category := range types.Categories {
+		m.categoryOn[i] = base.CategoryEnabled(category.Name)
+	}
 	return m
 }
 
 // Init implements tea.Model
 func (m Model) Init() tea.Cmd {
+	if m.streaming {
+		return tea.Batch(m.spinner.Tick, m.waitForStream(), refreshDiskFree())
+	}
 	if m.state == StateScanning {
-		return tea.Batch(m.spinner.Tick, m.tickScanning())
+		return tea.Batch(m.spinner.Tick, m.tickScanning(), refreshDiskFree())
 	}
-	return m.spinner.Tick
+	return tea.Batch(m.spinner.Tick, refreshDiskFree())
 }
 
 // Update implements tea.Model
@@ -570,8 +1181,43 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 
 	case tea.KeyMsg:
+		// Any key press dismisses the previous notice
+		m.notice = ""
+
 		// Handle based on current state
 		switch m.state {
+		case StateCategories:
+			switch {
+			case key.Matches(msg, keys.Quit):
+				m.quitting = true
+				return m, tea.Quit
+			case key.Matches(msg, keys.Up):
+				if m.categoryCursor > 0 {
+					m.categoryCursor--
+				}
+			case key.Matches(msg, keys.Down):
+				if m.categoryCursor < len(m.categoryOn)-1 {
+					m.categoryCursor++
+				}
+			case key.Matches(msg, keys.Toggle):
+				m.categoryOn[m.categoryCursor] = !m.categoryOn[m.categoryCursor]
+			case key.Matches(msg, keys.All):
+				for i := range m.categoryOn {
+					m.categoryOn[i] = true
+				}
+			case key.Matches(msg, keys.None):
+				for i := range m.categoryOn {
+					m.categoryOn[i] = false
+				}
+			case key.Matches(msg, keys.Confirm):
+				if len(m.chosenCategories()) == 0 {
+					m.notice = "Select at least one category to scan"
+					return m, nil
+				}
+				return m, m.startCategoryScan()
+			}
+			return m, nil
+
 		case StateDone:
 			switch msg.String() {
 			case "q", "ctrl+c":
@@ -602,9 +1248,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.state = StateSelecting
 				m.results = nil
 				m.err = nil
-				m.scanning = true
 				m.updateTableRows()
 
+				// Only one rescan at a time, or their results race on m.items
+				if m.rescanning {
+					return m, nil
+				}
+				m.scanning = true
+				m.rescanning = true
+
 				// Trigger rescan in background (non-blocking)
 				return m, m.rescanItems()
 
@@ -619,6 +1271,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.cursor = m.savedTreeState.cursorPos
 					m.returnToTree = false
 					m.savedTreeState = nil
+					m.pruneTree()
 					m.updateTreeTableRows()
 					return m, nil
 				}
@@ -631,54 +1284,64 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case StateConfirming:
+			// Huge deletions: type DELETE, which also covers risky items
+			if m.dangerous() {
+				switch msg.Type {
+				case tea.KeyEsc:
+					m.leaveConfirmation()
+				case tea.KeyBackspace:
+					if n := len(m.dangerTyped); n > 0 {
+						m.dangerTyped = m.dangerTyped[:n-1]
+					}
+				case tea.KeyEnter:
+					if m.dangerTyped == dangerConfirmWord {
+						return m, m.confirmDeletion()
+					}
+				case tea.KeyRunes:
+					if typed := m.dangerTyped + string(msg.Runes); len(typed) <= len(dangerConfirmWord) {
+						m.dangerTyped = typed
+					}
+				}
+				return m, nil
+			}
+
 			switch msg.String() {
 			case "y", "Y":
-				m.state = StateDeleting
-				m.percent = 0
-				m.deleteStart = time.Now()
-
-				// Prepare deletion list
-				m.deletingItems = []types.ScanResult{}
-				for i, item := range m.items {
-					if m.selected[i] {
-						m.deletingItems = append(m.deletingItems, item)
-					}
+				if m.countSelectedRisky() > 0 && !m.returnToTree && !m.riskyConfirmed {
+					m.riskyConfirmed = true
+					return m, nil
 				}
-				m.deleteComplete = make(map[int]bool)
-				m.deleteStatus = make(map[int]string)
-				m.currentDeleting = 0
-
-				// Start deletion with spinner, progress updates, and continuous tick
-				return m, tea.Batch(
-					m.spinner.Tick,
-					m.progress.SetPercent(0),
-					m.tickDeletion(), // Start continuous UI refresh
-					m.performClean(),
-				)
+				return m, m.confirmDeletion()
 			case "n", "N", "esc":
-				// Check if we came from tree mode
-				if m.returnToTree && m.savedTreeState != nil {
-					// Return to tree mode
-					m.state = StateTree
-					m.treeMode = true
-					m.currentNode = m.savedTreeState.parentNode
-					m.nodeStack = m.savedTreeState.nodeStack
-					m.cursor = m.savedTreeState.cursorPos
-					m.returnToTree = false
-					m.savedTreeState = nil
-					return m, nil
-				}
-				// Normal return to selection
-				m.state = StateSelecting
+				m.leaveConfirmation()
 				return m, nil
 			}
 			return m, nil
 
+		case StateCountdown:
+			switch {
+			case key.Matches(msg, keys.Quit):
+				m.quitting = true
+				return m, tea.Quit
+			case msg.String() == "esc", msg.String() == "n", msg.String() == "N":
+				// Abort: nothing has been deleted yet, back to the confirmation.
+				// A list deletion rebuilds its items from the selection there.
+				m.state = StateConfirming
+				m.countdown = 0
+				if !m.returnToTree {
+					m.deletingItems = nil
+				}
+			}
+			return m, nil
+
 		case StateDeleting:
-			// Ignore key presses while deleting
-			if key.Matches(msg, keys.Quit) {
+			switch {
+			case key.Matches(msg, keys.Quit):
 				m.quitting = true
 				return m, tea.Quit
+			case msg.String() == "esc", msg.String() == "x":
+				// The item being removed finishes, the rest are skipped
+				m.stopRequested = true
 			}
 			return m, nil
 
@@ -687,16 +1350,126 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state = StateSelecting
 			return m, nil
 
+		case StateTreemap:
+			switch {
+			case key.Matches(msg, keys.Quit):
+				m.quitting = true
+				return m, tea.Quit
+
+			case key.Matches(msg, keys.Help):
+				m.state = StateHelp
+				return m, nil
+
+			case key.Matches(msg, keys.Treemap), key.Matches(msg, keys.ExitTree):
+				// Keep the highlighted item under the cursor in list view
+				if indices := m.treemapIndices(); m.cursor < len(indices) {
+					m.cursor = m.rowOfItem(indices[m.cursor])
+				} else {
+					m.cursor = 0
+				}
+				m.state = StateSelecting
+				m.updateTableRows()
+				return m, nil
+
+			case key.Matches(msg, keys.Up):
+				if m.cursor > 0 {
+					m.cursor--
+				}
+
+			case key.Matches(msg, keys.Down):
+				if m.cursor < len(m.treemapIndices())-1 {
+					m.cursor++
+				}
+
+			case key.Matches(msg, keys.Toggle):
+				indices := m.treemapIndices()
+				if m.cursor < len(indices) {
+					idx := indices[m.cursor]
+					m.selected[idx] = !m.selected[idx]
+				}
+
+			case key.Matches(msg, keys.Confirm):
+				if m.countSelected() > 0 {
+					return m, m.enterConfirmation()
+				}
+			}
+			return m, nil
+
 		case StateSelecting:
+			// Visual mode: Space/Enter apply to the marked range, Esc/v cancel
+			if m.visualMode {
+				switch {
+				case key.Matches(msg, keys.Toggle), key.Matches(msg, keys.Confirm):
+					m.toggleVisualRange()
+					m.updateTableRows()
+					return m, nil
+				case key.Matches(msg, keys.ExitTree), key.Matches(msg, keys.Visual):
+					m.visualMode = false
+					m.updateTableRows()
+					return m, nil
+				}
+			}
+
 			switch {
 			case key.Matches(msg, keys.Quit):
 				m.quitting = true
 				return m, tea.Quit
 
+			case key.Matches(msg, keys.Open):
+				if path := m.cursorPath(); path != "" {
+					return m, m.openInFinder(path)
+				}
+				return m, nil
+
+			case key.Matches(msg, keys.Yank):
+				if path := m.cursorPath(); path != "" {
+					return m, copyPath(path)
+				}
+				return m, nil
+
+			case key.Matches(msg, keys.GroupBy):
+				m.toggleGrouping()
+				m.updateTableRows()
+				return m, nil
+
+			case key.Matches(msg, keys.RescanType):
+				i, ok := m.cursorItem()
+				if !ok || m.rescanning || m.streaming {
+					return m, nil
+				}
+				if m.rescan != nil {
+					m.notice = "Categories can't be rescanned here"
+					return m, nil
+				}
+				category := string(m.items[i].Type)
+				opts := m.rescanOptions()
+				if unknown := opts.OnlyCategories([]string{category}); len(unknown) > 0 {
+					m.notice = fmt.Sprintf("%s items can't be rescanned on their own", m.items[i].CategoryLabel())
+					return m, nil
+				}
+				m.scanning = true
+				m.rescanning = true
+				return m, m.rescanCategory(opts, category)
+
+			case key.Matches(msg, keys.Visual):
+				if len(m.items) > 0 {
+					m.visualMode = true
+					m.visualAnchor = m.cursor
+					m.updateTableRows()
+				}
+				return m, nil
+
 			case key.Matches(msg, keys.Help):
 				m.state = StateHelp
 				return m, nil
 
+			case key.Matches(msg, keys.Treemap):
+				if len(m.items) > 0 {
+					m.state = StateTreemap
+					m.cursor = 0
+				}
+				return m, nil
+
 			case key.Matches(msg, keys.Up):
 				if m.cursor > 0 {
 					m.cursor--
@@ -704,46 +1477,85 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 			case key.Matches(msg, keys.Down):
-				if m.cursor < len(m.items)-1 {
+				if m.cursor < len(m.listRows())-1 {
 					m.cursor++
 					m.updateTableRows()
 				}
 
+			case key.Matches(msg, keys.PageUp), key.Matches(msg, keys.PageDown),
+				key.Matches(msg, keys.Home), key.Matches(msg, keys.End):
+				// PgUp/PgDn move a whole page of the list
+				m.cursor = pageCursor(msg, m.cursor, len(m.listRows()), m.pageSize)
+				m.updateTableRows()
+
 			case key.Matches(msg, keys.Toggle):
-				m.selected[m.cursor] = !m.selected[m.cursor]
+				// On a group header, toggles the whole project
+				m.toggleItems(m.rowItems(m.listRows(), m.cursor))
 				m.updateTableRows()
 
 			case key.Matches(msg, keys.All):
 				for i := range m.items {
 					m.selected[i] = true
 				}
+				m.visualMode = false
 				m.updateTableRows()
 
 			case key.Matches(msg, keys.None):
 				m.selected = make(map[int]bool)
+				m.visualMode = false
 				m.updateTableRows()
 
+			case key.Matches(msg, keys.AllOfType):
+				if i, ok := m.cursorItem(); ok {
+					itemType := m.items[i].Type
+					count := m.selectAllOfType(itemType)
+					m.visualMode = false
+					m.notice = fmt.Sprintf("Selected all %s (%d)", itemType, count)
+					m.updateTableRows()
+				}
+
 			case key.Matches(msg, keys.Confirm):
 				if m.countSelected() > 0 {
-					m.state = StateConfirming
-					return m, nil
+					return m, m.enterConfirmation()
 				}
 
 			case key.Matches(msg, keys.QuickClean):
-				// Quick clean ONLY current item (clear all other selections)
-				if m.cursor < len(m.items) {
+				// Quick clean ONLY current item, or project on a group
+				// header (clear all other selections)
+				if items := m.rowItems(m.listRows(), m.cursor); len(items) > 0 {
+					m.visualMode = false
 					// Clear all previous selections
 					m.selected = make(map[int]bool)
 					// Select ONLY current item
-					m.selected[m.cursor] = true
+					for _, i := range items {
+						m.selected[i] = true
+					}
 					// Go to confirmation
-					m.state = StateConfirming
-					return m, nil
+					return m, m.enterConfirmation()
+				}
+
+			case key.Matches(msg, keys.GoBack):
+				// Collapse the current group
+				if m.groupByProject {
+					if rows := m.listRows(); m.cursor < len(rows) {
+						m.visualMode = false
+						m.setCollapsed(rows[m.cursor].group, true)
+						m.updateTableRows()
+					}
 				}
 
 			case key.Matches(msg, keys.DrillDown):
+				// Expand or collapse a group header
+				if rows := m.listRows(); m.cursor < len(rows) && rows[m.cursor].item < 0 {
+					group := m.projectGroups()[rows[m.cursor].group]
+					m.visualMode = false
+					m.setCollapsed(rows[m.cursor].group, !m.collapsed[group.Root])
+					m.updateTableRows()
+					return m, nil
+				}
 				// Enter tree mode for current item
-				if m.cursor < len(m.items) {
+				if _, ok := m.cursorItem(); ok {
+					m.visualMode = false
 					m.state = StateTree
 					m.treeMode = true
 					m.scanning = true
@@ -780,6 +1592,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 
+			case key.Matches(msg, keys.Open):
+				if m.currentNode != nil && m.cursor < len(m.currentNode.Children) {
+					return m, m.openInFinder(m.currentNode.Children[m.cursor].Path)
+				}
+				return m, nil
+
+			case key.Matches(msg, keys.Yank):
+				if m.currentNode != nil && m.cursor < len(m.currentNode.Children) {
+					return m, copyPath(m.currentNode.Children[m.cursor].Path)
+				}
+				return m, nil
+
+			case key.Matches(msg, keys.SortTree):
+				m.toggleTreeSort()
+				return m, nil
+
 			case key.Matches(msg, keys.Up):
 				if m.cursor > 0 {
 					m.cursor--
@@ -794,6 +1622,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 
+			case key.Matches(msg, keys.PageUp), key.Matches(msg, keys.PageDown),
+				key.Matches(msg, keys.Home), key.Matches(msg, keys.End):
+				if m.currentNode != nil && m.currentNode.HasChildren() {
+					m.cursor = pageCursor(msg, m.cursor, len(m.currentNode.Children), m.treeTable.Height())
+					m.updateTreeTableRows()
+				}
+
 			case key.Matches(msg, keys.Toggle):
 				if m.currentNode != nil && m.currentNode.HasChildren() {
 					if m.cursor < len(m.currentNode.Children) {
@@ -812,7 +1647,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						// Create a single-item deletion
 						m.deletingItems = []types.ScanResult{{
 							Path:      child.Path,
-							Type:      types.TypeNode,
+							Type:      child.Type,
 							Size:      child.Size,
 							FileCount: child.FileCount,
 							Name:      child.Name,
@@ -821,6 +1656,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						// Setup deletion state
 						m.deleteComplete = make(map[int]bool)
 						m.deleteStatus = make(map[int]string)
+						m.deleteFreed = make(map[int]int64)
+						m.deleteTimes = make(map[int]time.Duration)
 						m.currentDeleting = 0
 						m.selected = map[int]bool{0: true}
 
@@ -832,8 +1669,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							cursorPos:  m.cursor,
 						}
 
-						m.state = StateConfirming
-						return m, nil
+						return m, m.enterConfirmation()
 					}
 				}
 			}
@@ -842,8 +1678,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case deleteItemProgressMsg:
 		// Update item status
 		m.deleteComplete[msg.index] = true
+		m.deleteTimes[msg.index] = msg.duration
 		if msg.status == "error" {
 			m.deleteStatus[msg.index] = "error"
+			m.deleteFreed[msg.index] = msg.freed
 		} else {
 			m.deleteStatus[msg.index] = "success"
 		}
@@ -855,20 +1693,84 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Continue with next item or finish
-		return m, tea.Batch(
+		cmds := []tea.Cmd{
 			m.spinner.Tick, // Keep spinner animating
 			m.progress.SetPercent(m.percent),
 			m.performClean(), // Delete next item or finish
-		)
+		}
+		if !m.dryRun {
+			cmds = append(cmds, refreshDiskFree()) // Watch the space come back
+		}
+		return m, tea.Batch(cmds...)
+
+	case diskFreeMsg:
+		m.diskFree = msg.free
+		return m, nil
+
+	case countdownTickMsg:
+		// Ignore ticks from an aborted countdown
+		if m.state != StateCountdown || msg.id != m.countdownID {
+			return m, nil
+		}
+		m.countdown--
+		if m.countdown <= 0 {
+			return m, m.startDeletion()
+		}
+		return m, m.tickCountdown()
+
+	case streamResultsMsg:
+		items := append(m.items, msg.items...)
+		if msg.done {
+			// Categories can overlap, drop duplicates once everything is in
+			items = scanner.DedupeResults(items)
+			m.streaming = false
+			if summary := ui.ScanErrorsSummary(msg.scanErrors); summary != "" {
+				m.notice = summary
+			}
+		}
+		m.setItems(items)
+		if m.streaming {
+			return m, m.waitForStream()
+		}
+		m.selectRetained()
+		return m, nil
+
+	case largestFileMsg:
+		// Ignore results for a confirmation that was already left
+		if top, ok := m.topConfirmItem(); ok && m.state == StateConfirming && top.Path == msg.dir {
+			m.largestFile = &msg
+		}
+		return m, nil
+
+	case copyResultMsg:
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("Could not copy path: %v", msg.err)
+		} else {
+			m.notice = "Copied path: " + msg.path
+		}
+		return m, nil
+
+	case openResultMsg:
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("Could not open %s: %v", msg.path, msg.err)
+		} else {
+			m.notice = "Opened in Finder: " + msg.path
+		}
+		return m, nil
 
 	case cleanResultMsg:
 		m.state = StateDone
 		m.results = msg.results
 		m.err = msg.err
+		m.stoppedOf = msg.stoppedOf
+		m.stopRequested = false
+		// The next confirmation is built from the selection again
+		m.deletingItems = nil
+		m.spaceCheck = msg.space
 		// Freeze the deletion duration so timer stops counting
 		m.deleteDuration = time.Since(m.deleteStart)
 		m.percent = 1.0 // Ensure progress shows 100%
-		return m, nil
+		return m, refreshDiskFree()
 
 	case scanNodeMsg:
 		m.scanning = false
@@ -885,11 +1787,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case rescanItemsMsg:
+		m.rescanning = false
 		if msg.err != nil {
 			m.err = msg.err
 			m.scanning = false
 			return m, nil
 		}
+		if msg.category != "" {
+			// Replace only the rescanned category's items, keeping the
+			// selection and cursor of the rest. Shared scans add results
+			// of other types too (Android runs gradle, whose items are
+			// Java's); deduping with the new items first keeps their
+			// fresh copies instead of listing those paths twice.
+			var scope types.ScanOptions
+			scope.OnlyCategories([]string{msg.category})
+			items := slices.Clone(msg.items)
+			for _, item := range m.items {
+				if !scope.CategoryEnabled(string(item.Type)) {
+					items = append(items, item)
+				}
+			}
+			m.setItems(scanner.DedupeResults(items))
+			m.scanning = false
+			m.notice = fmt.Sprintf("Rescanned %s: %d items", types.CategoryLabel(types.CleanTargetType(msg.category)), len(msg.items))
+			if summary := ui.ScanErrorsSummary(msg.scanErrors); summary != "" {
+				m.notice = summary
+			}
+			return m, nil
+		}
 		// Reset state and show new items
 		m.items = msg.items
 		m.selected = make(map[int]bool)
@@ -898,6 +1823,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.results = nil
 		m.err = nil
 		m.scanning = false
+		m.notice = ui.ScanErrorsSummary(msg.scanErrors)
 		m.updateTableRows()
 		return m, nil
 
@@ -912,9 +1838,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentScanning++
 		}
 
-		// If all categories scanned, transition to selecting
+		// If all categories scanned, transition to the default view
 		if m.currentScanning >= len(m.scanningCategories) {
-			m.state = StateSelecting
+			m.state = m.initialViewState()
 			m.updateTableRows()
 			return m, nil
 		}
@@ -926,10 +1852,224 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// diskFreeMsg carries the free space on the home volume (0 = unknown)
+type diskFreeMsg struct {
+	free int64
+}
+
+// refreshDiskFree measures free space on the home volume for the status bar
+func refreshDiskFree() tea.Cmd {
+	return func() tea.Msg {
+		free, err := cleaner.HomeFreeSpace()
+		if err != nil {
+			return diskFreeMsg{}
+		}
+		return diskFreeMsg{free: free}
+	}
+}
+
 // cleanResultMsg is sent when cleaning is complete
 type cleanResultMsg struct {
 	results []cleaner.CleanResult
 	err     error
+	space   *cleaner.SpaceCheck // Nil for dry runs or when space can't be measured
+
+	// Number of items queued when the user stopped the deletion early,
+	// 0 when every item was processed
+	stoppedOf int
+}
+
+// streamResultsMsg carries a batch of streamed scan results
+type streamResultsMsg struct {
+	items      []types.ScanResult
+	done       bool              // Stream closed, scan finished
+	scanErrors []types.ScanError // Categories that failed, once done
+}
+
+// chosenCategories returns the names of the categories toggled on
+func (m Model) chosenCategories() []string {
+	var names []string
+	for i, on := range m.categoryOn {
+		if on {
+			names = append(names, types.Categories[i].Name)
+		}
+	}
+	return names
+}
+
+// startCategoryScan starts scanning the chosen categories and switches to
+// the list (or treemap), which fills in as results stream in. Rescans
+// reuse the same options.
+func (m *Model) startCategoryScan() tea.Cmd {
+	opts := types.DefaultScanOptions()
+	if m.scanOptions != nil {
+		opts = *m.scanOptions
+	}
+	opts.OnlyCategories(m.chosenCategories())
+	m.scanOptions = &opts
+
+	if m.startScan != nil {
+		m.stream, m.streamReport = m.startScan(opts)
+	} else {
+		s, err := scanner.New()
+		if err != nil {
+			m.notice = fmt.Sprintf("Could not start scan: %v", err)
+			return nil
+		}
+		m.stream, m.streamReport = s.ScanAllStreamReport(context.Background(), opts)
+	}
+	m.streaming = true
+	m.state = StateSelecting
+	if m.defaultView == "treemap" {
+		m.state = StateTreemap
+	}
+	return tea.Batch(m.spinner.Tick, m.waitForStream())
+}
+
+// waitForStream blocks for the next streamed result, then drains whatever
+// else is already available so the list updates in batches
+func (m Model) waitForStream() tea.Cmd {
+	stream, report := m.stream, m.streamReport
+	finished := func(batch []types.ScanResult) tea.Msg {
+		msg := streamResultsMsg{items: batch, done: true}
+		if report != nil {
+			msg.scanErrors = report().Errors
+		}
+		return msg
+	}
+	return func() tea.Msg {
+		result, ok := <-stream
+		if !ok {
+			return finished(nil)
+		}
+		batch := []types.ScanResult{result}
+		for {
+			select {
+			case result, ok := <-stream:
+				if !ok {
+					return finished(batch)
+				}
+				batch = append(batch, result)
+			default:
+				return streamResultsMsg{items: batch}
+			}
+		}
+	}
+}
+
+// setItems replaces the item list, keeping it sorted by size. Selections,
+// the cursor and the visual anchor follow their paths since indices shift
+// as items arrive.
+// In tree mode the cursor belongs to the tree and is left alone.
+func (m *Model) setItems(items []types.ScanResult) {
+	selectedPaths := make(map[string]bool)
+	for i, selected := range m.selected {
+		if selected && i < len(m.items) {
+			selectedPaths[m.items[i].Path] = true
+		}
+	}
+	cursorPath := ""
+	if i, ok := m.cursorItem(); ok && !m.treeMode {
+		cursorPath = m.items[i].Path
+	}
+	anchorPath := ""
+	if rows := m.listRows(); m.visualMode && m.visualAnchor < len(rows) && rows[m.visualAnchor].item >= 0 {
+		anchorPath = m.items[rows[m.visualAnchor].item].Path
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Size > items[j].Size
+	})
+
+	m.items = items
+	m.selected = make(map[int]bool)
+	if !m.treeMode {
+		m.cursor = 0
+	}
+	for i, item := range items {
+		if selectedPaths[item.Path] {
+			m.selected[i] = true
+		}
+		if cursorPath != "" && item.Path == cursorPath {
+			m.cursor = m.rowOfItem(i)
+		}
+	}
+	// The visual range follows its anchor item too; if that item is gone
+	// (or the anchor was a group header) the range can't be kept
+	if m.visualMode {
+		m.visualMode = false
+		for i, item := range items {
+			if anchorPath != "" && item.Path == anchorPath {
+				m.visualAnchor = m.rowOfItem(i)
+				m.visualMode = true
+			}
+		}
+	}
+
+	m.itemsTable.SetHeight(m.tableHeight())
+	m.updateTableRows()
+	clear(m.itemStats) // Rescanned items may have changed on disk
+}
+
+// newCleaner creates a cleaner honoring the model's dry-run and log settings
+func (m Model) newCleaner() (*cleaner.Cleaner, error) {
+	var allowedRoots []string
+	if m.scanOptions != nil {
+		allowedRoots = m.scanOptions.ExtraRoots
+	}
+	return cleaner.NewWithOptions(types.CleanOptions{
+		DryRun:       m.dryRun,
+		LogPath:      m.logPath,
+		AllowedRoots: allowedRoots,
+	})
+}
+
+// openResultMsg is sent after trying to reveal a path in Finder
+type openResultMsg struct {
+	path string
+	err  error
+}
+
+// openInFinder opens path in Finder via the macOS `open` command.
+// Docker and simctl pseudo-paths have no folder on disk, so they are ignored.
+func (m Model) openInFinder(path string) tea.Cmd {
+	if types.IsPseudoPath(path) {
+		return nil
+	}
+	return func() tea.Msg {
+		if _, err := exec.LookPath("open"); err != nil {
+			return openResultMsg{path: path, err: fmt.Errorf("'open' command not available")}
+		}
+
+		err := exec.Command("open", path).Run()
+		if c, logErr := m.newCleaner(); logErr == nil {
+			if err != nil {
+				c.Logger().Printf("[OPEN] Failed to open %s: %v\n", path, err)
+			} else {
+				c.Logger().Printf("[OPEN] Opened in Finder: %s\n", path)
+			}
+			c.Close()
+		}
+		return openResultMsg{path: path, err: err}
+	}
+}
+
+// copyResultMsg reports the outcome of copying a path to the clipboard
+type copyResultMsg struct {
+	path string
+	err  error
+}
+
+// copyPath copies path to the macOS clipboard by piping it to `pbcopy`
+func copyPath(path string) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := exec.LookPath("pbcopy"); err != nil {
+			return copyResultMsg{path: path, err: fmt.Errorf("'pbcopy' command not available")}
+		}
+		cmd := exec.Command("pbcopy")
+		cmd.Stdin = strings.NewReader(path)
+		return copyResultMsg{path: path, err: cmd.Run()}
+	}
 }
 
 // deleteProgressMsg is sent to update progress bar
@@ -937,6 +2077,48 @@ type deleteProgressMsg struct {
 	percent float64
 }
 
+// sizeProgress holds the bytes and files counted so far by a tree scan. It is
+// shared by pointer with the scanning goroutine and read on each render.
+type sizeProgress struct {
+	bytes atomic.Int64
+	files atomic.Int64
+	start atomic.Int64 // UnixNano of the last reset, for the files/s rate
+}
+
+// reset clears the counters before a new scan
+func (p *sizeProgress) reset() {
+	p.bytes.Store(0)
+	p.files.Store(0)
+	p.start.Store(time.Now().UnixNano())
+}
+
+// rateLabel renders the files counted per second, e.g. "12.4K files/s",
+// or "" before any progress was reported
+func (p *sizeProgress) rateLabel() string {
+	files := p.files.Load()
+	elapsed := time.Since(time.Unix(0, p.start.Load())).Seconds()
+	if files == 0 || elapsed <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s files/s", ui.FormatCount(int64(float64(files)/elapsed)))
+}
+
+// update is a scanner.SizeProgress callback
+func (p *sizeProgress) update(bytes int64, files int) {
+	p.bytes.Store(bytes)
+	p.files.Store(int64(files))
+}
+
+// String renders e.g. "3.2 GB so far (12402 files)", or "" before any
+// progress was reported
+func (p *sizeProgress) String() string {
+	files := p.files.Load()
+	if files == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s so far (%d files)", ui.FormatSize(p.bytes.Load()), files)
+}
+
 // scanNodeMsg is sent when folder scan completes
 type scanNodeMsg struct {
 	node *types.TreeNode
@@ -945,8 +2127,10 @@ type scanNodeMsg struct {
 
 // rescanItemsMsg is sent when items rescan completes
 type rescanItemsMsg struct {
-	items []types.ScanResult
-	err   error
+	items      []types.ScanResult
+	scanErrors []types.ScanError // Categories that failed
+	err        error
+	category   string // Only this category was rescanned; "" for all
 }
 
 // scanProgressMsg is sent to advance scanning animation
@@ -957,11 +2141,61 @@ type deleteItemProgressMsg struct {
 	index  int
 	status string // "start", "success", "error"
 	err    error
+	freed  int64 // Bytes freed, measured after a failed removal
+
+	duration time.Duration // Time spent in Cleaner.RemoveAll
 }
 
 // deletionTickMsg for UI refresh during deletion
 type deletionTickMsg struct{}
 
+// countdownTickMsg advances the pre-deletion countdown
+type countdownTickMsg struct {
+	id int
+}
+
+// tickCountdown schedules the next countdown second
+func (m Model) tickCountdown() tea.Cmd {
+	id := m.countdownID
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return countdownTickMsg{id: id}
+	})
+}
+
+// startDeletion switches to the deleting state and starts removing
+// m.deletingItems
+func (m *Model) startDeletion() tea.Cmd {
+	m.state = StateDeleting
+	m.percent = 0
+	m.stopRequested = false
+	m.deleteStart = time.Now()
+	m.deletedFiles.Store(0)
+	m.spaceCheck = nil
+	m.spaceMeasured = false
+	if !m.dryRun {
+		var err error
+		m.spaceBefore, err = cleaner.FreeSpace(m.deletingPaths())
+		m.spaceMeasured = err == nil
+	}
+
+	// Start deletion with spinner, progress updates, and continuous tick
+	return tea.Batch(
+		m.spinner.Tick,
+		m.progress.SetPercent(0),
+		m.tickDeletion(), // Start continuous UI refresh
+		m.performClean(),
+	)
+}
+
+// deletingPaths returns the paths of m.deletingItems
+func (m Model) deletingPaths() []string {
+	paths := make([]string, len(m.deletingItems))
+	for i, item := range m.deletingItems {
+		paths[i] = item.Path
+	}
+	return paths
+}
+
 // tickDeletion sends periodic UI refresh messages during deletion
 func (m Model) tickDeletion() tea.Cmd {
 	return tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
@@ -978,15 +2212,20 @@ func (m Model) tickScanning() tea.Cmd {
 
 // rescanItems rescans all items and returns to selection
 func (m Model) rescanItems() tea.Cmd {
+	if m.rescan != nil {
+		rescan := m.rescan
+		return func() tea.Msg {
+			results, err := rescan()
+			return rescanItemsMsg{items: results, err: err}
+		}
+	}
 	return func() tea.Msg {
 		s, err := scanner.New()
 		if err != nil {
 			return rescanItemsMsg{err: err}
 		}
 
-		opts := types.DefaultScanOptions()
-
-		results, err := s.ScanAll(opts)
+		results, scanErrors, err := s.ScanAll(m.rescanOptions())
 		if err != nil {
 			return rescanItemsMsg{err: err}
 		}
@@ -1000,26 +2239,52 @@ func (m Model) rescanItems() tea.Cmd {
 			}
 		}
 
-		return rescanItemsMsg{items: results}
+		return rescanItemsMsg{items: results, scanErrors: scanErrors}
+	}
+}
+
+// rescanOptions returns the options rescans use: the scan's own, or
+// DefaultScanOptions
+func (m Model) rescanOptions() types.ScanOptions {
+	opts := types.DefaultScanOptions()
+	if m.scanOptions != nil {
+		opts = *m.scanOptions
+	}
+	return opts
+}
+
+// rescanCategory rescans just one category (R on an item), with opts
+// limited to it; its results replace that category's items
+func (m Model) rescanCategory(opts types.ScanOptions, category string) tea.Cmd {
+	return func() tea.Msg {
+		s, err := scanner.New()
+		if err != nil {
+			return rescanItemsMsg{err: err, category: category}
+		}
+		results, scanErrors, err := s.ScanAll(opts)
+		return rescanItemsMsg{items: results, scanErrors: scanErrors, err: err, category: category}
 	}
 }
 
 // enterTreeMode transitions from flat list to tree view
 func (m Model) enterTreeMode() tea.Cmd {
 	return func() tea.Msg {
-		if m.cursor >= len(m.items) {
+		i, ok := m.cursorItem()
+		if !ok {
 			return scanNodeMsg{err: fmt.Errorf("invalid cursor position")}
 		}
 
-		item := m.items[m.cursor]
+		item := m.items[i]
 
 		s, err := scanner.New()
 		if err != nil {
 			return scanNodeMsg{err: err}
 		}
+		m.treeProgress.reset()
+		s.SetSizeProgress(m.treeProgress.update)
 
 		// Scan children
-		scanned, err := s.ScanDirectory(item.Path, 0, m.maxDepth)
+		scanned, err := s.ScanDirectoryTyped(item.Path, 0, m.maxDepth, item.Type)
 		if err != nil {
 			return scanNodeMsg{err: err}
 		}
@@ -1036,6 +2301,7 @@ func (m *Model) exitTreeMode() {
 	m.nodeStack = make([]*types.TreeNode, 0)
 	m.cursor = 0
 	m.scanning = false
+	m.updateTableRows() // Items may have streamed in meanwhile
 }
 
 // goBackInTree navigates to parent node
@@ -1085,42 +2351,265 @@ func (m *Model) drillDownInTree() tea.Cmd {
 		return m.scanNode(selectedNode)
 	}
 
-	// Save cursor position before navigating
-	m.cursorStack = append(m.cursorStack, m.cursor)
-	m.nodeStack = append(m.nodeStack, m.currentNode)
-	m.currentNode = selectedNode
-	m.cursor = 0
-	m.updateTreeTableRows()
+	// Save cursor position before navigating
+	m.cursorStack = append(m.cursorStack, m.cursor)
+	m.nodeStack = append(m.nodeStack, m.currentNode)
+	m.currentNode = selectedNode
+	m.cursor = 0
+	m.updateTreeTableRows()
+
+	return nil
+}
+
+// scanNode scans a tree node's children lazily
+func (m Model) scanNode(node *types.TreeNode) tea.Cmd {
+	return func() tea.Msg {
+		s, err := scanner.New()
+		if err != nil {
+			return scanNodeMsg{err: err}
+		}
+		m.treeProgress.reset()
+		s.SetSizeProgress(m.treeProgress.update)
+
+		scanned, err := s.ScanDirectoryTyped(node.Path, node.Depth, m.maxDepth, node.Type)
+		if err != nil {
+			return scanNodeMsg{err: err}
+		}
+
+		node.Children = scanned.Children
+		node.Scanned = true
+
+		return scanNodeMsg{node: node}
+	}
+}
+
+// rescanNode refreshes a node's children, first pruning vanished nodes from
+// the tree (and rescanning the nearest existing folder if node is gone)
+func (m *Model) rescanNode(node *types.TreeNode) tea.Cmd {
+	m.pruneTree()
+	if !pathExists(node.Path) && m.currentNode != nil {
+		node = m.currentNode
+	}
+	return func() tea.Msg {
+		node.Scanned = false
+		node.Children = nil
+		return m.scanNode(node)()
+	}
+}
+
+// largestFileMsg reports the largest file found in a confirmation's top item
+type largestFileMsg struct {
+	dir      string // Item that was walked
+	path     string
+	size     int64
+	complete bool // False when largestFileScanLimit cut the walk short
+}
+
+// enterConfirmation shows the confirmation dialog and starts looking for
+// the largest file in the biggest item about to be deleted, so the user
+// can recognize what a mystery folder holds
+func (m *Model) enterConfirmation() tea.Cmd {
+	m.state = StateConfirming
+	m.largestFile = nil
+
+	top, ok := m.topConfirmItem()
+	if !ok || types.IsPseudoPath(top.Path) {
+		return nil
+	}
+	return func() tea.Msg {
+		path, size, complete := scanner.LargestFile(top.Path, largestFileScanLimit)
+		return largestFileMsg{dir: top.Path, path: path, size: size, complete: complete}
+	}
+}
+
+// topConfirmItem returns the largest item the confirmation is about
+func (m Model) topConfirmItem() (types.ScanResult, bool) {
+	var top types.ScanResult
+	var found bool
+	consider := func(item types.ScanResult) {
+		if !found || item.Size > top.Size {
+			top, found = item, true
+		}
+	}
+	if len(m.deletingItems) > 0 {
+		for _, item := range m.deletingItems {
+			consider(item)
+		}
+	} else {
+		for i, item := range m.items {
+			if m.selected[i] {
+				consider(item)
+			}
+		}
+	}
+	return top, found
+}
+
+// dangerConfirmWord must be typed to confirm a deletion above the danger
+// thresholds
+const dangerConfirmWord = "DELETE"
+
+// confirmTotals returns the item count, size and file count the
+// confirmation screen is about: a tree quick clean's items, or the selection
+func (m Model) confirmTotals() (count int, size int64, files int64) {
+	items := m.confirmItems()
+	for _, item := range items {
+		size += item.Size
+		files += int64(item.FileCount)
+	}
+	return len(items), size, files
+}
+
+// confirmItems returns the items being confirmed: a tree quick clean's
+// item, or the selected ones
+func (m Model) confirmItems() []types.ScanResult {
+	if len(m.deletingItems) > 0 {
+		return m.deletingItems
+	}
+	var items []types.ScanResult
+	for i, item := range m.items {
+		if m.selected[i] {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// dangerous reports whether the pending real deletion exceeds the danger
+// size or count, so DELETE has to be typed to confirm it
+func (m Model) dangerous() bool {
+	if m.dryRun {
+		return false
+	}
+	count, size, _ := m.confirmTotals()
+	return (m.dangerCount > 0 && count > m.dangerCount) || (m.dangerSize > 0 && size > m.dangerSize)
+}
+
+// confirmDeletion starts the confirmed deletion: the countdown for a real
+// one, the deletion itself for a dry-run
+func (m *Model) confirmDeletion() tea.Cmd {
+	m.riskyConfirmed = false
+	m.dangerTyped = ""
+
+	// Prepare deletion list (tree quick clean already set it up)
+	if !m.returnToTree {
+		m.deletingItems = []types.ScanResult{}
+		for i, item := range m.items {
+			if m.selected[i] {
+				m.deletingItems = append(m.deletingItems, item)
+			}
+		}
+	}
+
+	// Never offer to delete something that is already gone
+	var vanished int
+	m.deletingItems, vanished = existingItems(m.deletingItems)
+	if vanished > 0 {
+		m.notice = fmt.Sprintf("Skipped %d item(s) that no longer exist", vanished)
+	}
+	if len(m.deletingItems) == 0 {
+		m.leaveConfirmation()
+		return nil
+	}
+	m.deleteComplete = make(map[int]bool)
+	m.deleteStatus = make(map[int]string)
+	m.deleteFreed = make(map[int]int64)
+	m.deleteTimes = make(map[int]time.Duration)
+	m.currentDeleting = 0
+
+	// Dry-runs delete nothing, so they skip the abort window
+	if m.dryRun {
+		return m.startDeletion()
+	}
+	m.state = StateCountdown
+	m.countdown = deleteCountdownSeconds
+	m.countdownID++
+	return m.tickCountdown()
+}
+
+// leaveConfirmation cancels the confirmation screen, returning to the tree
+// if the deletion started there
+func (m *Model) leaveConfirmation() {
+	m.riskyConfirmed = false
+	m.dangerTyped = ""
+	// A tree quick clean's item must not show up in the next confirmation
+	m.deletingItems = nil
+	// Check if we came from tree mode
+	if m.returnToTree && m.savedTreeState != nil {
+		// Return to tree mode
+		m.state = StateTree
+		m.treeMode = true
+		m.currentNode = m.savedTreeState.parentNode
+		m.nodeStack = m.savedTreeState.nodeStack
+		m.cursor = m.savedTreeState.cursorPos
+		m.returnToTree = false
+		m.savedTreeState = nil
+		m.pruneTree()
+		m.updateTreeTableRows()
+		return
+	}
+	// Normal return to selection
+	m.state = StateSelecting
+}
 
-	return nil
+// pathExists reports whether path is still on disk. Pseudo-paths (Docker,
+// simctl) are always treated as present.
+func pathExists(path string) bool {
+	if types.IsPseudoPath(path) {
+		return true
+	}
+	_, err := os.Lstat(path)
+	return err == nil
 }
 
-// scanNode scans a tree node's children lazily
-func (m Model) scanNode(node *types.TreeNode) tea.Cmd {
-	return func() tea.Msg {
-		s, err := scanner.New()
-		if err != nil {
-			return scanNodeMsg{err: err}
+// existingItems drops items whose paths vanished since they were scanned
+// and returns how many were dropped
+func existingItems(items []types.ScanResult) ([]types.ScanResult, int) {
+	kept := make([]types.ScanResult, 0, len(items))
+	for _, item := range items {
+		if pathExists(item.Path) {
+			kept = append(kept, item)
 		}
+	}
+	return kept, len(items) - len(kept)
+}
 
-		scanned, err := s.ScanDirectory(node.Path, node.Depth, m.maxDepth)
-		if err != nil {
-			return scanNodeMsg{err: err}
+// pruneVanished removes children of node (recursing into scanned children)
+// whose paths no longer exist, subtracting their sizes from node. It returns
+// the bytes removed.
+func pruneVanished(node *types.TreeNode) int64 {
+	var removed int64
+	kept := node.Children[:0]
+	for _, child := range node.Children {
+		if !pathExists(child.Path) {
+			removed += child.Size
+			continue
 		}
-
-		node.Children = scanned.Children
-		node.Scanned = true
-
-		return scanNodeMsg{node: node}
+		removed += pruneVanished(child)
+		kept = append(kept, child)
 	}
+	node.Children = kept
+	node.Size -= removed
+	return removed
 }
 
-// rescanNode refreshes a node's children
-func (m Model) rescanNode(node *types.TreeNode) tea.Cmd {
-	return func() tea.Msg {
-		node.Scanned = false
-		node.Children = nil
-		return m.scanNode(node)()
+// pruneTree drops vanished nodes from the tree being browsed and moves up
+// to the nearest existing folder if the current one is gone
+func (m *Model) pruneTree() {
+	if m.currentNode == nil {
+		return
+	}
+	root := m.currentNode
+	if len(m.nodeStack) > 0 {
+		root = m.nodeStack[0]
+	}
+	pruneVanished(root)
+
+	for !pathExists(m.currentNode.Path) && len(m.nodeStack) > 0 {
+		m.goBackInTree()
+	}
+	if m.cursor >= len(m.currentNode.Children) {
+		m.cursor = max(len(m.currentNode.Children)-1, 0)
 	}
 }
 
@@ -1132,10 +2621,10 @@ func (m *Model) prepareTreeDeletion() tea.Cmd {
 		if selected {
 			// Find the node in the tree
 			node := m.findNodeByPath(m.currentNode, path)
-			if node != nil {
+			if node != nil && pathExists(node.Path) {
 				selectedItems = append(selectedItems, types.ScanResult{
 					Path:      node.Path,
-					Type:      types.TypeNode, // Generic type for tree items
+					Type:      node.Type, // Inherited from the scan result being browsed
 					Size:      node.Size,
 					FileCount: node.FileCount,
 					Name:      node.Name,
@@ -1147,7 +2636,6 @@ func (m *Model) prepareTreeDeletion() tea.Cmd {
 	// If we have selected items, prepare for deletion
 	if len(selectedItems) > 0 {
 		// Exit tree mode
-		m.state = StateConfirming
 		m.treeMode = false
 		m.currentNode = nil
 		m.nodeStack = make([]*types.TreeNode, 0)
@@ -1160,6 +2648,7 @@ func (m *Model) prepareTreeDeletion() tea.Cmd {
 		for i := range m.items {
 			m.selected[i] = true
 		}
+		return m.enterConfirmation()
 	}
 
 	return nil
@@ -1183,28 +2672,49 @@ func (m Model) findNodeByPath(root *types.TreeNode, path string) *types.TreeNode
 	return nil
 }
 
-// performClean deletes a single item and returns a command to continue
+// performClean deletes a single item and returns a command to continue.
+// Once every item is processed, or the user asked to stop, it finishes with
+// the results of the items processed so far.
 func (m Model) performClean() tea.Cmd {
 	// Check if all items are processed
-	if m.currentDeleting >= len(m.deletingItems) {
+	if m.currentDeleting >= len(m.deletingItems) || m.stopRequested {
 		// All done, collect results and finish
 		var results []cleaner.CleanResult
-		for i, item := range m.deletingItems {
+		for i, item := range m.deletingItems[:m.currentDeleting] {
 			success := m.deleteComplete[i] && m.deleteStatus[i] != "error"
 			var err error
+			freed := item.Size
 			if !success && m.deleteStatus[i] == "error" {
 				err = fmt.Errorf("deletion failed")
+				freed = m.deleteFreed[i]
 			}
 			results = append(results, cleaner.CleanResult{
 				Path:      item.Path,
 				Size:      item.Size,
+				FreedSize: freed,
 				Success:   success,
 				Error:     err,
 				WasDryRun: m.dryRun,
+				Duration:  m.deleteTimes[i],
 			})
 		}
 		return func() tea.Msg {
-			return cleanResultMsg{results: results, err: nil}
+			if c, err := m.newCleaner(); err == nil {
+				c.ClearResume()
+				c.RecordStats(results)
+				c.Close()
+			}
+			msg := cleanResultMsg{results: results, err: nil}
+			if m.currentDeleting < len(m.deletingItems) {
+				msg.stoppedOf = len(m.deletingItems)
+			}
+			if m.spaceMeasured {
+				if after, err := cleaner.FreeSpace(m.deletingPaths()); err == nil {
+					check := cleaner.VerifySpace(m.spaceBefore, after, results)
+					msg.space = &check
+				}
+			}
+			return msg
 		}
 	}
 
@@ -1213,7 +2723,7 @@ func (m Model) performClean() tea.Cmd {
 	item := m.deletingItems[idx]
 
 	return func() tea.Msg {
-		c, err := cleaner.New(m.dryRun)
+		c, err := m.newCleaner()
 		if err != nil {
 			return deleteItemProgressMsg{
 				index:  idx,
@@ -1223,8 +2733,11 @@ func (m Model) performClean() tea.Cmd {
 		}
 		defer c.Close()
 
+		// Quitting during this item leaves it and the rest for clean --resume
+		c.SaveResume(m.deletingItems[idx:])
+
 		// Validate path safety
-		if err := cleaner.ValidatePath(item.Path); err != nil {
+		if err := c.ValidatePath(item.Path); err != nil {
 			return deleteItemProgressMsg{
 				index:  idx,
 				status: "error",
@@ -1235,6 +2748,22 @@ func (m Model) performClean() tea.Cmd {
 		// Send start message first (for immediate UI update)
 		time.Sleep(200 * time.Millisecond) // Initial delay to show "deleting" state
 
+		// Docker and simctl items are removed by running their tool
+		if types.IsPseudoPath(item.Path) {
+			result := c.CleanPseudoPath(item)
+			if !result.Success {
+				return deleteItemProgressMsg{
+					index:  idx,
+					status: "error",
+					err:    result.Error,
+				}
+			}
+			return deleteItemProgressMsg{
+				index:  idx,
+				status: "success",
+			}
+		}
+
 		// Perform deletion
 		if m.dryRun {
 			c.Logger().Printf("[DRY-RUN] Would delete: %s (%.2f MB)\n", item.Path, float64(item.Size)/(1024*1024))
@@ -1247,12 +2776,17 @@ func (m Model) performClean() tea.Cmd {
 		} else {
 			c.Logger().Printf("[DELETE] Removing: %s (%.2f MB)\n", item.Path, float64(item.Size)/(1024*1024))
 
-			if err := os.RemoveAll(item.Path); err != nil {
+			start := time.Now()
+			err := c.RemoveAll(item.Path, m.deletedFiles)
+			elapsed := time.Since(start)
+			if err != nil {
 				c.Logger().Printf("[ERROR] Failed to delete %s: %v\n", item.Path, err)
 				return deleteItemProgressMsg{
-					index:  idx,
-					status: "error",
-					err:    err,
+					index:    idx,
+					status:   "error",
+					err:      err,
+					freed:    cleaner.FreedAfterFailure(item.Path, item.Size),
+					duration: elapsed,
 				}
 			}
 
@@ -1260,8 +2794,9 @@ func (m Model) performClean() tea.Cmd {
 			// Delay to show success state
 			time.Sleep(200 * time.Millisecond)
 			return deleteItemProgressMsg{
-				index:  idx,
-				status: "success",
+				index:    idx,
+				status:   "success",
+				duration: elapsed,
 			}
 		}
 	}
@@ -1298,6 +2833,9 @@ func (m Model) View() string {
 	case StateConfirming:
 		content = m.renderConfirmation(&b)
 
+	case StateCountdown:
+		content = m.renderCountdown(&b)
+
 	case StateTree:
 		content = m.renderTreeView(&b)
 
@@ -1307,6 +2845,12 @@ func (m Model) View() string {
 	case StateSelecting:
 		content = m.renderSelection(&b)
 
+	case StateTreemap:
+		content = m.renderTreemap(&b)
+
+	case StateCategories:
+		content = m.renderCategories(&b)
+
 	default:
 		content = b.String()
 	}
@@ -1316,6 +2860,28 @@ func (m Model) View() string {
 	return content + "\n\n" + statusBar
 }
 
+// renderCategories renders the category checklist shown before scanning
+func (m Model) renderCategories(b *strings.Builder) string {
+	b.WriteString(statusStyle.Render("Choose what to scan"))
+	b.WriteString("\n\n")
+
+	for i, category := range types.Categories {
+		cursor := "  "
+		if i == m.categoryCursor {
+			cursor = cursorStyle.Render("▸ ")
+		}
+		checkbox := "[ ]"
+		if m.categoryOn[i] {
+			checkbox = checkboxStyle.Render("[✓]")
+		}
+		b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, checkbox, category.Label))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑/↓: Navigate • Space: Toggle • a: All • n: None • Enter: Scan • q: Quit"))
+	return b.String()
+}
+
 // renderScanning renders the animated scanning progress
 func (m Model) renderScanning(b *strings.Builder) string {
 	b.WriteString(successStyle.Render("🔍 Scanning for development artifacts...\n\n"))
@@ -1359,6 +2925,9 @@ func (m Model) renderTreeView(b *strings.Builder) string {
 	if m.currentNode == nil {
 		// Show loading animation while waiting for scan
 		loadingMsg := fmt.Sprintf("%s Loading directory tree...", m.spinner.View())
+		if progress := m.treeProgress.String(); progress != "" {
+			loadingMsg += " " + progress
+		}
 		b.WriteString(statusStyle.Render(loadingMsg))
 		b.WriteString("\n\n")
 		b.WriteString(helpStyle.Render("Please wait while scanning directory structure..."))
@@ -1382,7 +2951,11 @@ func (m Model) renderTreeView(b *strings.Builder) string {
 	// Scanning indicator
 	if m.scanning {
 		b.WriteString(m.spinner.View())
-		b.WriteString(" Scanning folder...\n\n")
+		b.WriteString(" Scanning folder...")
+		if progress := m.treeProgress.String(); progress != "" {
+			b.WriteString(" " + progress)
+		}
+		b.WriteString("\n\n")
 	}
 
 	// Children list - use table
@@ -1402,7 +2975,7 @@ func (m Model) renderTreeView(b *strings.Builder) string {
 	}
 
 	// Help
-	help := "\n\n↑/↓: Navigate • →/l: Drill down • ←/h: Go back • Space: Toggle • c: Quick Clean Current • Esc: Exit • q: Quit"
+	help := "\n\n↑/↓: Navigate • →/l: Drill down • ←/h: Go back • Space: Toggle • c: Quick Clean Current • o: Open • s: Sort • Esc: Exit • q: Quit"
 	b.WriteString(helpStyle.Render(help))
 
 	return b.String()
@@ -1530,33 +3103,58 @@ func (m Model) renderDeleting(b *strings.Builder) string {
 	// Summary
 	b.WriteString("\n")
 	summary := fmt.Sprintf("Progress: %d/%d items", completedItems, totalItems)
+	// Files/s shows a long RemoveAll of a huge node_modules is still moving
+	if files := m.deletedFiles.Load(); files > 0 {
+		rate := float64(files) / time.Since(m.deleteStart).Seconds()
+		summary += fmt.Sprintf(" • %s files removed (%s files/s)", ui.FormatCount(files), ui.FormatCount(int64(rate)))
+	}
 	b.WriteString(helpStyle.Render(summary))
 
 	// Help
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("Please wait... Press q to cancel"))
+	if m.stopRequested {
+		b.WriteString(helpStyle.Render("Stopping after the current item..."))
+	} else {
+		b.WriteString(helpStyle.Render("Please wait... Esc/x: stop after this item • q: quit"))
+	}
 
 	return b.String()
 }
 
-// renderConfirmation shows the confirmation dialog
-func (m Model) renderConfirmation(b *strings.Builder) string {
-	// Calculate count and size based on source
-	var selectedCount int
-	var selectedSize int64
-
-	// Check if coming from tree mode (has deletingItems)
-	if len(m.deletingItems) > 0 {
-		// Tree mode - calculate from deletingItems
-		selectedCount = len(m.deletingItems)
-		for _, item := range m.deletingItems {
-			selectedSize += item.Size
+// deleteThroughput renders the bytes freed per second of the running
+// deletion and the time left for the rest at that rate, e.g.
+// "1.2 GB/s • ETA 8s"; "" until the first item has been freed
+func (m Model) deleteThroughput() string {
+	var freed, total int64
+	for i, item := range m.deletingItems {
+		total += item.Size
+		if !m.deleteComplete[i] {
+			continue
 		}
-	} else {
-		// Normal selection mode
-		selectedCount = m.countSelected()
-		selectedSize = m.selectedSize()
+		if m.deleteStatus[i] == "error" {
+			freed += m.deleteFreed[i]
+		} else {
+			freed += item.Size
+		}
+	}
+	elapsed := time.Since(m.deleteStart).Seconds()
+	if freed <= 0 || elapsed <= 0 {
+		return ""
+	}
+
+	rate := float64(freed) / elapsed
+	label := ui.FormatSize(int64(rate)) + "/s"
+	if remaining := total - freed; remaining > 0 && m.currentDeleting < len(m.deletingItems) {
+		eta := time.Duration(float64(remaining) / rate * float64(time.Second))
+		label += " • ETA " + eta.Round(time.Second).String()
 	}
+	return label
+}
+
+// renderConfirmation shows the confirmation dialog
+func (m Model) renderConfirmation(b *strings.Builder) string {
+	// Calculate count and size based on source (tree quick clean or selection)
+	selectedCount, selectedSize, selectedFiles := m.confirmTotals()
 
 	// Confirmation box style - wider to show paths
 	confirmBoxStyle := lipgloss.NewStyle().
@@ -1614,27 +3212,125 @@ func (m Model) renderConfirmation(b *strings.Builder) string {
 				confirmMsg.WriteString(fmt.Sprintf("  ... and %d more items\n", remaining))
 				break
 			}
+			path := item.Path
+			if label := ui.ReclaimableLabel(item); label != "" {
+				path += sizeStyle.Render(" (" + label + ")")
+			}
 			confirmMsg.WriteString(fmt.Sprintf("  %s %s  %s\n",
 				pathStyle.Render("✗"),
 				sizeStyle.Render(fmt.Sprintf("[%s]", ui.FormatSize(item.Size))),
-				item.Path,
+				path,
 			))
 			displayCount++
 		}
 	}
 
 	confirmMsg.WriteString(fmt.Sprintf("\n  Total: %d items • %s\n\n", selectedCount, ui.FormatSize(selectedSize)))
-	confirmMsg.WriteString("  Press [y] to confirm, [n] to cancel")
+
+	// The exact entries the log will get, for review before confirming
+	if m.previewLog {
+		confirmMsg.WriteString("  Log entries to be written:\n")
+		lines := cleaner.PreviewLog(m.confirmItems(), m.dryRun)
+		for i, line := range lines {
+			if i >= maxDisplay {
+				confirmMsg.WriteString(fmt.Sprintf("  ... and %d more entries\n", len(lines)-maxDisplay))
+				break
+			}
+			confirmMsg.WriteString(sizeStyle.Render("  "+line) + "\n")
+		}
+		confirmMsg.WriteString("\n")
+	}
+
+	// What the biggest item holds, e.g. a simulator runtime or stray data
+	if lf := m.largestFile; lf != nil && lf.path != "" {
+		rel, err := filepath.Rel(lf.dir, lf.path)
+		if err != nil {
+			rel = lf.path
+		}
+		line := fmt.Sprintf("  Largest file in %s: %s (%s)", filepath.Base(lf.dir), rel, ui.FormatSize(lf.size))
+		if !lf.complete {
+			line += fmt.Sprintf(" among the first %d files", largestFileScanLimit)
+		}
+		confirmMsg.WriteString(sizeStyle.Render(line))
+		confirmMsg.WriteString("\n\n")
+	}
+
+	// Hundreds of thousands of tiny files take minutes to remove
+	if selectedFiles >= slowDeleteFiles {
+		confirmMsg.WriteString(warningStyle.Render(fmt.Sprintf("  ⚠️  %s files — deletion may take several minutes", ui.FormatCount(selectedFiles))))
+		confirmMsg.WriteString("\n\n")
+	}
+
+	// Past the danger thresholds, DELETE has to be typed out
+	if m.dangerous() {
+		confirmMsg.WriteString(errorStyle.Render(fmt.Sprintf("  ⚠ This deletes %d items (%s), past your danger threshold", selectedCount, ui.FormatSize(selectedSize))))
+		confirmMsg.WriteString("\n\n")
+		confirmMsg.WriteString(fmt.Sprintf("  Type %s and press [Enter] to confirm, [Esc] to cancel: %s▌", dangerConfirmWord, m.dangerTyped))
+	} else if risky := m.countSelectedRisky(); risky > 0 && !m.returnToTree {
+		// Risky items (recently used venvs, a linked pnpm store, recently edited projects) require a second confirmation
+		confirmMsg.WriteString(warningStyle.Render(fmt.Sprintf("  ⚠ %d selected items may still be in use", risky)))
+		confirmMsg.WriteString("\n\n")
+		if m.riskyConfirmed {
+			confirmMsg.WriteString("  Press [y] again to delete them anyway, [n] to cancel")
+		} else {
+			confirmMsg.WriteString("  Press [y] twice to confirm, [n] to cancel")
+		}
+	} else {
+		confirmMsg.WriteString("  Press [y] to confirm, [n] to cancel")
+	}
 
 	b.WriteString(confirmBoxStyle.Render(confirmMsg.String()))
 	return b.String()
 }
 
+// renderCountdown shows the abort window before permanent deletion
+func (m Model) renderCountdown(b *strings.Builder) string {
+	countdownStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#EF4444")).
+		Padding(1, 2).
+		Width(60)
+
+	var size int64
+	for _, item := range m.deletingItems {
+		size += item.Size
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+
+	// e.g. "3... 2... 1..." with the remaining seconds highlighted
+	var steps []string
+	for i := deleteCountdownSeconds; i >= 1; i-- {
+		step := fmt.Sprintf("%d...", i)
+		if i == m.countdown {
+			step = errorStyle.Render(step)
+		} else {
+			step = mutedStyle.Render(step)
+		}
+		steps = append(steps, step)
+	}
+
+	var msg strings.Builder
+	msg.WriteString(errorStyle.Render(fmt.Sprintf("🗑  Deleting %d items (%s) in", len(m.deletingItems), ui.FormatSize(size))))
+	msg.WriteString("\n\n  ")
+	msg.WriteString(strings.Join(steps, " "))
+	msg.WriteString("\n\n  Press [esc] to abort")
+
+	b.WriteString(countdownStyle.Render(msg.String()))
+	return b.String()
+}
+
 // renderSelection shows the item selection list using table
 func (m Model) renderSelection(b *strings.Builder) string {
 	// Render table (already updated in Update())
 	b.WriteString(m.itemsTable.View())
 	b.WriteString("\n")
+	if len(m.items) == 0 && !m.streaming {
+		b.WriteString("\n  📭 No cleanable items found.\n")
+	}
+	if i, ok := m.cursorItem(); ok {
+		b.WriteString(m.renderItemDetail(m.items[i]))
+	}
 
 	// Status bar
 	selectedCount := m.countSelected()
@@ -1650,7 +3346,170 @@ func (m Model) renderSelection(b *strings.Builder) string {
 	b.WriteString(tipStyle.Render(m.currentTip))
 
 	// Help
-	help := "\n\n↑/↓: Navigate • Space: Toggle • a: All • n: None • c: Quick Clean Current • Enter: Clean Selected • ?: Help • q: Quit"
+	help := "\n\n↑/↓: Navigate • Space: Toggle • v: Visual • a: All • n: None • c: Quick Clean Current • o: Open • Enter: Clean Selected • t: Treemap • g: Group • ?: Help • q: Quit"
+	if m.visualMode {
+		start, end := m.visualRange()
+		help = fmt.Sprintf("\n\n-- VISUAL -- %d items marked • ↑/↓: Extend • Space/Enter: Toggle range • Esc/v: Cancel", end-start+1)
+	}
+	b.WriteString(helpStyle.Render(help))
+
+	return b.String()
+}
+
+// statItem returns the mtime and direct subfolder count of path, reading
+// the disk only the first time a path is highlighted
+func (m Model) statItem(path string) itemStat {
+	if st, ok := m.itemStats[path]; ok {
+		return st
+	}
+
+	var st itemStat
+	if filepath.IsAbs(path) {
+		if info, err := os.Stat(path); err == nil {
+			st.modTime = info.ModTime()
+			st.ok = true
+			if entries, err := os.ReadDir(path); err == nil {
+				for _, entry := range entries {
+					if entry.IsDir() {
+						st.subdirs++
+					}
+				}
+			}
+		}
+	}
+	if m.itemStats != nil {
+		m.itemStats[path] = st
+	}
+	return st
+}
+
+// renderItemDetail shows the metadata of the highlighted item below the
+// list: full path, type, size, file count, age and subfolders
+func (m Model) renderItemDetail(item types.ScanResult) string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	pathStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#E5E7EB"))
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(labelStyle.Render("  Path:     "))
+	b.WriteString(pathStyle.Render(item.Path))
+	b.WriteString("\n")
+
+	info := fmt.Sprintf("%s • %s • %s • %s", item.CategoryLabel(), ui.SizeLabel(item), ui.FileCountLabel(item), item.Tier())
+	b.WriteString(labelStyle.Render("  Details:  "))
+	b.WriteString(info)
+	b.WriteString("\n")
+
+	if st := m.statItem(item.Path); st.ok {
+		modified := fmt.Sprintf("%s (%s) • %d subfolders",
+			st.modTime.Format("2006-01-02 15:04"), formatAge(time.Since(st.modTime)), st.subdirs)
+		b.WriteString(labelStyle.Render("  Modified: "))
+		b.WriteString(modified)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// formatAge renders a duration as a coarse "3 days ago"
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%d min ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%d h ago", int(d.Hours()))
+	case d < 48*time.Hour:
+		return "yesterday"
+	default:
+		return fmt.Sprintf("%d days ago", int(d.Hours()/24))
+	}
+}
+
+// initialViewState returns the view to land on once items are shown
+func (m Model) initialViewState() State {
+	if m.defaultView == "treemap" && len(m.items) > 0 {
+		return StateTreemap
+	}
+	return StateSelecting
+}
+
+// treemapIndices returns indices into m.items of the largest items, biggest first
+func (m Model) treemapIndices() []int {
+	indices := make([]int, len(m.items))
+	for i := range m.items {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return m.items[indices[a]].Size > m.items[indices[b]].Size
+	})
+
+	limit := treemapMaxItems
+	if m.height > 0 && m.height-12 < limit {
+		limit = m.height - 12
+	}
+	if limit < 5 {
+		limit = 5
+	}
+	if len(indices) > limit {
+		indices = indices[:limit]
+	}
+	return indices
+}
+
+// renderTreemap shows the top items as bars scaled to the largest one
+func (m Model) renderTreemap(b *strings.Builder) string {
+	indices := m.treemapIndices()
+
+	var totalSize int64
+	for _, item := range m.items {
+		totalSize += item.Size
+	}
+
+	header := fmt.Sprintf("📊 Top %d of %d items by size  •  %s total", len(indices), len(m.items), ui.FormatSize(totalSize))
+	b.WriteString(statusStyle.Render(header))
+	b.WriteString("\n\n")
+
+	var maxSize int64
+	if len(indices) > 0 {
+		maxSize = m.items[indices[0]].Size
+	}
+
+	barWidth := 30
+	if m.width > 0 && m.width-70 > barWidth {
+		barWidth = m.width - 70
+		if barWidth > 60 {
+			barWidth = 60
+		}
+	}
+
+	for row, idx := range indices {
+		item := m.items[idx]
+
+		cursor := "  "
+		if row == m.cursor {
+			cursor = cursorStyle.Render("▸ ")
+		}
+		checkbox := "[ ]"
+		if m.selected[idx] {
+			checkbox = checkboxStyle.Render("[✓]")
+		}
+
+		bar := ui.RenderProgressBar(item.Size, maxSize, barWidth)
+		line := fmt.Sprintf("%s%s %s %s %s  %s",
+			cursor,
+			checkbox,
+			m.getTypeBadge(item.Type),
+			bar,
+			m.getSizeStyle(item.Size).Render(ui.SizeLabel(item)),
+			item.Name,
+		)
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	help := "\n↑/↓: Navigate • Space: Toggle • Enter: Clean Selected • t/Esc: List View • ?: Help • q: Quit"
 	b.WriteString(helpStyle.Render(help))
 
 	return b.String()
@@ -1682,23 +3541,37 @@ func (m Model) renderHelp(b *strings.Builder) string {
 	help.WriteString(headerStyle.Render("Main List Navigation"))
 	help.WriteString("\n")
 	help.WriteString(fmt.Sprintf("  %s        Move up/down\n", keyStyle.Render("↑/↓ or k/j")))
+	help.WriteString(fmt.Sprintf("  %s         Previous/next page of the list (--page-size rows)\n", keyStyle.Render("PgUp/PgDn")))
+	help.WriteString(fmt.Sprintf("  %s          Jump to first/last item\n", keyStyle.Render("Home/End")))
 	help.WriteString(fmt.Sprintf("  %s          Toggle selection\n", keyStyle.Render("Space")))
 	help.WriteString(fmt.Sprintf("  %s              Select all items\n", keyStyle.Render("a")))
 	help.WriteString(fmt.Sprintf("  %s              Deselect all items\n", keyStyle.Render("n")))
+	help.WriteString(fmt.Sprintf("  %s              Select all items of the current item's type\n", keyStyle.Render("A")))
 	help.WriteString(fmt.Sprintf("  %s              Quick clean current item only\n", keyStyle.Render("c")))
+	help.WriteString(fmt.Sprintf("  %s              Open current item in Finder\n", keyStyle.Render("o")))
+	help.WriteString(fmt.Sprintf("  %s              Copy current item's path to the clipboard\n", keyStyle.Render("y")))
 	help.WriteString(fmt.Sprintf("  %s          Clean all selected items\n", keyStyle.Render("Enter")))
 	help.WriteString(fmt.Sprintf("  %s        Drill down into folder (tree mode)\n", keyStyle.Render("→ or l")))
+	help.WriteString(fmt.Sprintf("  %s              Visual mode: mark a range, Space toggles it\n", keyStyle.Render("v")))
+	help.WriteString(fmt.Sprintf("  %s              Toggle treemap size chart\n", keyStyle.Render("t")))
+	help.WriteString(fmt.Sprintf("  %s              Group by project; Space on a project toggles all of it\n", keyStyle.Render("g")))
+	help.WriteString(fmt.Sprintf("  %s              Rescan only the current item's category\n", keyStyle.Render("R")))
+	help.WriteString(fmt.Sprintf("  %s        Expand/collapse a project (grouped list)\n", keyStyle.Render("→/← or l/h")))
 	help.WriteString("\n")
 
 	// Tree Navigation
 	help.WriteString(headerStyle.Render("Tree Navigation Mode"))
 	help.WriteString("\n")
 	help.WriteString(fmt.Sprintf("  %s        Move up/down in current folder\n", keyStyle.Render("↑/↓ or k/j")))
+	help.WriteString(fmt.Sprintf("  %s         Page up/down, Home/End jump to first/last\n", keyStyle.Render("PgUp/PgDn")))
 	help.WriteString(fmt.Sprintf("  %s        Drill deeper into subfolder\n", keyStyle.Render("→ or l")))
 	help.WriteString(fmt.Sprintf("  %s        Go back to parent folder\n", keyStyle.Render("← or h")))
 	help.WriteString(fmt.Sprintf("  %s          Toggle selection\n", keyStyle.Render("Space")))
 	help.WriteString(fmt.Sprintf("  %s              Quick clean current item\n", keyStyle.Render("c")))
+	help.WriteString(fmt.Sprintf("  %s              Open current item in Finder\n", keyStyle.Render("o")))
+	help.WriteString(fmt.Sprintf("  %s              Copy current item's path to the clipboard\n", keyStyle.Render("y")))
 	help.WriteString(fmt.Sprintf("  %s              Refresh current folder\n", keyStyle.Render("r")))
+	help.WriteString(fmt.Sprintf("  %s              Sort by size (default) or name\n", keyStyle.Render("s")))
 	help.WriteString(fmt.Sprintf("  %s            Exit tree mode\n", keyStyle.Render("Esc")))
 	help.WriteString("\n")
 
@@ -1708,6 +3581,8 @@ func (m Model) renderHelp(b *strings.Builder) string {
 	help.WriteString("  • 'c' key: Clears all selections and cleans ONLY current item\n")
 	help.WriteString("  • Enter: Cleans ALL selected items (batch operation)\n")
 	help.WriteString("  • Dry-run is ON by default - files are safe until confirmed\n")
+	help.WriteString("  • With --confirm, deletion starts after a 3s countdown (Esc aborts)\n")
+	help.WriteString("  • While deleting, Esc or x stops after the current item\n")
 	help.WriteString("  • All deletions are logged to ~/.dev-cleaner.log\n")
 	help.WriteString("  • Tree mode: Delete items at any level, auto-refresh after\n")
 	help.WriteString("\n")
@@ -1717,7 +3592,8 @@ func (m Model) renderHelp(b *strings.Builder) string {
 	help.WriteString("\n")
 	help.WriteString("  🍎 Xcode • 🤖 Android • 📦 Node.js • 🐦 Flutter\n")
 	help.WriteString("  🐍 Python • 🦀 Rust • 🐹 Go • 🍺 Homebrew\n")
-	help.WriteString("  🐳 Docker • ☕ Java/Kotlin\n")
+	help.WriteString("  🐳 Docker • ☕ Java/Kotlin • 🦕 Deno • 🟣 .NET/Unity\n")
+	help.WriteString("  🐘 PHP/Composer • 💧 Elixir/Erlang • λ Haskell\n")
 	help.WriteString("\n")
 
 	// Tips
@@ -1744,61 +3620,59 @@ func (m Model) renderResults(b *strings.Builder) string {
 		return b.String()
 	}
 
-	var successCount int
-	var freedSize int64
 	for _, r := range m.results {
+		// Slow items are pointed out, e.g. folders of millions of files
+		took := ""
+		if label := r.TookLabel(); label != "" {
+			took = " (" + label + ")"
+		}
 		if r.Success {
-			successCount++
-			freedSize += r.Size
 			if r.WasDryRun {
 				b.WriteString(fmt.Sprintf("  [DRY-RUN] Would delete: %s\n", r.Path))
 			} else {
-				b.WriteString(successStyle.Render(fmt.Sprintf("  ✓ Deleted: %s\n", r.Path)))
+				b.WriteString(successStyle.Render(fmt.Sprintf("  ✓ Deleted: %s%s\n", r.Path, took)))
 			}
 		} else {
-			b.WriteString(errorStyle.Render(fmt.Sprintf("  ✗ Failed: %s\n", r.Path)))
+			b.WriteString(errorStyle.Render(fmt.Sprintf("  ✗ Failed: %s%s\n", r.Path, took)))
+			if r.FreedSize > 0 {
+				b.WriteString(fmt.Sprintf("    partially removed: %s of %s freed\n", ui.FormatSize(r.FreedSize), ui.FormatSize(r.Size)))
+			}
 		}
 	}
 
+	successCount, freedSize, notFreed := cleaner.SummarizeResults(m.results)
 	summary := fmt.Sprintf("\n✅ Completed: %d items", successCount)
+	if m.stoppedOf > 0 {
+		summary = fmt.Sprintf("\n⏹  Stopped: %d of %d cleaned", successCount, m.stoppedOf)
+	}
 	if m.dryRun {
 		summary += fmt.Sprintf(" (would free %s)", ui.FormatSize(freedSize))
+	} else if notFreed > 0 {
+		summary += fmt.Sprintf(" (%s freed, %s could not be removed)", ui.FormatSize(freedSize), ui.FormatSize(notFreed))
 	} else {
 		summary += fmt.Sprintf(" (%s freed)", ui.FormatSize(freedSize))
 	}
 	b.WriteString(successStyle.Render(summary))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if m.spaceCheck != nil {
+		b.WriteString(fmt.Sprintf("   %s\n", m.spaceCheck))
+		if m.spaceCheck.Diverges() {
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B")).Render("   ⚠ Free space differs from the estimate (hard links, sparse files or other disk activity)"))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
 	b.WriteString(helpStyle.Render("r/Enter: Rescan • Esc: Back • q: Quit"))
 
 	return b.String()
 }
 
 func (m Model) getTypeBadge(t types.CleanTargetType) string {
-	style := lipgloss.NewStyle().Width(10).Bold(true)
-	switch t {
-	case types.TypeXcode:
-		return style.Foreground(lipgloss.Color("#147EFB")).Render(string(t))
-	case types.TypeAndroid:
-		return style.Foreground(lipgloss.Color("#3DDC84")).Render(string(t))
-	case types.TypeNode:
-		return style.Foreground(lipgloss.Color("#68A063")).Render(string(t))
-	case types.TypeFlutter:
-		return style.Foreground(lipgloss.Color("#02569B")).Render(string(t))
-	case types.TypePython:
-		return style.Foreground(lipgloss.Color("#3776AB")).Render(string(t)) // Python blue
-	case types.TypeRust:
-		return style.Foreground(lipgloss.Color("#DEA584")).Render(string(t)) // Rust orange
-	case types.TypeGo:
-		return style.Foreground(lipgloss.Color("#00ADD8")).Render(string(t)) // Go cyan
-	case types.TypeHomebrew:
-		return style.Foreground(lipgloss.Color("#FBB040")).Render(string(t)) // Homebrew yellow
-	case types.TypeDocker:
-		return style.Foreground(lipgloss.Color("#2496ED")).Render(string(t)) // Docker blue
-	case types.TypeJava:
-		return style.Foreground(lipgloss.Color("#ED8B00")).Render(string(t)) // Java orange
-	default:
-		return style.Render(string(t))
+	style := lipgloss.NewStyle().Width(16).Bold(true)
+	if color := types.CategoryColor(t); color != "" {
+		style = style.Foreground(lipgloss.Color(color))
 	}
+	return style.Render(types.CategoryLabel(t))
 }
 
 func (m Model) countSelected() int {
@@ -1811,6 +3685,17 @@ func (m Model) countSelected() int {
 	return count
 }
 
+// countSelectedRisky counts selected items flagged as Risky by the scanner
+func (m Model) countSelectedRisky() int {
+	count := 0
+	for i, selected := range m.selected {
+		if selected && i < len(m.items) && m.items[i].Risky {
+			count++
+		}
+	}
+	return count
+}
+
 func (m Model) selectedSize() int64 {
 	var size int64
 	for i, selected := range m.selected {
@@ -1821,6 +3706,15 @@ func (m Model) selectedSize() int64 {
 	return size
 }
 
+// diskFreeLabel formats the home volume's free space, e.g. "Disk: 42.0 GB
+// free", or returns "" while it is unknown
+func (m Model) diskFreeLabel() string {
+	if m.diskFree <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("Disk: %s free", ui.FormatSize(m.diskFree))
+}
+
 // renderStatusBar creates a unified status bar based on current state
 func (m Model) renderStatusBar() string {
 	var left, center, right string
@@ -1849,6 +3743,17 @@ func (m Model) renderStatusBar() string {
 			totalSize += item.Size
 		}
 		left = fmt.Sprintf("[SELECT] %d items • %s", len(m.items), ui.FormatSize(totalSize))
+		if m.visualMode {
+			left = fmt.Sprintf("[VISUAL] %d items • %s", len(m.items), ui.FormatSize(totalSize))
+		}
+		if page, pages := m.pageInfo(); pages > 1 {
+			left += fmt.Sprintf(" • page %d/%d", page, pages)
+		}
+		if m.streaming {
+			left += " • " + m.spinner.View() + "scanning"
+		} else if m.rescanning {
+			left += " • " + m.spinner.View() + "rescanning..."
+		}
 
 		// Center: Selected info
 		selectedCount := m.countSelected()
@@ -1859,6 +3764,9 @@ func (m Model) renderStatusBar() string {
 			center = "No items selected"
 		}
 
+		// Right: Free space, to compare with the selection
+		right = m.diskFreeLabel()
+
 	case StateTree:
 		// Left: State + Current path
 		if m.currentNode != nil {
@@ -1866,8 +3774,16 @@ func (m Model) renderStatusBar() string {
 
 			// Center: Folder info
 			center = fmt.Sprintf("%s • %d items", ui.FormatSize(m.currentNode.Size), m.currentNode.FileCount)
+			if m.treeSortByName {
+				center += " • Sort: name"
+			} else {
+				center += " • Sort: size"
+			}
 			if m.scanning {
 				center += " • Scanning..."
+				if rate := m.treeProgress.rateLabel(); rate != "" {
+					center += " " + rate
+				}
 			}
 
 			// Depth indicator
@@ -1882,6 +3798,26 @@ func (m Model) renderStatusBar() string {
 		// Right: Key hints
 		right = "→:drill ←:back space:toggle c:quick esc:exit q:quit"
 
+	case StateTreemap:
+		// Left: State + Item count
+		left = fmt.Sprintf("[TREEMAP] %d items", len(m.items))
+		if m.streaming {
+			left += " • " + m.spinner.View() + "scanning"
+		} else if m.rescanning {
+			left += " • " + m.spinner.View() + "rescanning..."
+		}
+
+		// Center: Selected info
+		selectedCount := m.countSelected()
+		if selectedCount > 0 {
+			center = fmt.Sprintf("Selected: %d • %s", selectedCount, ui.FormatSize(m.selectedSize()))
+		} else {
+			center = "No items selected"
+		}
+
+		// Right: Key hints
+		right = "t:list space:toggle q:quit"
+
 	case StateConfirming:
 		// Left: State
 		left = "[CONFIRM]"
@@ -1893,6 +3829,22 @@ func (m Model) renderStatusBar() string {
 
 		// Right: Key hints
 		right = "y:yes n:no"
+		if m.dangerous() {
+			right = "type " + dangerConfirmWord + " enter:confirm esc:cancel"
+		}
+
+	case StateCategories:
+		left = "[CATEGORIES]"
+		center = fmt.Sprintf("%d of %d selected", len(m.chosenCategories()), len(m.categoryOn))
+		if m.notice != "" {
+			center = m.notice
+		}
+		right = m.diskFreeLabel()
+
+	case StateCountdown:
+		left = "[COUNTDOWN]"
+		center = fmt.Sprintf("Deleting %d items in %ds", len(m.deletingItems), m.countdown)
+		right = "esc:abort"
 
 	case StateDeleting:
 		// Left: State + Progress
@@ -1902,34 +3854,41 @@ func (m Model) renderStatusBar() string {
 		selectedCount := m.countSelected()
 		processed := int(float64(selectedCount) * m.percent)
 		center = fmt.Sprintf("%d/%d items", processed, selectedCount)
+		if throughput := m.deleteThroughput(); throughput != "" {
+			center += " • " + throughput
+		}
 
-		// Right: Elapsed time
+		// Right: Elapsed time, and free space climbing as items go
 		deleteElapsed := time.Since(m.deleteStart)
 		right = fmt.Sprintf("Elapsed: %ds", int(deleteElapsed.Seconds()))
+		if disk := m.diskFreeLabel(); disk != "" {
+			right += " • " + disk
+		}
 
 	case StateDone:
 		// Left: State
 		left = "[DONE]"
 
 		// Center: Summary
-		var successCount int
-		var freedSize int64
-		for _, r := range m.results {
-			if r.Success {
-				successCount++
-				freedSize += r.Size
-			}
-		}
+		successCount, freedSize, _ := cleaner.SummarizeResults(m.results)
 		if m.dryRun {
 			center = fmt.Sprintf("✓ %d items • Would free %s", successCount, ui.FormatSize(freedSize))
 		} else {
 			center = fmt.Sprintf("✓ %d items • %s freed", successCount, ui.FormatSize(freedSize))
 		}
+		if disk := m.diskFreeLabel(); disk != "" {
+			center += " • " + disk
+		}
 
 		// Right: Deletion time (frozen when completed) + hints
 		right = fmt.Sprintf("Total: %ds • r:rescan esc:back q:quit", int(m.deleteDuration.Seconds()))
 	}
 
+	// A pending notice replaces the center section until the next key press
+	if m.notice != "" {
+		center = m.notice
+	}
+
 	// Build status bar with sections
 	leftPart := statusLeftStyle.Render(left)
 	centerPart := statusCenterStyle.Render(center)
@@ -1967,7 +3926,29 @@ func (m Model) renderStatusBar() string {
 
 // Run starts the TUI
 func Run(items []types.ScanResult, dryRun bool, version string) error {
-	m := NewModel(items, dryRun, version)
+	return RunWithOptions(items, dryRun, version, Options{})
+}
+
+// RunStream starts the TUI immediately and fills the list from stream
+func RunStream(stream <-chan types.ScanResult, dryRun bool, version string, opts Options) error {
+	m := NewStreamModel(stream, dryRun, version, opts)
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
+
+// RunCategories starts the TUI on the category screen; it scans once the
+// user has chosen what to scan
+func RunCategories(dryRun bool, version string, opts Options) error {
+	m := NewCategoriesModel(dryRun, version, opts)
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
+
+// RunWithOptions starts the TUI with optional behavior
+func RunWithOptions(items []types.ScanResult, dryRun bool, version string, opts Options) error {
+	m := NewModelWithOptions(items, dryRun, version, opts)
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	_, err := p.Run()
 	return err
@@ -0,0 +1,590 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// press sends one key to m and returns the updated model
+func press(t *testing.T, m Model, k string) Model {
+	t.Helper()
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	switch k {
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case " ":
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(k)}
+	}
+	updated, _ := m.Update(msg)
+	return updated.(Model)
+}
+
+func TestTreeQuickCleanThenNormalClean(t *testing.T) {
+	root := t.TempDir()
+	var items []types.ScanResult
+	for i, name := range []string{"web/node_modules", "api/node_modules"} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Join(path, "pkg"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, types.ScanResult{Path: path, Name: name, Type: types.TypeNode, Size: int64(2000 - i)})
+	}
+	treeChild := filepath.Join(items[0].Path, "pkg")
+
+	m := NewModelWithOptions(items, true, "test", Options{})
+	m.state = StateTree
+	m.treeMode = true
+	m.currentNode = &types.TreeNode{
+		Path:     items[0].Path,
+		Children: []*types.TreeNode{{Path: treeChild, Name: "pkg", Size: 100, IsDir: true}},
+	}
+
+	// Quick clean in the tree, then back out of it
+	m = press(t, m, "c")
+	if m.state != StateConfirming || len(m.deletingItems) != 1 {
+		t.Fatalf("tree quick clean: state %v, %d deleting items", m.state, len(m.deletingItems))
+	}
+	m = press(t, m, "n")
+	m = press(t, m, "esc")
+	if m.state != StateSelecting {
+		t.Fatalf("state = %v, want StateSelecting", m.state)
+	}
+
+	// A normal clean confirms the list selection, not the old tree item
+	m.selected = map[int]bool{1: true}
+	m = press(t, m, "enter")
+	if m.state != StateConfirming {
+		t.Fatalf("state = %v, want StateConfirming", m.state)
+	}
+	if len(m.deletingItems) != 0 {
+		t.Errorf("stale deletingItems %v", m.deletingItems)
+	}
+	if top, _ := m.topConfirmItem(); top.Path != items[1].Path {
+		t.Errorf("confirmation shows %s, want %s", top.Path, items[1].Path)
+	}
+
+	// Finishing a clean clears its items as well
+	m.deletingItems = []types.ScanResult{items[1]}
+	updated, _ := m.Update(cleanResultMsg{})
+	if m = updated.(Model); len(m.deletingItems) != 0 {
+		t.Errorf("deletingItems after clean = %v, want none", m.deletingItems)
+	}
+}
+
+func TestStatusBarDiskFree(t *testing.T) {
+	m := NewModelWithOptions([]types.ScanResult{{Path: "/tmp/x", Name: "x", Size: 1024}}, true, "test", Options{})
+	if bar := m.renderStatusBar(); strings.Contains(bar, "Disk:") {
+		t.Errorf("status bar shows disk space before it was measured: %q", bar)
+	}
+
+	updated, _ := m.Update(diskFreeMsg{free: 42 << 30})
+	m = updated.(Model)
+	if bar := m.renderStatusBar(); !strings.Contains(bar, "Disk: 42.0 GB free") {
+		t.Errorf("status bar = %q, want free disk space", bar)
+	}
+}
+
+func TestStopDuringDeletion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	var items []types.ScanResult
+	for _, name := range []string{"a/node_modules", "b/node_modules", "c/node_modules"} {
+		items = append(items, types.ScanResult{Path: filepath.Join(root, name), Name: name, Type: types.TypeNode, Size: 1000})
+	}
+
+	m := NewModelWithOptions(items, true, "test", Options{})
+	m.deletingItems = items
+	m.state = StateDeleting
+
+	// Stop while the first item is being removed
+	m = press(t, m, "x")
+	if !m.stopRequested || m.state != StateDeleting {
+		t.Fatalf("after x: stopRequested %v, state %v", m.stopRequested, m.state)
+	}
+	if view := m.View(); !strings.Contains(view, "Stopping after the current item") {
+		t.Errorf("deleting view does not show the pending stop:\n%s", view)
+	}
+
+	updated, _ := m.Update(deleteItemProgressMsg{index: 0, status: "success"})
+	m = updated.(Model)
+	updated, _ = m.Update(m.performClean()())
+	m = updated.(Model)
+
+	if m.state != StateDone || len(m.results) != 1 || m.results[0].Path != items[0].Path {
+		t.Fatalf("state %v, results %+v; want StateDone with only the first item", m.state, m.results)
+	}
+	if view := m.View(); !strings.Contains(view, "Stopped: 1 of 3 cleaned") {
+		t.Errorf("results view does not show the stop:\n%s", view)
+	}
+}
+
+func TestStreamReportErrorsInNotice(t *testing.T) {
+	stream := make(chan types.ScanResult, 1)
+	stream <- types.ScanResult{Path: "/tmp/a/node_modules", Name: "a", Type: types.TypeNode, Size: 10}
+	close(stream)
+	m := NewStreamModel(stream, true, "test", Options{
+		StreamReport: func() types.ScanReport {
+			return types.ScanReport{Errors: []types.ScanError{{Category: "docker", Message: "daemon error"}}}
+		},
+	})
+
+	updated, _ := m.Update(m.waitForStream()())
+	m = updated.(Model)
+	if m.streaming || len(m.items) != 1 {
+		t.Fatalf("after stream: streaming %v, %d items", m.streaming, len(m.items))
+	}
+	if !strings.Contains(m.notice, "docker failed: daemon error") {
+		t.Errorf("notice = %q, want the docker failure", m.notice)
+	}
+}
+
+func TestCustomRescan(t *testing.T) {
+	items := []types.ScanResult{{Path: "/d/big", Name: "big", Type: types.TypeGeneric, Size: 100}}
+	m := NewModelWithOptions(items, true, "test", Options{
+		Rescan: func() ([]types.ScanResult, error) {
+			return []types.ScanResult{{Path: "/d/bigger", Name: "bigger", Type: types.TypeGeneric, Size: 200}}, nil
+		},
+	})
+	m.state = StateSelecting
+
+	// No per-category rescan, the custom one replaces the full rescan
+	m = press(t, m, "R")
+	if m.rescanning || m.notice == "" {
+		t.Fatalf("R with a custom rescan: rescanning %v, notice %q", m.rescanning, m.notice)
+	}
+	updated, _ := m.Update(m.rescanItems()())
+	m = updated.(Model)
+	if len(m.items) != 1 || m.items[0].Path != "/d/bigger" {
+		t.Errorf("items after rescan = %+v, want the custom rescan's", m.items)
+	}
+}
+
+func TestCategoriesStartScan(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	opts := types.ScanOptions{IncludeNode: true}
+	var scanned types.ScanOptions
+	m := NewCategoriesModel(true, "test", Options{
+		ScanOptions: &opts,
+		StartScan: func(o types.ScanOptions) (<-chan types.ScanResult, func() types.ScanReport) {
+			scanned = o
+			stream := make(chan types.ScanResult)
+			close(stream)
+			return stream, nil
+		},
+	})
+	for i, category := range types.Categories {
+		if want := category.Name == "node"; m.categoryOn[i] != want {
+			t.Errorf("category %s preselected %v, want %v", category.Name, m.categoryOn[i], want)
+		}
+	}
+
+	// Nothing selected: Enter stays on the checklist
+	m = press(t, m, "n")
+	m = press(t, m, "enter")
+	if m.state != StateCategories || m.notice == "" {
+		t.Fatalf("enter with no category: state %v, notice %q", m.state, m.notice)
+	}
+
+	// Toggle the first category and scan it
+	m = press(t, m, " ")
+	m = press(t, m, "enter")
+	first := types.Categories[0].Name
+	if m.state != StateSelecting || !m.streaming {
+		t.Fatalf("after enter: state %v, streaming %v", m.state, m.streaming)
+	}
+	for _, category := range types.Categories {
+		if want := category.Name == first; scanned.CategoryEnabled(category.Name) != want {
+			t.Errorf("scanned %s: %v, want %v", category.Name, !want, want)
+		}
+	}
+}
+
+func TestDeleteThroughput(t *testing.T) {
+	items := []types.ScanResult{
+		{Path: "/tmp/a", Name: "a", Size: 3 << 20},
+		{Path: "/tmp/b", Name: "b", Size: 3 << 20},
+	}
+	m := NewModelWithOptions(items, true, "test", Options{})
+	m.deletingItems = items
+	m.state = StateDeleting
+	m.deleteStart = time.Now().Add(-2 * time.Second)
+
+	if got := m.deleteThroughput(); got != "" {
+		t.Errorf("before anything was freed: %q, want empty", got)
+	}
+
+	// The first 3 MB took 2s: 1.5 MB/s, 2s left for the second item
+	m.deleteComplete[0] = true
+	m.deleteStatus[0] = "success"
+	m.currentDeleting = 1
+	if got := m.deleteThroughput(); got != "1.5 MB/s • ETA 2s" {
+		t.Errorf("deleteThroughput() = %q, want %q", got, "1.5 MB/s • ETA 2s")
+	}
+	if bar := m.renderStatusBar(); !strings.Contains(bar, "ETA 2s") {
+		t.Errorf("status bar does not show the ETA:\n%s", bar)
+	}
+}
+
+func TestDangerConfirmation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	var items []types.ScanResult
+	for _, name := range []string{"a", "b", "c"} {
+		path := filepath.Join(root, name)
+		if err := os.Mkdir(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, types.ScanResult{Path: path, Name: name, Type: types.TypeNode, Size: 1000})
+	}
+
+	m := NewModelWithOptions(items, false, "test", Options{DangerCount: 2})
+	for i := range items {
+		m.selected[i] = true
+	}
+	m.state = StateConfirming
+	if !m.dangerous() {
+		t.Fatal("3 items over a danger count of 2 is not dangerous")
+	}
+
+	backspace := func() {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		m = updated.(Model)
+	}
+
+	// y does nothing, a wrong word does nothing
+	m = press(t, m, "y")
+	m = press(t, m, "enter")
+	if m.state != StateConfirming {
+		t.Fatalf("after y+enter: state %v, want StateConfirming", m.state)
+	}
+	backspace()
+	m = press(t, m, "DELETX")
+	m = press(t, m, "enter")
+	if m.state != StateConfirming {
+		t.Fatalf("after a typo: state %v, want StateConfirming", m.state)
+	}
+	if view := m.View(); !strings.Contains(view, "Type DELETE") {
+		t.Errorf("confirmation does not ask for DELETE:\n%s", view)
+	}
+
+	backspace()
+	m = press(t, m, "E")
+	m = press(t, m, "enter")
+	if m.state != StateCountdown || len(m.deletingItems) != 3 {
+		t.Fatalf("after DELETE: state %v, %d items; want StateCountdown with 3", m.state, len(m.deletingItems))
+	}
+
+	// Dry-runs delete nothing and keep the plain y
+	dry := NewModelWithOptions(items, true, "test", Options{DangerCount: 2})
+	for i := range items {
+		dry.selected[i] = true
+	}
+	if dry.dangerous() {
+		t.Error("a dry-run is dangerous")
+	}
+}
+
+func TestCountdownAbortKeepsRiskyConfirm(t *testing.T) {
+	root := t.TempDir()
+	var items []types.ScanResult
+	for _, name := range []string{"venv", "cache"} {
+		path := filepath.Join(root, name)
+		if err := os.Mkdir(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, types.ScanResult{Path: path, Name: name, Type: types.TypePython, Size: 1000, Risky: name == "venv"})
+	}
+
+	m := NewModelWithOptions(items, false, "test", Options{})
+	m.selected[0], m.selected[1] = true, true
+	m.state = StateConfirming
+
+	m = press(t, m, "y")
+	m = press(t, m, "y")
+	if m.state != StateCountdown {
+		t.Fatalf("after y y: state %v, want StateCountdown", m.state)
+	}
+	m = press(t, m, "esc")
+	if m.state != StateConfirming || len(m.deletingItems) != 0 {
+		t.Fatalf("after abort: state %v, %d deleting items; want StateConfirming with none", m.state, len(m.deletingItems))
+	}
+	if view := m.View(); !strings.Contains(view, "may still be in use") {
+		t.Errorf("risky prompt missing after abort:\n%s", view)
+	}
+
+	// A single y must not skip the risky double-confirm
+	m = press(t, m, "y")
+	if m.state != StateConfirming {
+		t.Fatalf("after abort and one y: state %v, want StateConfirming", m.state)
+	}
+}
+
+func TestTreeDepthFromScanOptions(t *testing.T) {
+	if m := NewModelWithOptions(nil, true, "test", Options{}); m.maxDepth != types.DefaultTreeDepth {
+		t.Errorf("default tree depth = %d, want %d", m.maxDepth, types.DefaultTreeDepth)
+	}
+	// Tree depth is independent of how deep projects were searched
+	opts := types.ScanOptions{ProjectSearchDepth: 6, MaxDepth: 2}
+	if m := NewModelWithOptions(nil, true, "test", Options{ScanOptions: &opts}); m.maxDepth != 2 {
+		t.Errorf("tree depth = %d, want 2", m.maxDepth)
+	}
+}
+
+func TestConfirmationPreviewLog(t *testing.T) {
+	items := []types.ScanResult{{Path: "/p/a", Name: "a", Type: types.TypeNode, Size: 1024 * 1024}}
+
+	m := NewModelWithOptions(items, true, "test", Options{})
+	m.selected[0] = true
+	m.state = StateConfirming
+	if view := m.View(); strings.Contains(view, "Log entries") {
+		t.Error("log entries shown without PreviewLog")
+	}
+
+	m = NewModelWithOptions(items, true, "test", Options{PreviewLog: true})
+	m.selected[0] = true
+	m.state = StateConfirming
+	view := m.View()
+	if !strings.Contains(view, "Log entries to be written") || !strings.Contains(view, "[DRY-RUN] Would delete: /p/a (1.00 MB)") {
+		t.Errorf("confirmation does not preview the log entry:\n%s", view)
+	}
+}
+
+func TestGroupByProject(t *testing.T) {
+	w := t.TempDir()
+	for _, manifest := range []string{"proj-a/package.json", "proj-b/Cargo.toml"} {
+		path := filepath.Join(w, manifest)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items := []types.ScanResult{
+		{Path: filepath.Join(w, "proj-a/node_modules"), Name: "proj-a/node_modules", Type: types.TypeNode, Size: 300, SafetyTier: types.TierInactive},
+		{Path: filepath.Join(w, "proj-b/target"), Name: "proj-b/target", Type: types.TypeRust, Size: 200, SafetyTier: types.TierInactive},
+		{Path: filepath.Join(w, "proj-a/ios/build"), Name: "proj-a - iOS Build", Type: types.TypeReactNative, Size: 100, SafetyTier: types.TierInactive},
+	}
+
+	m := NewModelWithOptions(items, true, "test", Options{GroupByProject: true})
+	m.state = StateSelecting
+	if rows := m.listRows(); len(rows) != 5 || rows[0].item != -1 || rows[1].item != 0 || rows[2].item != 2 {
+		t.Fatalf("grouped rows = %+v, want proj-a header, 0, 2, then proj-b", rows)
+	}
+	if view := m.View(); !strings.Contains(view, "proj-a (total 400 B)") {
+		t.Errorf("view has no proj-a total:\n%s", view)
+	}
+
+	// Space on a header selects the whole project, again deselects it
+	m = press(t, m, " ")
+	if !m.selected[0] || !m.selected[2] || m.selected[1] {
+		t.Fatalf("selected %v, want proj-a's items 0 and 2", m.selected)
+	}
+	m = press(t, m, " ")
+	if m.countSelected() != 0 {
+		t.Fatalf("selected %v after toggling again, want none", m.selected)
+	}
+
+	// Collapsing hides the project's items, h collapses from an item
+	m = press(t, m, "l")
+	if rows := m.listRows(); len(rows) != 3 || m.cursor != 0 {
+		t.Fatalf("collapsed rows = %+v, cursor %d", rows, m.cursor)
+	}
+	m = press(t, m, "l")
+	m = press(t, m, "j")
+	m = press(t, m, "h")
+	if rows := m.listRows(); len(rows) != 3 || m.cursor != 0 {
+		t.Fatalf("after h: rows = %+v, cursor %d", rows, m.cursor)
+	}
+
+	// g returns to the flat list, keeping the cursor on the same item
+	m = press(t, m, "l")
+	m = press(t, m, "j")
+	m = press(t, m, "j")
+	m = press(t, m, "g")
+	if i, ok := m.cursorItem(); !ok || i != 2 || m.cursor != 2 {
+		t.Errorf("flat list cursor = %d (item %d), want item 2", m.cursor, i)
+	}
+}
+
+func TestRescanCategory(t *testing.T) {
+	items := []types.ScanResult{
+		{Path: "/w/app/node_modules", Type: types.TypeNode, Size: 300},
+		{Path: "/home/.cache/go-build", Type: types.TypeGo, Size: 200},
+		{Path: "/w/old/node_modules", Type: types.TypeNode, Size: 100},
+	}
+	m := NewModelWithOptions(items, true, "test", Options{})
+	m.state = StateSelecting
+	m = press(t, m, "j")
+	m = press(t, m, " ")
+
+	// R on an item starts a rescan limited to its category
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = updated.(Model)
+	if cmd == nil || !m.rescanning {
+		t.Fatal("R did not start a rescan")
+	}
+
+	// The results replace only that category; the Go item stays selected
+	updated, _ = m.Update(rescanItemsMsg{
+		category: "go",
+		items:    []types.ScanResult{{Path: "/home/.cache/go-build", Type: types.TypeGo, Size: 50}},
+	})
+	m = updated.(Model)
+	if m.rescanning || len(m.items) != 3 {
+		t.Fatalf("items = %+v, rescanning %v", m.items, m.rescanning)
+	}
+	if m.items[2].Type != types.TypeGo || m.items[2].Size != 50 || !m.selected[2] {
+		t.Errorf("go item = %+v (selected %v), want the rescanned one, still selected", m.items[2], m.selected[2])
+	}
+	if m.items[0].Path != "/w/app/node_modules" || m.items[1].Path != "/w/old/node_modules" {
+		t.Errorf("node items changed: %+v", m.items)
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	var items []types.ScanResult
+	for i := 0; i < 25; i++ {
+		items = append(items, types.ScanResult{Path: fmt.Sprintf("/w/app-%02d/node_modules", i), Type: types.TypeNode, Size: int64(100 - i)})
+	}
+	m := NewModelWithOptions(items, true, "test", Options{PageSize: 10})
+	m.state = StateSelecting
+	if rows := len(m.itemsTable.Rows()); rows != 10 {
+		t.Fatalf("table has %d rows, want one page of 10", rows)
+	}
+
+	// PgDn moves to the same row of the next page; selections stay keyed
+	// by item index
+	m = press(t, m, "j")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	m = updated.(Model)
+	m = press(t, m, " ")
+	if m.cursor != 11 || !m.selected[11] || m.itemsTable.Cursor() != 1 {
+		t.Fatalf("cursor %d (table %d), selected %v; want item 11 on row 1 of page 2", m.cursor, m.itemsTable.Cursor(), m.selected)
+	}
+	if view := m.View(); !strings.Contains(view, "page 2/3") {
+		t.Errorf("status bar has no page 2/3:\n%s", view)
+	}
+
+	// The last page holds the remaining 5 rows
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	m = updated.(Model)
+	if page, pages := m.pageInfo(); page != 3 || pages != 3 || len(m.itemsTable.Rows()) != 5 {
+		t.Errorf("page %d/%d with %d rows, want 3/3 with 5", page, pages, len(m.itemsTable.Rows()))
+	}
+}
+
+func TestStreamBatchDuringTreeMode(t *testing.T) {
+	root := t.TempDir()
+	items := []types.ScanResult{{Path: filepath.Join(root, "web", "node_modules"), Type: types.TypeNode, Size: 100}}
+	m := NewModelWithOptions(items, true, "test", Options{})
+	m.state = StateTree
+	m.treeMode = true
+	m.streaming = true
+	m.currentNode = &types.TreeNode{Path: items[0].Path}
+	for _, name := range []string{"a", "b", "c"} {
+		m.currentNode.Children = append(m.currentNode.Children, &types.TreeNode{Path: filepath.Join(items[0].Path, name), Name: name, Size: 10, IsDir: true})
+	}
+	m.cursor = 2
+	m.updateTreeTableRows()
+
+	// A larger result streams in: the tree cursor stays on child c
+	updated, _ := m.Update(streamResultsMsg{items: []types.ScanResult{{Path: filepath.Join(root, "api", "target"), Type: types.TypeRust, Size: 500}}})
+	m = updated.(Model)
+	if m.cursor != 2 || len(m.items) != 2 {
+		t.Fatalf("cursor = %d with %d items, want 2 with 2", m.cursor, len(m.items))
+	}
+	m = press(t, m, "c")
+	if len(m.deletingItems) != 1 || filepath.Base(m.deletingItems[0].Path) != "c" {
+		t.Errorf("quick clean targets %+v, want child c", m.deletingItems)
+	}
+}
+
+func TestStreamBatchKeepsVisualAnchor(t *testing.T) {
+	items := []types.ScanResult{
+		{Path: "/w/a/node_modules", Type: types.TypeNode, Size: 300},
+		{Path: "/w/b/node_modules", Type: types.TypeNode, Size: 200},
+		{Path: "/w/c/node_modules", Type: types.TypeNode, Size: 100},
+	}
+	m := NewModelWithOptions(items, true, "test", Options{})
+	m.state = StateSelecting
+	m.streaming = true
+	m = press(t, m, "j")
+	m = press(t, m, "v")
+
+	// A larger result lands above the anchor, shifting every row down
+	updated, _ := m.Update(streamResultsMsg{items: []types.ScanResult{{Path: "/w/d/target", Type: types.TypeRust, Size: 500}}})
+	m = updated.(Model)
+	m = press(t, m, "j")
+	m = press(t, m, " ")
+
+	var got []string
+	for i, selected := range m.selected {
+		if selected {
+			got = append(got, m.items[i].Path)
+		}
+	}
+	sort.Strings(got)
+	want := []string{"/w/b/node_modules", "/w/c/node_modules"}
+	if !slices.Equal(got, want) {
+		t.Errorf("selected %v, want %v", got, want)
+	}
+}
+
+func TestRescanCategorySharedScan(t *testing.T) {
+	gradle := "/home/.gradle/caches/modules-2"
+	items := []types.ScanResult{
+		{Path: gradle, Type: types.TypeJava, Size: 300},
+		{Path: "/w/api/build", Type: types.TypeJava, Size: 200},
+		{Path: "/home/Library/Android/sdk/system-images/android-30", Type: types.TypeAndroid, Size: 100},
+	}
+	m := NewModelWithOptions(items, true, "test", Options{})
+	m.state = StateSelecting
+	m = press(t, m, " ")
+
+	// Android's rescan runs gradle too, which returns Java items
+	updated, _ := m.Update(rescanItemsMsg{
+		category: "android",
+		items: []types.ScanResult{
+			{Path: "/home/Library/Android/sdk/system-images/android-30", Type: types.TypeAndroid, Size: 100},
+			{Path: gradle, Type: types.TypeJava, Size: 250},
+		},
+	})
+	m = updated.(Model)
+	if len(m.items) != 3 {
+		t.Fatalf("items = %+v, want each path once", m.items)
+	}
+	for i, item := range m.items {
+		if item.Path == gradle && (item.Size != 250 || !m.selected[i]) {
+			t.Errorf("gradle item = %+v (selected %v), want the rescanned size, still selected", item, m.selected[i])
+		}
+	}
+}
+
+func TestScanningCategoriesFollowTypeDisplays(t *testing.T) {
+	items := []types.ScanResult{
+		{Path: "/w/app/ios/Pods", Type: types.TypeReactNative, Size: 100},
+		{Path: "/w/web/node_modules", Type: types.TypeNode, Size: 200},
+		{Path: "/home/Library/Developer/Xcode/DerivedData", Type: types.TypeXcode, Size: 300},
+	}
+	m := NewModelWithOptions(items, true, "test", Options{})
+	want := []string{
+		types.CategoryLabel(types.TypeXcode),
+		types.CategoryLabel(types.TypeNode),
+		types.CategoryLabel(types.TypeReactNative),
+	}
+	if !slices.Equal(m.scanningCategories, want) {
+		t.Errorf("scanningCategories = %v, want %v", m.scanningCategories, want)
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+// Output formats supported by the scan command
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+// ValidateFormat checks that format is one of the supported output formats
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatTable, FormatJSON, FormatCSV:
+		return nil
+	default:
+		return fmt.Errorf("invalid format %q (must be one of: table, json, csv)", format)
+	}
+}
+
+// WriteCSV writes results as CSV with a header row, one line per result
+func WriteCSV(w io.Writer, results []types.ScanResult) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"path", "type", "name", "size_bytes", "size_human", "file_count"}); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		record := []string{
+			r.Path,
+			string(r.Type),
+			r.Name,
+			strconv.FormatInt(r.Size, 10),
+			FormatSize(r.Size),
+			strconv.Itoa(r.FileCount),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTreeJSON writes a folder hierarchy as indented, nested JSON
+func WriteTreeJSON(w io.Writer, node *types.TreeNode) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(node)
+}
+
+// WriteJSON writes results as an indented JSON array
+func WriteJSON(w io.Writer, results []types.ScanResult) error {
+	if results == nil {
+		results = []types.ScanResult{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
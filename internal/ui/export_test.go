@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
+)
+
+func TestValidateFormat(t *testing.T) {
+	for _, format := range []string{"table", "json", "csv"} {
+		if err := ValidateFormat(format); err != nil {
+			t.Errorf("ValidateFormat(%s) unexpected error: %v", format, err)
+		}
+	}
+	if err := ValidateFormat("xml"); err == nil {
+		t.Error("ValidateFormat(xml) expected error")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	results := []types.ScanResult{
+		{Path: "/Users/me/app/node_modules", Type: types.TypeNode, Name: "app/node_modules", Size: 2048, FileCount: 12},
+		{Path: "/Users/me/a,b", Type: types.TypeXcode, Name: "comma, name", Size: 10, FileCount: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, results); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %q", len(lines), buf.String())
+	}
+	if lines[0] != "path,type,name,size_bytes,size_human,file_count" {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	if lines[1] != "/Users/me/app/node_modules,node,app/node_modules,2048,2.0 KB,12" {
+		t.Errorf("unexpected row: %s", lines[1])
+	}
+	if lines[2] != `"/Users/me/a,b",xcode,"comma, name",10,10 B,1` {
+		t.Errorf("fields with commas should be quoted: %s", lines[2])
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("nil results should encode as [], got %s", buf.String())
+	}
+
+	buf.Reset()
+	results := []types.ScanResult{{Path: "/tmp/x", Type: types.TypeGo, Size: 5}}
+	if err := WriteJSON(&buf, results); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded []types.ScanResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0].Path != "/tmp/x" {
+		t.Errorf("unexpected decoded results: %v", decoded)
+	}
+}
+
+func TestWriteTreeJSON(t *testing.T) {
+	node := &types.TreeNode{Path: "/tmp/x", Name: "x", Size: 3, IsDir: true, Scanned: true}
+	node.AddChild(&types.TreeNode{Path: "/tmp/x/f", Name: "f", Size: 3, Depth: 1, FileCount: 1})
+
+	var buf bytes.Buffer
+	if err := WriteTreeJSON(&buf, node); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{`"path": "/tmp/x"`, `"children": [`, `"fileCount": 1`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("tree JSON lacks %s:\n%s", want, buf.String())
+		}
+	}
+	// Files have no children key at all
+	if strings.Count(buf.String(), `"children"`) != 1 {
+		t.Errorf("children key on a file:\n%s", buf.String())
+	}
+}
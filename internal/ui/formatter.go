@@ -3,7 +3,12 @@ package ui
 
 import (
 	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/thanhdevapp/dev-cleaner/pkg/types"
@@ -18,13 +23,6 @@ var (
 	dangerColor  = lipgloss.Color("#EF4444") // Red
 	infoColor    = lipgloss.Color("#3B82F6") // Blue
 	mutedColor   = lipgloss.Color("#6B7280") // Gray
-
-	// Type colors
-	xcodeColor   = lipgloss.Color("#147EFB") // Apple Blue
-	androidColor = lipgloss.Color("#3DDC84") // Android Green
-	nodeColor    = lipgloss.Color("#68A063") // Node Green
-	flutterColor = lipgloss.Color("#02569B") // Flutter Blue
-	cacheColor   = lipgloss.Color("#9CA3AF") // Gray
 )
 
 // Styles
@@ -56,7 +54,7 @@ var (
 
 	typeStyleBase = lipgloss.NewStyle().
 			Bold(true).
-			Width(10)
+			Width(16)
 
 	sizeStyle = lipgloss.NewStyle().
 			Width(10).
@@ -98,47 +96,100 @@ var (
 			MarginTop(1)
 )
 
-// FormatSize formats bytes to human-readable format
+// FormatSize formats bytes to human-readable format, in the units chosen
+// with --si (see types.SetSI)
 func FormatSize(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
+	return types.FormatSize(bytes)
+}
+
+// FormatCount formats a file count compactly, e.g. 950, 12.3K, 1.2M
+func FormatCount(n int64) string {
+	switch {
+	case n < 1000:
+		return fmt.Sprintf("%d", n)
+	case n < 1000*1000:
+		return fmt.Sprintf("%.1fK", float64(n)/1000)
+	}
+	return fmt.Sprintf("%.1fM", float64(n)/(1000*1000))
+}
+
+// FileCountLabel formats a result's file count, e.g. "12.3K files", or
+// "—" when it has a size but no count (Docker resources, du sizing), so
+// it doesn't read as "0 files"
+func FileCountLabel(result types.ScanResult) string {
+	if result.FileCount == 0 && result.Size > 0 {
+		return "—"
+	}
+	return FormatCount(int64(result.FileCount)) + " files"
+}
+
+// ParseAge parses an age like "180d", "4w" or "36h" into a duration. Days
+// and weeks are whole numbers; anything else goes to time.ParseDuration.
+func ParseAge(s string) (time.Duration, error) {
+	str := strings.ToLower(strings.TrimSpace(s))
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(str, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(str, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit != 0 {
+		n, err := strconv.Atoi(str[:len(str)-1])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q (examples: 180d, 4w, 36h)", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q (examples: 180d, 4w, 36h)", s)
 	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
+	return d, nil
+}
+
+// quiet suppresses decorative output (headers, footers, emoji, ANSI styling)
+var quiet bool
+
+// SetQuiet toggles quiet mode for all print helpers
+func SetQuiet(q bool) {
+	quiet = q
+}
+
+// IsQuiet reports whether quiet mode is enabled
+func IsQuiet() bool {
+	return quiet
+}
+
+// Colorize wraps text in an ANSI color code unless quiet mode is enabled
+func Colorize(color, text string) string {
+	if quiet {
+		return text
 	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+	return color + text + Reset
 }
 
 // PrintHeader prints a styled header
-func PrintHeader(text string) {
+func PrintHeader(w io.Writer, text string) {
+	if quiet {
+		return
+	}
 	emoji := "🧹"
 	if strings.Contains(text, "Scanning") {
 		emoji = "🔍"
 	}
-	fmt.Println()
-	fmt.Println(headerStyle.Render(fmt.Sprintf(" %s %s ", emoji, text)))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, headerStyle.Render(fmt.Sprintf(" %s %s ", emoji, text)))
 }
 
-// getTypeStyle returns styled type badge
+// getTypeStyle returns the type badge style in the type's color
+// (types.CategoryColor)
 func getTypeStyle(t types.CleanTargetType) lipgloss.Style {
 	style := typeStyleBase.Copy()
-	switch t {
-	case types.TypeXcode:
-		return style.Foreground(xcodeColor)
-	case types.TypeAndroid:
-		return style.Foreground(androidColor)
-	case types.TypeNode:
-		return style.Foreground(nodeColor)
-	case types.TypeFlutter:
-		return style.Foreground(flutterColor)
-	case types.TypeCache:
-		return style.Foreground(cacheColor)
-	default:
-		return style
+	if color := types.CategoryColor(t); color != "" {
+		return style.Foreground(lipgloss.Color(color))
 	}
+	return style
 }
 
 // getSizeStyle returns styled size based on magnitude
@@ -152,8 +203,8 @@ func getSizeStyle(bytes int64) lipgloss.Style {
 	return style.Foreground(successColor)
 }
 
-// renderProgressBar creates a visual progress bar
-func renderProgressBar(current, max int64, width int) string {
+// RenderProgressBar creates a visual progress bar scaled to max
+func RenderProgressBar(current, max int64, width int) string {
 	if max == 0 {
 		return ""
 	}
@@ -178,21 +229,57 @@ func renderProgressBar(current, max int64, width int) string {
 	return bar
 }
 
+// ReclaimableLabel returns e.g. "80% reclaimable" for Docker results, whose
+// size is only the reclaimable part of a resource type, or "" otherwise
+func ReclaimableLabel(result types.ScanResult) string {
+	if result.Type != types.TypeDocker || result.ReclaimablePercent <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d%% reclaimable", result.ReclaimablePercent)
+}
+
+// SizeLabel formats a result's size, with a "~" in front of estimates
+// (ScanOptions.EstimateOnly)
+func SizeLabel(result types.ScanResult) string {
+	if result.Estimated {
+		return "~" + FormatSize(result.Size)
+	}
+	return FormatSize(result.Size)
+}
+
 // PrintResult prints a single scan result with enhanced formatting
-func PrintResult(result types.ScanResult, index int, maxSize int64) {
+func PrintResult(w io.Writer, result types.ScanResult, index int, maxSize int64) {
+	if quiet {
+		line := fmt.Sprintf("[%d] %s %s %s", index+1, result.Type, SizeLabel(result), result.Name)
+		if label := ReclaimableLabel(result); label != "" {
+			line += " (" + label + ")"
+		}
+		if result.Risky {
+			line += " (in use)"
+		}
+		fmt.Fprintln(w, line)
+		return
+	}
+
 	idx := indexStyle.Render(fmt.Sprintf("[%d]", index+1))
-	typeStr := getTypeStyle(result.Type).Render(string(result.Type))
-	sizeStr := getSizeStyle(result.Size).Render(FormatSize(result.Size))
-	bar := renderProgressBar(result.Size, maxSize, 15)
+	typeStr := getTypeStyle(result.Type).Render(result.CategoryLabel())
+	sizeStr := getSizeStyle(result.Size).Render(SizeLabel(result))
+	bar := RenderProgressBar(result.Size, maxSize, 15)
 	name := nameStyle.Render(result.Name)
+	if label := ReclaimableLabel(result); label != "" {
+		name += lipgloss.NewStyle().Foreground(mutedColor).Render(" (" + label + ")")
+	}
+	if result.Risky {
+		name += lipgloss.NewStyle().Foreground(warningColor).Render(" ⚠ in use")
+	}
 
-	fmt.Printf("  %s %s %s %s  %s\n", idx, typeStr, sizeStr, bar, name)
+	fmt.Fprintf(w, "  %s %s %s %s  %s\n", idx, typeStr, sizeStr, bar, name)
 }
 
 // PrintResults prints all results in a styled box
-func PrintResults(results []types.ScanResult) {
+func PrintResults(w io.Writer, results []types.ScanResult) {
 	if len(results) == 0 {
-		fmt.Println("\n  📭 No cleanable items found.")
+		PrintNoResults(w)
 		return
 	}
 
@@ -209,84 +296,412 @@ func PrintResults(results []types.ScanResult) {
 		Foreground(mutedColor).
 		Render(strings.Repeat("─", 70))
 
-	fmt.Println()
-	fmt.Println(separator)
-	fmt.Println()
+	if quiet {
+		for i, result := range results {
+			PrintResult(w, result, i, maxSize)
+		}
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, separator)
+	fmt.Fprintln(w)
 
 	for i, result := range results {
-		PrintResult(result, i, maxSize)
+		PrintResult(w, result, i, maxSize)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, separator)
+}
+
+// PrintTopResults prints only the n largest results (results must be sorted
+// largest first), then one line totaling the rest (--top). n <= 0 prints
+// everything.
+func PrintTopResults(w io.Writer, results []types.ScanResult, n int) {
+	if n <= 0 || n >= len(results) {
+		PrintResults(w, results)
+		return
 	}
 
-	fmt.Println()
-	fmt.Println(separator)
+	PrintResults(w, results[:n])
+
+	var restSize int64
+	for _, r := range results[n:] {
+		restSize += r.Size
+	}
+	line := fmt.Sprintf("... and %d smaller items totaling %s", len(results)-n, FormatSize(restSize))
+	if quiet {
+		fmt.Fprintln(w, line)
+		return
+	}
+	fmt.Fprintln(w, lipgloss.NewStyle().Foreground(mutedColor).Render("  "+line))
 }
 
-// PrintSummary prints the scan summary with enhanced styling
-func PrintSummary(results []types.ScanResult) {
+// PrintSummary prints the scan summary with enhanced styling. dirsWalked
+// (ScanReport.DirsWalked) adds a line on how much the scan examined; 0
+// leaves it out.
+func PrintSummary(w io.Writer, results []types.ScanResult, dirsWalked int64) {
 	var totalSize int64
+	var totalFiles int64
+	var estimated bool
 	typeCounts := make(map[types.CleanTargetType]int)
 
 	for _, r := range results {
 		totalSize += r.Size
+		totalFiles += int64(r.FileCount)
 		typeCounts[r.Type]++
+		estimated = estimated || r.Estimated
+	}
+
+	total := FormatSize(totalSize)
+	if estimated {
+		total = "~" + total
+	}
+
+	if quiet {
+		fmt.Fprintf(w, "Total: %d items, %s\n", len(results), total)
+		return
 	}
 
 	// Summary line
 	summary := fmt.Sprintf("📊 Total: %d items  •  %s",
 		len(results),
-		FormatSize(totalSize),
+		total,
 	)
-	fmt.Println(summaryStyle.Render(summary))
+	fmt.Fprintln(w, summaryStyle.Render(summary))
+	if estimated {
+		fmt.Fprintln(w, lipgloss.NewStyle().Foreground(warningColor).Render("   ≈ Estimated sizes (--fast); scan without --fast for exact figures"))
+	}
 
 	// Type breakdown
 	breakdown := ""
-	if c := typeCounts[types.TypeXcode]; c > 0 {
-		breakdown += getTypeStyle(types.TypeXcode).Render(fmt.Sprintf(" %d xcode", c))
+	for _, display := range types.TypeDisplays {
+		if c := typeCounts[display.Type]; c > 0 {
+			breakdown += getTypeStyle(display.Type).UnsetWidth().Render(fmt.Sprintf("  %d %s", c, display.Label))
+		}
 	}
-	if c := typeCounts[types.TypeAndroid]; c > 0 {
-		breakdown += getTypeStyle(types.TypeAndroid).Render(fmt.Sprintf(" %d android", c))
+	if breakdown != "" {
+		fmt.Fprintln(w, lipgloss.NewStyle().Foreground(mutedColor).Render("   "+breakdown))
+	}
+
+	// Scan thoroughness, which also explains long scans
+	if dirsWalked > 0 {
+		scanned := fmt.Sprintf("   🔎 Scanned %s directories  •  %s files examined",
+			FormatCount(dirsWalked),
+			FormatCount(totalFiles),
+		)
+		fmt.Fprintln(w, lipgloss.NewStyle().Foreground(mutedColor).Render(scanned))
 	}
-	if c := typeCounts[types.TypeNode]; c > 0 {
-		breakdown += getTypeStyle(types.TypeNode).Render(fmt.Sprintf(" %d node", c))
+}
+
+// tierLabels describes each safety tier in the --recommend report
+var tierLabels = map[types.SafetyTier]string{
+	types.TierSafe:     "Safe to delete anytime (caches, re-downloaded on demand)",
+	types.TierInactive: "Safe if the project is inactive (rebuilt on next build)",
+	types.TierReview:   "Review first (may hold data that is slow to recreate)",
+}
+
+// tierIcons prefixes each tier heading outside quiet mode
+var tierIcons = map[types.SafetyTier]string{
+	types.TierSafe:     "✅",
+	types.TierInactive: "💤",
+	types.TierReview:   "🔍",
+}
+
+// PrintRecommendations prints results grouped by safety tier, safest
+// first, each group with its own subtotal
+func PrintRecommendations(w io.Writer, results []types.ScanResult) {
+	if len(results) == 0 {
+		PrintNoResults(w)
+		return
 	}
-	if c := typeCounts[types.TypeFlutter]; c > 0 {
-		breakdown += getTypeStyle(types.TypeFlutter).Render(fmt.Sprintf(" %d flutter", c))
+
+	groups := make(map[types.SafetyTier][]types.ScanResult)
+	var maxSize int64
+	for _, r := range results {
+		groups[r.Tier()] = append(groups[r.Tier()], r)
+		if r.Size > maxSize {
+			maxSize = r.Size
+		}
 	}
-	if breakdown != "" {
-		fmt.Println(lipgloss.NewStyle().Foreground(mutedColor).Render("   " + breakdown))
+
+	index := 0
+	for _, tier := range types.SafetyTiers {
+		group := groups[tier]
+		if len(group) == 0 {
+			continue
+		}
+
+		var size int64
+		for _, r := range group {
+			size += r.Size
+		}
+
+		if quiet {
+			fmt.Fprintf(w, "%s: %d items, %s\n", tierLabels[tier], len(group), FormatSize(size))
+		} else {
+			heading := fmt.Sprintf("%s %s  •  %d items  •  %s", tierIcons[tier], tierLabels[tier], len(group), FormatSize(size))
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, titleStyle.Render(heading))
+		}
+
+		for _, r := range group {
+			PrintResult(w, r, index, maxSize)
+			index++
+		}
+	}
+
+	if !quiet {
+		fmt.Fprintln(w)
+	}
+}
+
+// diffHeadings title the sections of PrintDiff
+var diffHeadings = map[types.ChangeKind]string{
+	types.ChangeNew:   "🆕 New",
+	types.ChangeGrown: "📈 Grown",
+	types.ChangeGone:  "🗑  Gone",
+}
+
+// FormatDelta formats a size change with its sign, e.g. "+3.0 GB"
+func FormatDelta(delta int64) string {
+	if delta < 0 {
+		return "-" + FormatSize(-delta)
+	}
+	return "+" + FormatSize(delta)
+}
+
+// PrintDiff prints the changes since the scan saved at since (scan --diff),
+// grouped into new, grown and gone items, then one line totaling each group
+func PrintDiff(w io.Writer, changes []types.ResultChange, since time.Time) {
+	sinceText := since.Format("2006-01-02 15:04")
+	if len(changes) == 0 {
+		if quiet {
+			fmt.Fprintf(w, "No changes since %s.\n", sinceText)
+			return
+		}
+		fmt.Fprintln(w, lipgloss.NewStyle().Foreground(successColor).Render("✨ No changes since the last scan ("+sinceText+")"))
+		return
+	}
+
+	if !quiet {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, titleStyle.Render("Changes since the last scan ("+sinceText+")"))
+	}
+
+	counts := make(map[types.ChangeKind]int)
+	totals := make(map[types.ChangeKind]int64)
+	var kind types.ChangeKind
+	for _, change := range changes {
+		counts[change.Kind]++
+		totals[change.Kind] += change.Delta
+
+		r := change.Result
+		if quiet {
+			fmt.Fprintf(w, "%s %s %s %s\n", change.Kind, r.Type, FormatDelta(change.Delta), r.Name)
+			continue
+		}
+
+		if change.Kind != kind {
+			kind = change.Kind
+			fmt.Fprintln(w, lipgloss.NewStyle().Bold(true).Render(diffHeadings[kind]))
+		}
+		deltaStyle := sizeStyle.Copy().Foreground(warningColor)
+		if change.Delta < 0 {
+			deltaStyle = sizeStyle.Copy().Foreground(successColor)
+		}
+		fmt.Fprintf(w, "  %s %s %s\n",
+			deltaStyle.Render(FormatDelta(change.Delta)),
+			getTypeStyle(r.Type).Render(r.CategoryLabel()),
+			nameStyle.Render(r.Name))
+	}
+
+	var parts []string
+	for _, k := range []types.ChangeKind{types.ChangeNew, types.ChangeGrown, types.ChangeGone} {
+		if counts[k] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s (%s)", counts[k], k, FormatDelta(totals[k])))
+		}
+	}
+	if quiet {
+		fmt.Fprintf(w, "Changes: %s\n", strings.Join(parts, ", "))
+		return
+	}
+	fmt.Fprintln(w, summaryStyle.Render("📊 "+strings.Join(parts, "  •  ")))
+}
+
+// PrintDuplicates prints the projects whose package.json declare the same
+// direct dependencies (scan --find-dupes), suggesting sharing one install
+func PrintDuplicates(w io.Writer, dupes []types.DuplicateDeps) {
+	if len(dupes) == 0 {
+		if quiet {
+			fmt.Fprintln(w, "No projects share the same dependencies.")
+			return
+		}
+		fmt.Fprintln(w, lipgloss.NewStyle().Foreground(successColor).Render("✨ No projects share the same dependencies"))
+		return
+	}
+
+	if !quiet {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, titleStyle.Render("Projects with identical dependencies"))
+	}
+
+	var projects int
+	var total int64
+	for _, set := range dupes {
+		projects += len(set.Results)
+		total += set.Size
+		if quiet {
+			for _, r := range set.Results {
+				fmt.Fprintf(w, "%s %s %s\n", set.Fingerprint, FormatSize(r.Size), filepath.Dir(r.Path))
+			}
+			continue
+		}
+
+		heading := fmt.Sprintf("%d projects  •  %d dependencies  •  %s", len(set.Results), set.Dependencies, FormatSize(set.Size))
+		fmt.Fprintln(w, lipgloss.NewStyle().Bold(true).Render(heading))
+		for _, r := range set.Results {
+			fmt.Fprintf(w, "  %s %s\n", sizeStyle.Render(FormatSize(r.Size)), nameStyle.Render(filepath.Dir(r.Path)))
+		}
+	}
+
+	summary := fmt.Sprintf("%d dependency sets shared by %d projects, %s of node_modules", len(dupes), projects, FormatSize(total))
+	if quiet {
+		fmt.Fprintln(w, summary)
+		return
 	}
+	fmt.Fprintln(w, summaryStyle.Render("📊 "+summary))
+	fmt.Fprintln(w, footerStyle.Render("Consider an npm/yarn/pnpm workspace or pnpm's shared store to install them once."))
 }
 
 // PrintDryRunWarning prints a dry-run mode notice
-func PrintDryRunWarning() {
+func PrintDryRunWarning(w io.Writer) {
+	if quiet {
+		fmt.Fprintln(w, "Dry-run: no files will be deleted.")
+		return
+	}
 	warning := dryRunStyle.Render(" ⚡ DRY-RUN MODE ")
 	msg := lipgloss.NewStyle().Foreground(mutedColor).Render(" No files will be deleted")
-	fmt.Printf("\n%s%s\n", warning, msg)
-	fmt.Println(footerStyle.Render("Use --confirm to actually delete files."))
+	fmt.Fprintf(w, "\n%s%s\n", warning, msg)
+	fmt.Fprintln(w, footerStyle.Render("Use --confirm to actually delete files."))
 }
 
 // PrintDeleteWarning prints a deletion warning
-func PrintDeleteWarning(count int, size int64) {
+func PrintDeleteWarning(w io.Writer, count int, size int64) {
+	if quiet {
+		fmt.Fprintf(w, "About to delete %d items (%s)\n", count, FormatSize(size))
+		return
+	}
 	msg := fmt.Sprintf("⚠️  WARNING: About to delete %d items (%s)", count, FormatSize(size))
-	fmt.Println()
-	fmt.Println(warningStyle.Render(msg))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, warningStyle.Render(msg))
 }
 
 // PrintFooter prints helpful footer message
-func PrintFooter() {
-	fmt.Println(footerStyle.Render("💡 Run 'dev-cleaner clean' to interactively select items to delete."))
+func PrintFooter(w io.Writer) {
+	if quiet {
+		return
+	}
+	fmt.Fprintln(w, footerStyle.Render("💡 Run 'dev-cleaner clean' to interactively select items to delete."))
 }
 
 // PrintSuccess prints a success message
-func PrintSuccess(msg string) {
+func PrintSuccess(w io.Writer, msg string) {
+	if quiet {
+		fmt.Fprintln(w, msg)
+		return
+	}
 	style := lipgloss.NewStyle().Foreground(successColor)
-	fmt.Println(style.Render("✓ " + msg))
+	fmt.Fprintln(w, style.Render("✓ "+msg))
 }
 
 // PrintError prints an error message
-func PrintError(msg string) {
+func PrintError(w io.Writer, msg string) {
+	if quiet {
+		fmt.Fprintln(w, msg)
+		return
+	}
 	style := lipgloss.NewStyle().Foreground(dangerColor)
-	fmt.Println(style.Render("✗ " + msg))
+	fmt.Fprintln(w, style.Render("✗ "+msg))
+}
+
+// FormatTimings formats per-category scan durations, slowest first,
+// e.g. "node: 12.3s, xcode: 4.1s"
+func FormatTimings(timings map[string]time.Duration) string {
+	categories := make([]string, 0, len(timings))
+	for category := range timings {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if timings[categories[i]] != timings[categories[j]] {
+			return timings[categories[i]] > timings[categories[j]]
+		}
+		return categories[i] < categories[j]
+	})
+
+	parts := make([]string, 0, len(categories))
+	for _, category := range categories {
+		parts = append(parts, fmt.Sprintf("%s: %.1fs", category, timings[category].Seconds()))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// PrintTimings prints the per-category scan timing breakdown
+func PrintTimings(w io.Writer, timings map[string]time.Duration) {
+	if len(timings) == 0 {
+		return
+	}
+	if quiet {
+		fmt.Fprintf(w, "Timing: %s\n", FormatTimings(timings))
+		return
+	}
+	fmt.Fprintln(w, lipgloss.NewStyle().Foreground(mutedColor).Render("⏱  Scan timing: "+FormatTimings(timings)))
+}
+
+// PrintNotes prints the scan report's notes, e.g. a stopped Docker daemon
+func PrintNotes(w io.Writer, notes []string) {
+	for _, note := range notes {
+		if quiet {
+			fmt.Fprintf(w, "Note: %s\n", note)
+			continue
+		}
+		fmt.Fprintln(w, lipgloss.NewStyle().Foreground(infoColor).Render("ℹ  "+note))
+	}
+}
+
+// ScanErrorsSummary describes failed categories in one line, e.g.
+// "docker failed: daemon not running", or "" when none failed
+func ScanErrorsSummary(scanErrors []types.ScanError) string {
+	parts := make([]string, 0, len(scanErrors))
+	for _, e := range scanErrors {
+		parts = append(parts, e.Category+" failed: "+e.Message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// PrintScanErrors prints the categories that failed after the scanned
+// count, e.g. "9 categories scanned, docker failed: ...", so a failure is
+// not mistaken for nothing to clean. Nothing is printed when none failed.
+func PrintScanErrors(w io.Writer, scanErrors []types.ScanError, scanned int) {
+	if len(scanErrors) == 0 {
+		return
+	}
+	line := fmt.Sprintf("%d categories scanned, %s", scanned, ScanErrorsSummary(scanErrors))
+	if quiet {
+		fmt.Fprintln(w, "Warning: "+line)
+		return
+	}
+	fmt.Fprintln(w, lipgloss.NewStyle().Foreground(warningColor).Render("⚠  "+line))
+}
+
+// PrintNoResults prints the empty scan result notice
+func PrintNoResults(w io.Writer) {
+	if quiet {
+		fmt.Fprintln(w, "No cleanable items found.")
+		return
+	}
+	fmt.Fprintln(w, "\n  📭 No cleanable items found.")
 }
 
 // Deprecated colors for backward compatibility
@@ -1,7 +1,12 @@
 package ui
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/thanhdevapp/dev-cleaner/pkg/types"
 )
 
 func TestFormatSize(t *testing.T) {
@@ -27,3 +32,243 @@ func TestFormatSize(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatCount(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0"},
+		{950, "950"},
+		{12345, "12.3K"},
+		{1200000, "1.2M"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatCount(tt.n); got != tt.want {
+			t.Errorf("FormatCount(%d) = %s, want %s", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFileCountLabel(t *testing.T) {
+	tests := []struct {
+		result types.ScanResult
+		want   string
+	}{
+		{types.ScanResult{Size: 100, FileCount: 12345}, "12.3K files"},
+		{types.ScanResult{Size: 100}, "—"},
+		{types.ScanResult{}, "0 files"},
+	}
+
+	for _, tt := range tests {
+		if got := FileCountLabel(tt.result); got != tt.want {
+			t.Errorf("FileCountLabel(%+v) = %s, want %s", tt.result, got, tt.want)
+		}
+	}
+}
+
+func TestColorizeQuiet(t *testing.T) {
+	defer SetQuiet(false)
+
+	if got := Colorize(Green, "ok"); got != Green+"ok"+Reset {
+		t.Errorf("Colorize() = %q, want ANSI-wrapped text", got)
+	}
+
+	SetQuiet(true)
+	if got := Colorize(Green, "ok"); got != "ok" {
+		t.Errorf("Colorize() in quiet mode = %q, want %q", got, "ok")
+	}
+}
+
+func TestPrintResultsWriter(t *testing.T) {
+	defer SetQuiet(false)
+	SetQuiet(true)
+
+	results := []types.ScanResult{
+		{Type: types.TypeNode, Size: 2048, Name: "app/node_modules"},
+		{Type: types.TypeGo, Size: 1024, Name: "Go Build Cache", Risky: true},
+	}
+
+	var buf bytes.Buffer
+	PrintResults(&buf, results)
+	PrintSummary(&buf, results, 0)
+
+	want := "[1] node 2.0 KB app/node_modules\n" +
+		"[2] go 1.0 KB Go Build Cache (in use)\n" +
+		"Total: 2 items, 3.0 KB\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestPrintTopResults(t *testing.T) {
+	defer SetQuiet(false)
+	SetQuiet(true)
+
+	results := []types.ScanResult{
+		{Type: types.TypeXcode, Size: 4096, Name: "Xcode DerivedData"},
+		{Type: types.TypeNode, Size: 2048, Name: "app/node_modules"},
+		{Type: types.TypeGo, Size: 1024, Name: "Go Build Cache"},
+		{Type: types.TypeRust, Size: 512, Name: "cli/target"},
+	}
+
+	var buf bytes.Buffer
+	PrintTopResults(&buf, results, 2)
+	want := "[1] xcode 4.0 KB Xcode DerivedData\n" +
+		"[2] node 2.0 KB app/node_modules\n" +
+		"... and 2 smaller items totaling 1.5 KB\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	PrintTopResults(&buf, results, 10)
+	if got := strings.Count(buf.String(), "\n"); got != len(results) {
+		t.Errorf("top larger than the results printed %d lines, want %d", got, len(results))
+	}
+}
+
+func TestPrintRecommendations(t *testing.T) {
+	defer SetQuiet(false)
+	SetQuiet(true)
+
+	results := []types.ScanResult{
+		{Type: types.TypeNode, Size: 4096, Name: "app/node_modules", SafetyTier: types.TierInactive},
+		{Type: types.TypeGo, Size: 2048, Name: "Go Build Cache", SafetyTier: types.TierSafe},
+		{Type: types.TypeDocker, Size: 1024, Name: "Docker Volumes (unused)", SafetyTier: types.TierReview},
+		{Type: types.TypePython, Size: 512, Name: "api/.venv", SafetyTier: types.TierInactive, Risky: true},
+	}
+
+	var buf bytes.Buffer
+	PrintRecommendations(&buf, results)
+
+	want := "Safe to delete anytime (caches, re-downloaded on demand): 1 items, 2.0 KB\n" +
+		"[1] go 2.0 KB Go Build Cache\n" +
+		"Safe if the project is inactive (rebuilt on next build): 1 items, 4.0 KB\n" +
+		"[2] node 4.0 KB app/node_modules\n" +
+		"Review first (may hold data that is slow to recreate): 2 items, 1.5 KB\n" +
+		"[3] docker 1.0 KB Docker Volumes (unused)\n" +
+		"[4] python 512 B api/.venv (in use)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestPrintNotes(t *testing.T) {
+	defer SetQuiet(false)
+	SetQuiet(true)
+
+	var buf bytes.Buffer
+	PrintNotes(&buf, []string{"Docker daemon is not running"})
+	if got, want := buf.String(), "Note: Docker daemon is not running\n"; got != want {
+		t.Errorf("PrintNotes() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatTimings(t *testing.T) {
+	timings := map[string]time.Duration{
+		"xcode":  4100 * time.Millisecond,
+		"node":   12300 * time.Millisecond,
+		"gradle": 4100 * time.Millisecond,
+	}
+
+	want := "node: 12.3s, gradle: 4.1s, xcode: 4.1s"
+	if got := FormatTimings(timings); got != want {
+		t.Errorf("FormatTimings() = %q, want %q", got, want)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"180d", 180 * 24 * time.Hour},
+		{"4W", 4 * 7 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+		{"0d", 0},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "d", "1.5d", "-3d", "180", "soon"} {
+		if _, err := ParseAge(bad); err == nil {
+			t.Errorf("ParseAge(%q) should fail", bad)
+		}
+	}
+}
+
+func TestPrintDiffQuiet(t *testing.T) {
+	SetQuiet(true)
+	defer SetQuiet(false)
+
+	changes := []types.ResultChange{
+		{Kind: types.ChangeNew, Result: types.ScanResult{Type: types.TypeNode, Name: "app/node_modules"}, Delta: 2048},
+		{Kind: types.ChangeGrown, Result: types.ScanResult{Type: types.TypeNode, Name: "web/node_modules"}, Delta: 3 * 1024 * 1024 * 1024},
+		{Kind: types.ChangeGone, Result: types.ScanResult{Type: types.TypeRust, Name: "old/target"}, Delta: -1024},
+	}
+	since := time.Date(2026, 10, 1, 9, 30, 0, 0, time.Local)
+
+	var buf bytes.Buffer
+	PrintDiff(&buf, changes, since)
+	want := "new node +2.0 KB app/node_modules\n" +
+		"grown node +3.0 GB web/node_modules\n" +
+		"gone rust -1.0 KB old/target\n" +
+		"Changes: 1 new (+2.0 KB), 1 grown (+3.0 GB), 1 gone (-1.0 KB)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("PrintDiff() = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	PrintDiff(&buf, nil, since)
+	if got, want := buf.String(), "No changes since 2026-10-01 09:30.\n"; got != want {
+		t.Errorf("PrintDiff(nil) = %q, want %q", got, want)
+	}
+}
+
+func TestPrintScanErrors(t *testing.T) {
+	SetQuiet(true)
+	defer SetQuiet(false)
+
+	var buf bytes.Buffer
+	PrintScanErrors(&buf, nil, 9)
+	if buf.Len() != 0 {
+		t.Errorf("PrintScanErrors(nil) = %q, want nothing", buf.String())
+	}
+
+	PrintScanErrors(&buf, []types.ScanError{{Category: "docker", Message: "daemon not running"}}, 9)
+	if got, want := buf.String(), "Warning: 9 categories scanned, docker failed: daemon not running\n"; got != want {
+		t.Errorf("PrintScanErrors() = %q, want %q", got, want)
+	}
+}
+
+func TestPrintDuplicatesQuiet(t *testing.T) {
+	SetQuiet(true)
+	defer SetQuiet(false)
+
+	dupes := []types.DuplicateDeps{{
+		Fingerprint:  "0123abcd4567",
+		Dependencies: 12,
+		Size:         3072,
+		Results: []types.ScanResult{
+			{Path: "/work/app-a/node_modules", Size: 2048},
+			{Path: "/work/app-b/node_modules", Size: 1024},
+		},
+	}}
+
+	var buf bytes.Buffer
+	PrintDuplicates(&buf, dupes)
+
+	want := "0123abcd4567 2.0 KB /work/app-a\n" +
+		"0123abcd4567 1.0 KB /work/app-b\n" +
+		"1 dependency sets shared by 2 projects, 3.0 KB of node_modules\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// spinnerFrames are drawn in turn by the text-mode spinner
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is how often the spinner is redrawn
+const spinnerInterval = 100 * time.Millisecond
+
+// IsTerminal reports whether f is a terminal rather than a pipe or file
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// StartSpinner shows "⠋ label (12s)" on f, redrawn in place, until the
+// returned stop clears the line. Quiet mode and outputs that are not a
+// terminal get no spinner, so logs and redirected output stay clean.
+func StartSpinner(f *os.File, label string) (stop func()) {
+	if quiet || !IsTerminal(f) {
+		return func() {}
+	}
+	return startSpinner(f, label, spinnerInterval)
+}
+
+// startSpinner draws the spinner on w every interval until stop is called;
+// stop returns once the line is cleared
+func startSpinner(w io.Writer, label string, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	cleared := make(chan struct{})
+	start := time.Now()
+
+	go func() {
+		defer close(cleared)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for frame := 0; ; frame++ {
+			elapsed := int(time.Since(start).Seconds())
+			fmt.Fprintf(w, "\r%s %s (%ds)", spinnerFrames[frame%len(spinnerFrames)], label, elapsed)
+			select {
+			case <-done:
+				fmt.Fprint(w, "\r\033[K")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-cleared
+	}
+}
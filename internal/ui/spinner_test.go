@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStartSpinner(t *testing.T) {
+	var buf bytes.Buffer
+	stop := startSpinner(&buf, "Scanning...", time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	stop()
+
+	out := buf.String()
+	if !strings.Contains(out, "Scanning... (0s)") {
+		t.Errorf("spinner output %q has no label with elapsed time", out)
+	}
+	if !strings.HasSuffix(out, "\r\033[K") {
+		t.Errorf("spinner output %q does not end by clearing the line", out)
+	}
+
+	// Files and pipes get no spinner
+	file, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	StartSpinner(file, "Scanning...")()
+	if info, _ := file.Stat(); info.Size() != 0 {
+		t.Errorf("spinner wrote %d bytes to a file", info.Size())
+	}
+}
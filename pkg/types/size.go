@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sizeBase is the unit FormatSize and ParseSize step by: 1024 by default,
+// 1000 with --si so sizes match what Finder shows
+var sizeBase = 1024
+
+// SetSI switches FormatSize and ParseSize between decimal (1000) and
+// binary (1024) units
+func SetSI(si bool) {
+	sizeBase = 1024
+	if si {
+		sizeBase = 1000
+	}
+}
+
+// FormatSize formats bytes to human-readable format, in the units chosen
+// with SetSI
+func FormatSize(bytes int64) string {
+	return FormatSizeBase(bytes, sizeBase)
+}
+
+// FormatSizeBase formats bytes with units that step by base, 1000 (SI,
+// like Finder) or 1024. Both are labeled KB, MB, GB...
+func FormatSizeBase(bytes int64, base int) string {
+	unit := int64(base)
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := unit, 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// ParseSize parses a human-readable size like "20GB", "1.5T" or "512 MB"
+// into bytes, in the units chosen with SetSI so it matches FormatSize.
+// GiB-style suffixes are always 1024-based. A bare number is taken as
+// bytes.
+func ParseSize(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	base := int64(sizeBase)
+	if strings.HasSuffix(str, "IB") {
+		str = strings.TrimSuffix(str, "IB")
+		base = 1024
+	}
+	str = strings.TrimSuffix(str, "B")
+
+	multiplier := int64(1)
+	if n := len(str); n > 0 {
+		if exp := strings.IndexByte("KMGTPE", str[n-1]); exp >= 0 {
+			for i := 0; i <= exp; i++ {
+				multiplier *= base
+			}
+			str = str[:n-1]
+		}
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q (examples: 20GB, 500MB, 1.5T)", s)
+	}
+	return int64(value * float64(multiplier)), nil
+}
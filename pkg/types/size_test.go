@@ -0,0 +1,68 @@
+package types
+
+import "testing"
+
+func TestFormatSizeBase(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		base  int
+		want  string
+	}{
+		{999, 1000, "999 B"},
+		{1500, 1000, "1.5 KB"},
+		{4_600_000_000, 1000, "4.6 GB"},
+		{4_600_000_000, 1024, "4.3 GB"},
+		{2048, 1024, "2.0 KB"},
+	}
+	for _, tt := range tests {
+		if got := FormatSizeBase(tt.bytes, tt.base); got != tt.want {
+			t.Errorf("FormatSizeBase(%d, %d) = %q, want %q", tt.bytes, tt.base, got, tt.want)
+		}
+	}
+
+	SetSI(true)
+	defer SetSI(false)
+	if got := FormatSize(1500); got != "1.5 KB" {
+		t.Errorf("FormatSize with SI = %q, want 1.5 KB", got)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"1024", 1024},
+		{"20GB", 20 * 1024 * 1024 * 1024},
+		{"20g", 20 * 1024 * 1024 * 1024},
+		{"1.5 KB", 1536},
+		{"512MiB", 512 * 1024 * 1024},
+		{"2T", 2 * 1024 * 1024 * 1024 * 1024},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "GB", "abc", "-5GB", "10XB"} {
+		if _, err := ParseSize(bad); err == nil {
+			t.Errorf("ParseSize(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseSizeSI(t *testing.T) {
+	SetSI(true)
+	defer SetSI(false)
+
+	// Decimal units match what FormatSize shows; GiB stays binary
+	if got, _ := ParseSize("20GB"); got != 20_000_000_000 {
+		t.Errorf("ParseSize(20GB) with SI = %d, want 20000000000", got)
+	}
+	if got, _ := ParseSize("1GiB"); got != 1024*1024*1024 {
+		t.Errorf("ParseSize(1GiB) with SI = %d, want %d", got, 1024*1024*1024)
+	}
+}
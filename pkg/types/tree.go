@@ -6,15 +6,15 @@ import (
 
 // TreeNode represents a file/directory in hierarchical tree navigation
 type TreeNode struct {
-	Path      string          // Full path
-	Name      string          // Display name (basename)
-	Size      int64           // Size in bytes
-	IsDir     bool            // Directory flag
-	Type      CleanTargetType // xcode/android/node
-	Children  []*TreeNode     // Child nodes (nil = not scanned)
-	Scanned   bool            // Lazy scan flag
-	Depth     int             // Current depth in tree
-	FileCount int             // Number of files
+	Path      string          `json:"path"`               // Full path
+	Name      string          `json:"name"`               // Display name (basename)
+	Size      int64           `json:"size"`               // Size in bytes
+	IsDir     bool            `json:"isDir"`              // Directory flag
+	Type      CleanTargetType `json:"type,omitempty"`     // xcode/android/node
+	Children  []*TreeNode     `json:"children,omitempty"` // Child nodes (nil = not scanned)
+	Scanned   bool            `json:"scanned"`            // Lazy scan flag
+	Depth     int             `json:"depth"`              // Current depth in tree
+	FileCount int             `json:"fileCount"`          // Number of files
 }
 
 // AddChild appends child to node's children
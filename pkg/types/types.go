@@ -1,6 +1,11 @@
 // Package types contains shared types for the dev-cleaner CLI
 package types
 
+import (
+	"strings"
+	"time"
+)
+
 // CleanTargetType represents the category of the clean target
 type CleanTargetType string
 
@@ -17,15 +22,129 @@ const (
 	TypeHomebrew    CleanTargetType = "homebrew"
 	TypeDocker      CleanTargetType = "docker"
 	TypeJava        CleanTargetType = "java"
+	TypeDeno        CleanTargetType = "deno"
+	TypeDotNet      CleanTargetType = "dotnet"
+	TypeUnity       CleanTargetType = "unity"
+	TypePHP         CleanTargetType = "php"
+	TypeElixir      CleanTargetType = "elixir"
+	TypeHaskell     CleanTargetType = "haskell"
+	TypeJetBrains   CleanTargetType = "jetbrains"
+	TypeGeneric     CleanTargetType = "generic" // Any folder, found by analyze rather than an ecosystem scanner
+)
+
+// SafetyTier ranks how safe a result is to clean, for --recommend
+type SafetyTier string
+
+const (
+	TierSafe     SafetyTier = "safe"     // Caches and registries, re-downloaded or rebuilt on demand
+	TierInactive SafetyTier = "inactive" // Project build output, safe if the project is not being worked on
+	TierReview   SafetyTier = "review"   // May hold state that is slow or impossible to recreate
 )
 
+// SafetyTiers lists the tiers from lowest to highest risk
+var SafetyTiers = []SafetyTier{TierSafe, TierInactive, TierReview}
+
 // ScanResult represents a single scannable/cleanable directory
 type ScanResult struct {
-	Path      string          `json:"path"`
-	Type      CleanTargetType `json:"type"`
-	Size      int64           `json:"size"`
-	FileCount int             `json:"fileCount"`
-	Name      string          `json:"name"` // Display name
+	Path       string          `json:"path"`
+	Type       CleanTargetType `json:"type"`
+	Size       int64           `json:"size"`
+	FileCount  int             `json:"fileCount"`
+	Name       string          `json:"name"`                 // Display name
+	Risky      bool            `json:"risky,omitempty"`      // Likely in active use; needs extra confirmation
+	SafetyTier SafetyTier      `json:"safetyTier,omitempty"` // Set by the scanner from the artifact kind
+
+	// ReclaimablePercent is the share of a Docker resource type that is
+	// reclaimable, as reported by docker system df (0 = not reported)
+	ReclaimablePercent int `json:"reclaimablePercent,omitempty"`
+
+	// Estimated marks a size extrapolated by ScanOptions.EstimateOnly
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// Pseudo-path prefixes of results that are cleaned by running a tool
+// instead of deleting a directory
+const (
+	DockerPathPrefix = "docker:" // docker:images, docker:containers, ...
+	SimctlPathPrefix = "simctl:" // simctl:unavailable
+)
+
+// IsPseudoPath reports whether path names a tool-managed resource (Docker,
+// simctl) rather than a folder on disk
+func IsPseudoPath(path string) bool {
+	return strings.HasPrefix(path, DockerPathPrefix) || strings.HasPrefix(path, SimctlPathPrefix)
+}
+
+// Tier returns the result's safety tier. Risky or unclassified results
+// are always TierReview.
+func (r ScanResult) Tier() SafetyTier {
+	if r.Risky || r.SafetyTier == "" {
+		return TierReview
+	}
+	return r.SafetyTier
+}
+
+// ScanReport holds scan results together with per-category scan durations
+// and notes about what could not be scanned
+type ScanReport struct {
+	Results []ScanResult
+	Timings map[string]time.Duration // Keyed by category, e.g. "node", "gradle"
+	Notes   []string                 // e.g. Docker installed but its daemon is stopped
+	Errors  []ScanError              // Categories that failed, in full or in part
+
+	// Directories read by project finders and size walks; directories
+	// whose size came from the size cache are not walked
+	DirsWalked int64
+
+	// Categories that had not finished when the scan was cancelled or
+	// timed out; their results are missing or partial
+	Unfinished []string
+}
+
+// ScanError reports a category scan that failed, in full (docker system df
+// erroring) or in part (a project root that cannot be read), so it is not
+// mistaken for a category with nothing to clean
+type ScanError struct {
+	Category string `json:"category"` // e.g. "docker", or "project roots" for all project finders
+	Message  string `json:"message"`
+}
+
+func (e ScanError) Error() string {
+	return e.Category + ": " + e.Message
+}
+
+// ChangeKind classifies a result in a comparison of two scans (scan --diff)
+type ChangeKind string
+
+const (
+	ChangeNew   ChangeKind = "new"   // Not in the previous scan
+	ChangeGrown ChangeKind = "grown" // Larger than in the previous scan
+	ChangeGone  ChangeKind = "gone"  // Only in the previous scan
+)
+
+// ResultChange is one difference between two scans. Delta is the change in
+// size: the whole size for new results, negative for gone ones.
+type ResultChange struct {
+	Kind   ChangeKind
+	Result ScanResult // The current result, or the previous one when gone
+	Delta  int64
+}
+
+// DuplicateDeps is a set of projects whose package.json declare the same
+// direct dependencies (scan --find-dupes), so their node_modules are likely
+// near-identical
+type DuplicateDeps struct {
+	Fingerprint  string       // Short hash of the sorted name@version list
+	Dependencies int          // Number of direct dependencies
+	Results      []ScanResult // The projects' node_modules, largest first
+	Size         int64
+}
+
+// ScanSummary aggregates scan results for dashboard display
+type ScanSummary struct {
+	TotalSize int64                     `json:"totalSize"`
+	Count     int                       `json:"count"`
+	ByType    map[CleanTargetType]int64 `json:"byType"` // Total size per category
 }
 
 // ScanOptions controls scanning behavior
@@ -42,8 +161,33 @@ type ScanOptions struct {
 	IncludeHomebrew    bool
 	IncludeDocker      bool
 	IncludeJava        bool
-	MaxDepth           int
-	ProjectRoot        string // Optional: scan from specific root
+	IncludeDeno        bool
+	IncludeDotNet      bool // .NET/NuGet and Unity
+	IncludePHP         bool
+	IncludeElixir      bool     // Elixir/Mix and Erlang/rebar3
+	IncludeHaskell     bool     // Haskell/Stack and Cabal
+	IncludeJetBrains   bool     // JetBrains IDE caches, indexes and logs
+	ProjectSearchDepth int      // Levels searched below each project root; see DefaultProjectSearchDepth
+	MaxDepth           int      // Tree navigation depth limit (TUI tree mode, scan --tree-json); see DefaultTreeDepth
+	ProjectRoot        string   // Optional: scan from specific root
+	Deep               bool     // Expand global cache roots into per-subfolder results
+	IncludeHiddenRoots bool     // Also search hidden dirs directly below project roots
+	GlobalsOnly        bool     // Report global caches only, skip project directory search
+	Concurrency        int      // Max category scans running at once; 0 runs all at once
+	ExtraRoots         []string // Additional project roots to search (--path)
+	ProtectActiveDays  int      // Mark build output of projects edited within this many days Risky; 0 disables
+	FollowSymlinks     bool     // Project finders follow symlinked directories (cycle-safe); tree mode never does
+	ExcludeGlobs       []string // Drop results at or below paths matching these patterns; "**" spans folders (--exclude-glob)
+	UseDu              bool     // Size directories with du -sk, faster on APFS but without file counts; falls back to the Go walk
+	EstimateOnly       bool     // Walk only a few levels and extrapolate sizes, for a quick rough total (--fast)
+
+	// AssumeDockerStopped skips the Docker daemon and reports the size of
+	// Docker Desktop's disk image instead (--assume-docker-stopped)
+	AssumeDockerStopped bool
+
+	// ArchivesOlderThan lists only Xcode Archives date folders (YYYY-MM-DD)
+	// dated more than this long ago (--older-than); 0 lists all
+	ArchivesOlderThan time.Duration
 }
 
 // CleanOptions controls cleaning behavior
@@ -51,8 +195,30 @@ type CleanOptions struct {
 	DryRun  bool
 	Confirm bool
 	LogPath string
+
+	// StatsPath is the cumulative stats file updated after real runs;
+	// empty means ~/.dev-cleaner-stats.json
+	StatsPath string
+
+	// ResumePath lists the targets of an unfinished real run for
+	// clean --resume; empty means ~/.dev-cleaner-resume.json
+	ResumePath string
+
+	// AllowedRoots are non-home roots deletion is allowed under
+	// (system and protected paths are still refused)
+	AllowedRoots []string
 }
 
+// DefaultProjectSearchDepth is how many directory levels below each project
+// root the project finders search (node, react-native, flutter, python, rust,
+// java, dotnet, php, elixir, haskell). Global cache scanners (xcode, android,
+// gradle, go, homebrew, docker, deno, jetbrains) check fixed locations and
+// ignore it.
+const DefaultProjectSearchDepth = 3
+
+// DefaultTreeDepth is how many levels below a result tree mode descends
+const DefaultTreeDepth = 5
+
 // DefaultScanOptions returns options with all categories enabled
 func DefaultScanOptions() ScanOptions {
 	return ScanOptions{
@@ -68,6 +234,196 @@ func DefaultScanOptions() ScanOptions {
 		IncludeHomebrew:    true,
 		IncludeDocker:      true,
 		IncludeJava:        true,
-		MaxDepth:           3,
+		IncludeDeno:        true,
+		IncludeDotNet:      true,
+		IncludePHP:         true,
+		IncludeElixir:      true,
+		IncludeHaskell:     true,
+		IncludeJetBrains:   true,
+		ProjectSearchDepth: DefaultProjectSearchDepth,
+		MaxDepth:           DefaultTreeDepth,
+	}
+}
+
+// TypeDisplay is how a result type is presented to users
+type TypeDisplay struct {
+	Type  CleanTargetType
+	Label string // Proper-cased with an emoji, e.g. "📦 Node.js"
+	Color string // Badge color as "#RRGGBB", "" for the default
+}
+
+// TypeDisplays lists every result type's presentation in display order;
+// adding an ecosystem's label and color is one entry here
+var TypeDisplays = []TypeDisplay{
+	{TypeXcode, "🍎 Xcode", "#147EFB"},
+	{TypeAndroid, "🤖 Android", "#3DDC84"},
+	{TypeNode, "📦 Node.js", "#68A063"},
+	{TypeReactNative, "📱 React Native", "#61DAFB"},
+	{TypeFlutter, "🐦 Flutter", "#02569B"},
+	{TypePython, "🐍 Python", "#3776AB"},
+	{TypeRust, "🦀 Rust", "#DEA584"},
+	{TypeGo, "🐹 Go", "#00ADD8"},
+	{TypeHomebrew, "🍺 Homebrew", "#FBB040"},
+	{TypeDocker, "🐳 Docker", "#2496ED"},
+	{TypeJava, "☕ Java", "#ED8B00"},
+	{TypeDeno, "🦕 Deno", "#70FFAF"},
+	{TypeDotNet, "🟣 .NET", "#9B72CB"},
+	{TypeUnity, "🎮 Unity", "#CCCCCC"},
+	{TypePHP, "🐘 PHP", "#777BB4"},
+	{TypeElixir, "💧 Elixir", "#6E4A7E"},
+	{TypeHaskell, "λ Haskell", "#5E5086"},
+	{TypeJetBrains, "🧠 JetBrains", "#FE2857"},
+	{TypeCache, "💾 Cache", "#9CA3AF"},
+	{TypeGeneric, "📁 Folder", ""},
+}
+
+// typeDisplay returns t's entry in TypeDisplays. Unknown types are shown
+// by their name, without a color.
+func typeDisplay(t CleanTargetType) TypeDisplay {
+	for _, display := range TypeDisplays {
+		if display.Type == t {
+			return display
+		}
+	}
+	return TypeDisplay{Type: t, Label: string(t)}
+}
+
+// CategoryLabel returns the display label of a result type, e.g.
+// "📦 Node.js" for TypeNode
+func CategoryLabel(t CleanTargetType) string {
+	return typeDisplay(t).Label
+}
+
+// CategoryColor returns the badge color of a result type, "" for none
+func CategoryColor(t CleanTargetType) string {
+	return typeDisplay(t).Color
+}
+
+// CategoryLabel returns the display label of the result's type
+func (r ScanResult) CategoryLabel() string {
+	return CategoryLabel(r.Type)
+}
+
+// Category is a scan category as offered by the category pickers
+// (scan --interactive, the TUI's category screen)
+type Category struct {
+	Name  string // Settings name, accepted by ScanOptionsForCategories
+	Label string // Display name
+}
+
+// Categories lists every scan category in display order
+var Categories = []Category{
+	{"xcode", "Xcode / iOS"},
+	{"android", "Android"},
+	{"node", "Node.js"},
+	{"react-native", "React Native"},
+	{"flutter", "Flutter / Dart"},
+	{"python", "Python"},
+	{"rust", "Rust / Cargo"},
+	{"go", "Go"},
+	{"homebrew", "Homebrew"},
+	{"docker", "Docker"},
+	{"java", "Java / Kotlin"},
+	{"deno", "Deno"},
+	{"dotnet", ".NET / Unity"},
+	{"php", "PHP / Composer"},
+	{"elixir", "Elixir / Erlang"},
+	{"haskell", "Haskell / Stack / Cabal"},
+	{"jetbrains", "JetBrains IDEs"},
+}
+
+// ScanOptionsForCategories returns DefaultScanOptions with only the named
+// categories enabled (as used in settings, e.g. "node", "xcode"). Unknown
+// names are returned so callers can warn about them.
+func ScanOptionsForCategories(categories []string) (ScanOptions, []string) {
+	opts := DefaultScanOptions()
+	unknown := opts.OnlyCategories(categories)
+	return opts, unknown
+}
+
+// OnlyCategories enables just the named categories, leaving the other
+// options alone, and returns the names it does not know
+func (o *ScanOptions) OnlyCategories(categories []string) []string {
+	for _, category := range Categories {
+		o.setCategory(category.Name, false)
+	}
+
+	var unknown []string
+	for _, category := range categories {
+		if !o.setCategory(category, true) {
+			unknown = append(unknown, category)
+		}
+	}
+	return unknown
+}
+
+// CategoryEnabled reports whether the named category is scanned
+func (o *ScanOptions) CategoryEnabled(category string) bool {
+	field := o.categoryField(category)
+	return field != nil && *field
+}
+
+// ExcludeCategories switches off the named categories (--exclude-type),
+// returning the names it does not know
+func (o *ScanOptions) ExcludeCategories(categories []string) []string {
+	var unknown []string
+	for _, category := range categories {
+		if !o.setCategory(category, false) {
+			unknown = append(unknown, category)
+		}
+	}
+	return unknown
+}
+
+// setCategory turns a category on or off by its settings name or alias,
+// reporting whether the name is known
+func (o *ScanOptions) setCategory(category string, on bool) bool {
+	field := o.categoryField(category)
+	if field == nil {
+		return false
+	}
+	*field = on
+	return true
+}
+
+// categoryField returns the Include flag of a category by its settings
+// name or alias, or nil when the name is unknown
+func (o *ScanOptions) categoryField(category string) *bool {
+	switch strings.ToLower(strings.TrimSpace(category)) {
+	case "xcode", "ios":
+		return &o.IncludeXcode
+	case "android":
+		return &o.IncludeAndroid
+	case "node":
+		return &o.IncludeNode
+	case "react-native", "rn":
+		return &o.IncludeReactNative
+	case "flutter":
+		return &o.IncludeFlutter
+	case "python":
+		return &o.IncludePython
+	case "rust":
+		return &o.IncludeRust
+	case "go":
+		return &o.IncludeGo
+	case "homebrew":
+		return &o.IncludeHomebrew
+	case "docker":
+		return &o.IncludeDocker
+	case "java":
+		return &o.IncludeJava
+	case "deno":
+		return &o.IncludeDeno
+	case "dotnet", "unity":
+		return &o.IncludeDotNet
+	case "php", "composer":
+		return &o.IncludePHP
+	case "elixir", "erlang":
+		return &o.IncludeElixir
+	case "haskell", "stack", "cabal":
+		return &o.IncludeHaskell
+	case "jetbrains", "intellij":
+		return &o.IncludeJetBrains
 	}
+	return nil
 }
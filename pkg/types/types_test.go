@@ -0,0 +1,85 @@
+package types
+
+import "testing"
+
+func TestScanOptionsForCategories(t *testing.T) {
+	opts, unknown := ScanOptionsForCategories([]string{"node", " Xcode ", "rn", "cobol"})
+
+	if !opts.IncludeNode || !opts.IncludeXcode || !opts.IncludeReactNative {
+		t.Errorf("named categories not enabled: %+v", opts)
+	}
+	if opts.IncludeAndroid || opts.IncludeDocker || opts.IncludeDotNet {
+		t.Errorf("unnamed categories enabled: %+v", opts)
+	}
+	if opts.ProjectSearchDepth != DefaultProjectSearchDepth || opts.MaxDepth != DefaultTreeDepth {
+		t.Errorf("ProjectSearchDepth, MaxDepth = %d, %d; want defaults", opts.ProjectSearchDepth, opts.MaxDepth)
+	}
+	if len(unknown) != 1 || unknown[0] != "cobol" {
+		t.Errorf("unknown = %v, want [cobol]", unknown)
+	}
+}
+
+func TestExcludeCategories(t *testing.T) {
+	opts := DefaultScanOptions()
+	unknown := opts.ExcludeCategories([]string{"docker", "Unity", "cobol"})
+
+	if opts.IncludeDocker || opts.IncludeDotNet {
+		t.Errorf("excluded categories still enabled: %+v", opts)
+	}
+	if !opts.IncludeNode || !opts.IncludeXcode || !opts.IncludeElixir {
+		t.Errorf("other categories disabled: %+v", opts)
+	}
+	if len(unknown) != 1 || unknown[0] != "cobol" {
+		t.Errorf("unknown = %v, want [cobol]", unknown)
+	}
+}
+
+func TestScanResultTier(t *testing.T) {
+	tests := []struct {
+		result ScanResult
+		want   SafetyTier
+	}{
+		{ScanResult{SafetyTier: TierSafe}, TierSafe},
+		{ScanResult{SafetyTier: TierInactive}, TierInactive},
+		{ScanResult{SafetyTier: TierInactive, Risky: true}, TierReview},
+		{ScanResult{}, TierReview},
+	}
+
+	for _, tt := range tests {
+		if got := tt.result.Tier(); got != tt.want {
+			t.Errorf("%+v.Tier() = %q, want %q", tt.result, got, tt.want)
+		}
+	}
+}
+
+func TestIsPseudoPath(t *testing.T) {
+	for path, want := range map[string]bool{
+		"docker:images":       true,
+		"simctl:unavailable":  true,
+		"/Users/me/.npm":      false,
+		"docker-data/volumes": false,
+	} {
+		if got := IsPseudoPath(path); got != want {
+			t.Errorf("IsPseudoPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestCategoryLabel(t *testing.T) {
+	if got := CategoryLabel(TypeNode); got != "📦 Node.js" {
+		t.Errorf("CategoryLabel(node) = %q, want 📦 Node.js", got)
+	}
+	if got := (ScanResult{Type: TypeXcode}).CategoryLabel(); got != "🍎 Xcode" {
+		t.Errorf("ScanResult.CategoryLabel() = %q, want 🍎 Xcode", got)
+	}
+	if got, color := CategoryLabel("zig"), CategoryColor("zig"); got != "zig" || color != "" {
+		t.Errorf("unknown type = %q %q, want its name without a color", got, color)
+	}
+
+	// Every scan category's types have a label
+	for _, category := range Categories {
+		if got := CategoryLabel(CleanTargetType(category.Name)); got == category.Name {
+			t.Errorf("category %s has no display label", category.Name)
+		}
+	}
+}